@@ -7,7 +7,6 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
-	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
 )
 
@@ -35,7 +34,7 @@ func CommitToPolynomials(polynomials []kzg.Polynomial, commitKey *kzg.CommitKey)
 }
 
 // Modified function from gnark
-func BatchOpenSinglePoint(domain *kzg.Domain, polynomials []kzg.Polynomial, commitKey *kzg.CommitKey) (*BatchOpeningProof, error) {
+func (p Protocol) BatchOpenSinglePoint(domain *kzg.Domain, polynomials []kzg.Polynomial, commitKey *kzg.CommitKey) (*BatchOpeningProof, error) {
 	// 1. Commit to polynomials
 	//
 	commitments, err := CommitToPolynomials(polynomials, commitKey)
@@ -43,21 +42,31 @@ func BatchOpenSinglePoint(domain *kzg.Domain, polynomials []kzg.Polynomial, comm
 		return nil, err
 	}
 
+	return p.BatchOpenSinglePointWithCommitments(domain, polynomials, commitments, commitKey)
+}
+
+// Same as BatchOpenSinglePoint, except that the caller supplies the commitments to the polynomials.
+// This is useful when the caller has already committed to the polynomials, and
+// avoids recomputing the commitments.
+//
+// Note: the commitments are not checked against the polynomials, if they are incorrect
+// then the proof will not verify.
+func (p Protocol) BatchOpenSinglePointWithCommitments(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, commitKey *kzg.CommitKey) (*BatchOpeningProof, error) {
 	// 2. Correctness checks on polynomials and commitments
 	//
-	err = correctnessChecks(domain, polynomials, commitments)
+	err := correctnessChecks(domain, polynomials, commitments)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Compute the challenges needed. This is one round protocol, so all challenges to be computed
 	// are done here
-	vandermondeChallenges, evaluationChallenge := computeChallenges(commitments, polynomials)
+	vandermondeChallenges, evaluationChallenge := p.computeChallenges(commitments, polynomials)
 
 	// 4. Aggregate the polynomials using powers of the first challenge generated
 	//
 	// The prover does not need to compute the aggregated commitment like the verifier does
-	foldedPoly, err := foldPolynomials(polynomials, vandermondeChallenges)
+	foldedPoly, err := FoldPolynomials(polynomials, vandermondeChallenges, p.FoldWorkers)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +86,7 @@ func BatchOpenSinglePoint(domain *kzg.Domain, polynomials []kzg.Polynomial, comm
 	}, nil
 }
 
-func VerifyBatchOpen(domain *kzg.Domain, polynomials []kzg.Polynomial, proof *BatchOpeningProof, open_key *kzg.OpeningKey) error {
+func (p Protocol) VerifyBatchOpen(domain *kzg.Domain, polynomials []kzg.Polynomial, proof *BatchOpeningProof, open_key *kzg.OpeningKey) error {
 	// 1. Correctness checks on polynomials and commitments
 	//
 	err := correctnessChecks(domain, polynomials, proof.Commitments)
@@ -87,14 +96,14 @@ func VerifyBatchOpen(domain *kzg.Domain, polynomials []kzg.Polynomial, proof *Ba
 
 	// 2. Compute the challenges needed. This is one round protocol, so all challenges to be computed
 	// are done here
-	vandermondeChallenges, evaluationChallenge := computeChallenges(proof.Commitments, polynomials)
+	vandermondeChallenges, evaluationChallenge := p.computeChallenges(proof.Commitments, polynomials)
 
 	// 3. Aggregate the polynomials and commitments using powers of the first challenge generated
-	foldedPoly, err := foldPolynomials(polynomials, vandermondeChallenges)
+	foldedPoly, err := FoldPolynomials(polynomials, vandermondeChallenges, p.FoldWorkers)
 	if err != nil {
 		return err
 	}
-	foldedComm, err := foldCommitments(proof.Commitments, vandermondeChallenges)
+	foldedComm, err := FoldCommitments(proof.Commitments, vandermondeChallenges)
 	if err != nil {
 		return err
 	}
@@ -115,8 +124,76 @@ func VerifyBatchOpen(domain *kzg.Domain, polynomials []kzg.Polynomial, proof *Ba
 	return kzg.Verify(foldedComm, openingProof, open_key)
 }
 
-func computeChallenges(points []curve.G1Affine, polynomials [][]fr.Element) ([]fr.Element, fr.Element) {
-	transcript := fiatshamir.NewTranscript(DOM_SEP_PROTOCOL)
+// Recomputes the challenges for a batch opening proof, recording the transcript.
+// This is intended for debugging challenges which differ from another implementation
+func (p Protocol) TraceChallenges(commitments []kzg.Commitment, polynomials []kzg.Polynomial) *fiatshamir.Trace {
+	trace := &fiatshamir.Trace{}
+	p.computeChallengesWithTrace(commitments, polynomials, trace)
+	return trace
+}
+
+// Verifies many proofs which were each created by BatchOpenSinglePoint over a single polynomial,
+// such as blob proofs.
+//
+// The i'th proof is for polynomials[i] and commitments[i]. Rather than verifying each proof
+// separately, the openings are checked together with kzg.BatchVerifyOpeningProofs.
+func (p Protocol) BatchVerifySinglePolyProofs(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, quotientComms []curve.G1Affine, openKey *kzg.OpeningKey) error {
+	n := len(polynomials)
+	if len(commitments) != n || len(quotientComms) != n {
+		return kzg.ErrBatchVerifyLengthMismatch
+	}
+
+	// 1. Compute the point that each polynomial was opened at, and its evaluation there
+	points := make([]fr.Element, n)
+	values := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		point, value, err := p.SinglePolyOpeningClaim(domain, polynomials[i], commitments[i])
+		if err != nil {
+			return err
+		}
+		points[i] = point
+		values[i] = value
+	}
+
+	// 2. Verify all of the openings at once
+	return kzg.BatchVerifyOpeningProofs(commitments, points, values, quotientComms, openKey)
+}
+
+// Returns the point that a proof from BatchOpenSinglePoint over a single polynomial opens the
+// polynomial at, and the evaluation of the polynomial at that point.
+//
+// The proof is then an ordinary KZG opening proof for this claim. This lets callers compute
+// the claims for many proofs in parallel, before verifying them with kzg.BatchVerifyOpeningProofs.
+func (p Protocol) SinglePolyOpeningClaim(domain *kzg.Domain, polynomial kzg.Polynomial, commitment kzg.Commitment) (fr.Element, fr.Element, error) {
+	polys := []kzg.Polynomial{polynomial}
+	comms := []kzg.Commitment{commitment}
+
+	// 1. Correctness checks on the polynomial and commitment
+	err := correctnessChecks(domain, polys, comms)
+	if err != nil {
+		return fr.Element{}, fr.Element{}, err
+	}
+
+	// 2. Compute the evaluation challenge. With a single polynomial, the first challenge
+	// is not needed since the folded polynomial is the polynomial itself
+	_, evaluationChallenge := p.computeChallenges(comms, polys)
+
+	// 3. Evaluate the polynomial at the challenge
+	outputPoint, err := kzg.EvaluateLagrangePolynomial(domain, polynomial, evaluationChallenge)
+	if err != nil {
+		return fr.Element{}, fr.Element{}, err
+	}
+
+	return evaluationChallenge, *outputPoint, nil
+}
+
+func (p Protocol) computeChallenges(points []curve.G1Affine, polynomials [][]fr.Element) ([]fr.Element, fr.Element) {
+	return p.computeChallengesWithTrace(points, polynomials, nil)
+}
+
+// A nil trace disables recording
+func (p Protocol) computeChallengesWithTrace(points []curve.G1Affine, polynomials [][]fr.Element, trace *fiatshamir.Trace) ([]fr.Element, fr.Element) {
+	transcript := fiatshamir.NewTranscriptWithHash(DOM_SEP_PROTOCOL, p.NewHash, trace)
 	transcript.AppendPointsPolys(points, polynomials)
 
 	// Generate two challenges:
@@ -129,7 +206,8 @@ func computeChallenges(points []curve.G1Affine, polynomials [][]fr.Element) ([]f
 	evaluationChallenge := challenges[1]
 
 	numPolynomials := uint(len(polynomials))
-	vandermondeChallenges := utils.ComputePowers(linearCombinationChallenge, numPolynomials)
+	// The powers are computed once here and reused to fold both the polynomials and the commitments
+	vandermondeChallenges := utils.ComputePowersParallel(linearCombinationChallenge, numPolynomials)
 
 	return vandermondeChallenges, evaluationChallenge
 }
@@ -163,46 +241,3 @@ func correctnessChecks(domain *kzg.Domain, polynomials []kzg.Polynomial, digests
 
 	return nil
 }
-
-func foldPolynomials(polynomials []kzg.Polynomial, challenges []fr.Element) (kzg.Polynomial, error) {
-	numPolynomials := len(polynomials)
-	numChallenges := len(challenges)
-
-	if numPolynomials != numChallenges {
-		return nil, errors.New("number of polynomials is different to the number of challenges provided")
-	}
-
-	result := make(kzg.Polynomial, len(polynomials[0]))
-	// This copy assumes that the first challenge is 1
-	// TODO: can add an assert here, which may be fine because if this is changed
-	// TODO: it will break tests at compile time
-	copy(result, polynomials[0])
-
-	var pj fr.Element
-	for i := 1; i < numPolynomials; i++ {
-		for j := 0; j < len(result); j++ {
-			pj.Mul(&polynomials[i][j], &challenges[i])
-			result[j].Add(&result[j], &pj)
-		}
-	}
-
-	return result, nil
-}
-
-// Note: We can compute this aggregate commitment by committing to the aggregate poly
-// or doing a linear combination of the individual polynomial commitments
-// The first will be a MSM where the size is the length of the largest polynomial
-// The second will be an MSM where the size is the number of polynomials
-// The second will therefore be cheaper in all cases for the usage of this lib
-func foldCommitments(commitments []kzg.Commitment, challenges []fr.Element) (*kzg.Commitment, error) {
-	if len(commitments) != len(challenges) {
-		return nil, errors.New("incorrect number of commitments or challenges")
-	}
-
-	foldedComm, err := multiexp.MultiExp(challenges, commitments)
-	if err != nil {
-		return nil, err
-	}
-
-	return foldedComm, nil
-}
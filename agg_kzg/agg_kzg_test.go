@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
 )
@@ -27,3 +28,57 @@ func TestProofVerifySmoke(t *testing.T) {
 		t.Errorf(err.Error())
 	}
 }
+
+func TestBatchOpenWithCommitmentsMatches(t *testing.T) {
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly_a := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	polys := []kzg.Polynomial{poly_a}
+
+	expected, err := BatchOpenSinglePoint(domain, polys, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comms, _ := CommitToPolynomials(polys, &srs.CommitKey)
+	got, err := BatchOpenSinglePointWithCommitments(domain, polys, comms, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.QuotientComm.Equal(&expected.QuotientComm) {
+		t.Error("proof with precomputed commitments does not match")
+	}
+}
+
+func TestBatchVerifySinglePolyProofs(t *testing.T) {
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly_a := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	poly_b := []fr.Element{fr.NewElement(1), fr.NewElement(4), fr.NewElement(1), fr.NewElement(6)}
+	polys := []kzg.Polynomial{poly_a, poly_b}
+
+	comms := make([]kzg.Commitment, len(polys))
+	quotientComms := make([]curve.G1Affine, len(polys))
+	for i, poly := range polys {
+		proof, err := BatchOpenSinglePoint(domain, []kzg.Polynomial{poly}, &srs.CommitKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		comms[i] = proof.Commitments[0]
+		quotientComms[i] = proof.QuotientComm
+	}
+
+	err := BatchVerifySinglePolyProofs(domain, polys, comms, quotientComms, &srs.OpeningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Swapping the proofs should fail
+	quotientComms[0], quotientComms[1] = quotientComms[1], quotientComms[0]
+	err = BatchVerifySinglePolyProofs(domain, polys, comms, quotientComms, &srs.OpeningKey)
+	if err == nil {
+		t.Error("proofs for the wrong polynomials should not verify")
+	}
+}
@@ -2,3 +2,6 @@ package agg_kzg
 
 // Domain Separator to identify the protocol
 const DOM_SEP_PROTOCOL = "FSBLOBVERIFY_V1_"
+
+// Domain Separator to identify the payload opening protocol
+const DOM_SEP_PAYLOAD = "FSPAYLOADOPEN_V1"
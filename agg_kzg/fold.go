@@ -0,0 +1,108 @@
+package agg_kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var (
+	ErrFoldLengthMismatch            = errors.New("number of polynomials is different to the number of challenges provided")
+	ErrFoldCommitmentsLengthMismatch = errors.New("number of commitments is different to the number of challenges provided")
+)
+
+// Computes \sum challenges[i] * polynomials[i] using `numWorkers` goroutines.
+// A number of workers of zero or less uses the number of CPUs.
+//
+// Each worker folds a contiguous range of the polynomials into its own partial sum,
+// and the partial sums are then added together. The polynomials are not modified.
+func FoldPolynomials(polynomials []kzg.Polynomial, challenges []fr.Element, numWorkers int) (kzg.Polynomial, error) {
+	return foldPolynomialsWithWorkers(polynomials, challenges, numWorkers, false)
+}
+
+// Same as FoldPolynomials, except that the polynomials are used as scratch space instead of
+// allocating a partial sum for each worker. The result is stored in the first polynomial
+// and the other polynomials are overwritten, so they must not be used afterwards.
+func FoldPolynomialsInPlace(polynomials []kzg.Polynomial, challenges []fr.Element, numWorkers int) (kzg.Polynomial, error) {
+	return foldPolynomialsWithWorkers(polynomials, challenges, numWorkers, true)
+}
+
+func foldPolynomialsWithWorkers(polynomials []kzg.Polynomial, challenges []fr.Element, numWorkers int, consume bool) (kzg.Polynomial, error) {
+	numPolynomials := len(polynomials)
+	if numPolynomials != len(challenges) {
+		return nil, ErrFoldLengthMismatch
+	}
+	if numPolynomials == 0 {
+		return nil, errors.New("cannot fold zero polynomials")
+	}
+	polySize := len(polynomials[0])
+	for i := 1; i < numPolynomials; i++ {
+		if len(polynomials[i]) != polySize {
+			return nil, errors.New("all polynomials must be the same length")
+		}
+	}
+
+	// 1. Each worker folds its range of polynomials into a partial sum, which is stored at the start of the range
+	partialSums := make([]kzg.Polynomial, numPolynomials)
+	utils.ParallelForWithGoroutines(numPolynomials, numWorkers, func(start, end int) {
+		partialSums[start] = foldRange(polynomials[start:end], challenges[start:end], consume)
+	})
+
+	// 2. Add the partial sums together
+	result := partialSums[0]
+	for i := 1; i < len(partialSums); i++ {
+		if partialSums[i] == nil {
+			continue
+		}
+		for j := 0; j < polySize; j++ {
+			result[j].Add(&result[j], &partialSums[i][j])
+		}
+	}
+	return result, nil
+}
+
+// Computes \sum challenges[i] * commitments[i], which is the commitment to the polynomials
+// folded with the same challenges.
+//
+// Note: We can compute this aggregate commitment by committing to the aggregate poly
+// or doing a linear combination of the individual polynomial commitments
+// The first will be a MSM where the size is the length of the largest polynomial
+// The second will be an MSM where the size is the number of polynomials
+// The second will therefore be cheaper in all cases for the usage of this lib
+func FoldCommitments(commitments []kzg.Commitment, challenges []fr.Element) (*kzg.Commitment, error) {
+	if len(commitments) != len(challenges) {
+		return nil, ErrFoldCommitmentsLengthMismatch
+	}
+	if len(commitments) == 0 {
+		return nil, errors.New("cannot fold zero commitments")
+	}
+
+	return multiexp.MultiExp(challenges, commitments)
+}
+
+// Folds the polynomials into a single partial sum. If `consume` is true, the partial sum is
+// stored in the first polynomial, otherwise it is newly allocated
+func foldRange(polynomials []kzg.Polynomial, challenges []fr.Element, consume bool) kzg.Polynomial {
+	partialSum := polynomials[0]
+	if !consume {
+		partialSum = make(kzg.Polynomial, len(polynomials[0]))
+		copy(partialSum, polynomials[0])
+	}
+	if !challenges[0].IsOne() {
+		for j := 0; j < len(partialSum); j++ {
+			partialSum[j].Mul(&partialSum[j], &challenges[0])
+		}
+	}
+
+	var tmp fr.Element
+	for i := 1; i < len(polynomials); i++ {
+		for j := 0; j < len(partialSum); j++ {
+			tmp.Mul(&polynomials[i][j], &challenges[i])
+			partialSum[j].Add(&partialSum[j], &tmp)
+		}
+	}
+	return partialSum
+}
@@ -0,0 +1,120 @@
+package agg_kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func testPolynomials(numPolys, polySize int) []kzg.Polynomial {
+	polys := make([]kzg.Polynomial, numPolys)
+	for i := range polys {
+		polys[i] = make(kzg.Polynomial, polySize)
+		for j := range polys[i] {
+			polys[i][j].SetUint64(uint64(i*polySize + j + 1))
+		}
+	}
+	return polys
+}
+
+func copyPolynomials(polys []kzg.Polynomial) []kzg.Polynomial {
+	copied := make([]kzg.Polynomial, len(polys))
+	for i := range polys {
+		copied[i] = append(kzg.Polynomial{}, polys[i]...)
+	}
+	return copied
+}
+
+func TestFoldPolynomials(t *testing.T) {
+	polys := testPolynomials(7, 4)
+	challenges := make([]fr.Element, len(polys))
+	for i := range challenges {
+		challenges[i].SetUint64(uint64(3*i + 2))
+	}
+
+	// \sum challenges[i] * polys[i]
+	expected := make(kzg.Polynomial, 4)
+	var tmp fr.Element
+	for i := range polys {
+		for j := range expected {
+			tmp.Mul(&polys[i][j], &challenges[i])
+			expected[j].Add(&expected[j], &tmp)
+		}
+	}
+
+	for _, numWorkers := range []int{0, 1, 2, 3, 7, 16} {
+		inputs := copyPolynomials(polys)
+		folded, err := FoldPolynomials(inputs, challenges, numWorkers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range expected {
+			if folded[j] != expected[j] {
+				t.Fatalf("folding with %d workers is incorrect", numWorkers)
+			}
+		}
+		for i := range polys {
+			for j := range polys[i] {
+				if inputs[i][j] != polys[i][j] {
+					t.Fatalf("folding with %d workers modified the polynomials", numWorkers)
+				}
+			}
+		}
+
+		folded, err = FoldPolynomialsInPlace(copyPolynomials(polys), challenges, numWorkers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range expected {
+			if folded[j] != expected[j] {
+				t.Fatalf("folding in place with %d workers is incorrect", numWorkers)
+			}
+		}
+	}
+}
+
+func TestFoldPolynomialsInvalidInputs(t *testing.T) {
+	polys := testPolynomials(2, 4)
+	if _, err := FoldPolynomials(polys, make([]fr.Element, 3), 1); err != ErrFoldLengthMismatch {
+		t.Error("expected an error for a different number of challenges")
+	}
+	if _, err := FoldPolynomials(nil, nil, 1); err == nil {
+		t.Error("expected an error when folding no polynomials")
+	}
+	polys[1] = polys[1][:3]
+	if _, err := FoldPolynomials(polys, make([]fr.Element, 2), 1); err == nil {
+		t.Error("expected an error for polynomials of different lengths")
+	}
+}
+
+func TestFoldCommitments(t *testing.T) {
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, big.NewInt(1234))
+
+	polys := testPolynomials(3, 4)
+	comms, err := CommitToPolynomials(polys, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenges := []fr.Element{fr.NewElement(1), fr.NewElement(5), fr.NewElement(25)}
+
+	// Folding the commitments should give the commitment to the folded polynomial
+	foldedComm, err := FoldCommitments(comms, challenges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foldedPoly, _ := FoldPolynomials(polys, challenges, 1)
+	expected, _ := kzg.Commit(foldedPoly, &srs.CommitKey)
+	if !foldedComm.Equal(expected) {
+		t.Error("folded commitment is not the commitment to the folded polynomial")
+	}
+
+	if _, err := FoldCommitments(comms, challenges[:2]); err != ErrFoldCommitmentsLengthMismatch {
+		t.Error("expected an error for a different number of challenges")
+	}
+	if _, err := FoldCommitments(nil, nil); err == nil {
+		t.Error("expected an error when folding no commitments")
+	}
+}
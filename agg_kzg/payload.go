@@ -0,0 +1,89 @@
+package agg_kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Proof that the prover knows the polynomials behind a list of commitments,
+// such as the blobs of a payload which spans multiple blobs.
+//
+// Unlike BatchOpeningProof, the challenges only depend on the commitments, so the
+// verifier does not need the polynomials. Instead the proof carries the evaluation
+// of the folded polynomial \sum r^i f_i(x) at the challenge point.
+type PayloadOpeningProof struct {
+	// Commitment to the quotient of the folded polynomial
+	QuotientComm curve.G1Affine
+	// Evaluation of the folded polynomial at the challenge point
+	ClaimedValue fr.Element
+}
+
+func (p Protocol) OpenPayload(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, commitKey *kzg.CommitKey) (*PayloadOpeningProof, error) {
+	// 1. Correctness checks on polynomials and commitments
+	//
+	err := correctnessChecks(domain, polynomials, commitments)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Compute the challenges from the commitments
+	vandermondeChallenges, evaluationChallenge := p.computePayloadChallenges(commitments)
+
+	// 3. Aggregate the polynomials using powers of the first challenge
+	foldedPoly, err := FoldPolynomials(polynomials, vandermondeChallenges, p.FoldWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Open the aggregated polynomial at the evaluation challenge
+	openingProof, err := kzg.Open(domain, foldedPoly, evaluationChallenge, commitKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayloadOpeningProof{
+		QuotientComm: openingProof.QuotientComm,
+		ClaimedValue: openingProof.ClaimedValue,
+	}, nil
+}
+
+func (p Protocol) VerifyPayloadOpening(commitments []kzg.Commitment, proof *PayloadOpeningProof, openKey *kzg.OpeningKey) error {
+	if len(commitments) == 0 {
+		return errors.New("cannot verify a payload opening proof with no commitments")
+	}
+
+	// 1. Compute the challenges from the commitments
+	vandermondeChallenges, evaluationChallenge := p.computePayloadChallenges(commitments)
+
+	// 2. Aggregate the commitments using powers of the first challenge
+	foldedComm, err := FoldCommitments(commitments, vandermondeChallenges)
+	if err != nil {
+		return err
+	}
+
+	// 3. Verify the KZG opening proof of the aggregated commitment
+	openingProof := &kzg.OpeningProof{
+		QuotientComm: proof.QuotientComm,
+		InputPoint:   evaluationChallenge,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	return kzg.Verify(foldedComm, openingProof, openKey)
+}
+
+func (p Protocol) computePayloadChallenges(commitments []kzg.Commitment) ([]fr.Element, fr.Element) {
+	transcript := fiatshamir.NewTranscriptWithHash(DOM_SEP_PAYLOAD, p.NewHash, nil)
+	transcript.AppendPoints(commitments)
+
+	challenges := transcript.ChallengeScalars(2)
+	linearCombinationChallenge := challenges[0]
+	evaluationChallenge := challenges[1]
+
+	vandermondeChallenges := utils.ComputePowersParallel(linearCombinationChallenge, uint(len(commitments)))
+
+	return vandermondeChallenges, evaluationChallenge
+}
@@ -0,0 +1,34 @@
+package agg_kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func TestPayloadOpeningSmoke(t *testing.T) {
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly_a := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	poly_b := []fr.Element{fr.NewElement(1), fr.NewElement(4), fr.NewElement(1), fr.NewElement(6)}
+	polys := []kzg.Polynomial{poly_a, poly_b}
+
+	comms, _ := CommitToPolynomials(polys, &srs.CommitKey)
+	proof, err := OpenPayload(domain, polys, comms, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyPayloadOpening(comms, proof, &srs.OpeningKey); err != nil {
+		t.Error(err)
+	}
+
+	// Swapping the commitments changes the challenges
+	swapped := []kzg.Commitment{comms[1], comms[0]}
+	if err := VerifyPayloadOpening(swapped, proof, &srs.OpeningKey); err == nil {
+		t.Error("proof should not verify for reordered commitments")
+	}
+}
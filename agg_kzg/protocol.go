@@ -0,0 +1,48 @@
+package agg_kzg
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+// Configures the Fiat-Shamir transform used to compute the challenges,
+// and how the polynomials are folded.
+//
+// The zero value uses SHA-256, as required by Ethereum, and is what the package
+// level functions use. A different hash is only for deployments outside of Ethereum,
+// since proofs made with it will not verify with the SHA-256 protocol.
+type Protocol struct {
+	// Creates the hash used for the transcript, nil means SHA-256
+	NewHash fiatshamir.HashFunc
+	// Number of goroutines used to fold the polynomials, zero means the number of CPUs
+	FoldWorkers int
+}
+
+func BatchOpenSinglePoint(domain *kzg.Domain, polynomials []kzg.Polynomial, commitKey *kzg.CommitKey) (*BatchOpeningProof, error) {
+	return Protocol{}.BatchOpenSinglePoint(domain, polynomials, commitKey)
+}
+
+func BatchOpenSinglePointWithCommitments(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, commitKey *kzg.CommitKey) (*BatchOpeningProof, error) {
+	return Protocol{}.BatchOpenSinglePointWithCommitments(domain, polynomials, commitments, commitKey)
+}
+
+func VerifyBatchOpen(domain *kzg.Domain, polynomials []kzg.Polynomial, proof *BatchOpeningProof, openKey *kzg.OpeningKey) error {
+	return Protocol{}.VerifyBatchOpen(domain, polynomials, proof, openKey)
+}
+
+func TraceChallenges(commitments []kzg.Commitment, polynomials []kzg.Polynomial) *fiatshamir.Trace {
+	return Protocol{}.TraceChallenges(commitments, polynomials)
+}
+
+func BatchVerifySinglePolyProofs(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, quotientComms []curve.G1Affine, openKey *kzg.OpeningKey) error {
+	return Protocol{}.BatchVerifySinglePolyProofs(domain, polynomials, commitments, quotientComms, openKey)
+}
+
+func OpenPayload(domain *kzg.Domain, polynomials []kzg.Polynomial, commitments []kzg.Commitment, commitKey *kzg.CommitKey) (*PayloadOpeningProof, error) {
+	return Protocol{}.OpenPayload(domain, polynomials, commitments, commitKey)
+}
+
+func VerifyPayloadOpening(commitments []kzg.Commitment, proof *PayloadOpeningProof, openKey *kzg.OpeningKey) error {
+	return Protocol{}.VerifyPayloadOpening(commitments, proof, openKey)
+}
@@ -2,11 +2,23 @@ package context
 
 import (
 	"bytes"
+	"errors"
+)
+
+var (
+	ErrInvalidBlobBytesSize  = errors.New("blob is not the expected number of bytes")
+	ErrInvalidPointBytesSize = errors.New("serialised G1 point is not 48 bytes")
 )
 
 // Alternative API that may make it easier for clients to do less work
 
-func (c *Context) ComputeAggregateKzgProofAlt(serPolys [][]byte, polySize uint) (KZGProof, SerialisedCommitments, error) {
+func (c *Context) ComputeAggregateKzgProofAlt(serPolys [][]byte, polySize uint) (_ KZGProof, _ SerialisedCommitments, err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return KZGProof{}, nil, err
+	}
+
 	polys, err := deserialisePolysBytes(serPolys, polySize)
 	if err != nil {
 		return KZGProof{}, nil, err
@@ -15,7 +27,13 @@ func (c *Context) ComputeAggregateKzgProofAlt(serPolys [][]byte, polySize uint)
 	return c.ComputeAggregateKzgProof(polys)
 }
 
-func (c *Context) VerifyAggregateKzgProofAlt(serPolysFlat [][]byte, polySize uint, serProof KZGProof, serCommsFlat [][]byte) error {
+func (c *Context) VerifyAggregateKzgProofAlt(serPolysFlat [][]byte, polySize uint, serProof KZGProof, serCommsFlat [][]byte) (err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkBatchSize(len(serPolysFlat)); err != nil {
+		return err
+	}
+
 	polys, err := deserialisePolysBytes(serPolysFlat, polySize)
 	if err != nil {
 		return err
@@ -29,6 +47,52 @@ func (c *Context) VerifyAggregateKzgProofAlt(serPolysFlat [][]byte, polySize uin
 
 }
 
+// Same as VerifyBlobKZGProofBatch, except that the blobs, commitments and proofs are raw bytes.
+// Each blob must be the concatenation of its scalars and each commitment and proof
+// must be a 48 byte compressed G1 point.
+//
+// Unlike the other methods in this file, the lengths are checked exactly, so a short or
+// long input is rejected rather than partially read.
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) VerifyBlobKZGProofBatchBytes(blobs [][]byte, commitments [][]byte, proofs [][]byte) (err error) {
+	defer c.recoverPanic(&err)
+
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return ErrBlobBatchLengthMismatch
+	}
+	if err := c.checkBatchSize(len(blobs)); err != nil {
+		return err
+	}
+
+	// 1. Split each blob into its scalars
+	blobSize := int(c.params.BytesPerBlob())
+	scalarSize := int(c.params.BytesPerFieldElement)
+	serPolys := make([]SerialisedPoly, len(blobs))
+	for i, blob := range blobs {
+		if len(blob) != blobSize {
+			return ErrInvalidBlobBytesSize
+		}
+		serPoly := make(SerialisedPoly, c.params.FieldElementsPerBlob)
+		for j := 0; j < len(serPoly); j++ {
+			serPoly[j] = blob[j*scalarSize : (j+1)*scalarSize]
+		}
+		serPolys[i] = serPoly
+	}
+
+	// 2. Check the size of the commitments and the proofs
+	serComms := make(SerialisedCommitments, len(commitments))
+	kzgProofs := make([]KZGProof, len(proofs))
+	for i := range commitments {
+		if len(commitments[i]) != serialisedG1Size || len(proofs[i]) != serialisedG1Size {
+			return ErrInvalidPointBytesSize
+		}
+		serComms[i] = commitments[i]
+		kzgProofs[i] = proofs[i]
+	}
+
+	return c.VerifyBlobKZGProofBatch(serPolys, serComms, kzgProofs)
+}
+
 // polySize is the degree of the polynomial
 func deserialisePolysBytes(serPolys [][]byte, polySize uint) ([]SerialisedPoly, error) {
 	numPolys := len(serPolys)
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
 )
 
@@ -16,6 +19,54 @@ type Context struct {
 	domain    *kzg.Domain
 	commitKey *kzg.CommitKey
 	openKey   *kzg.OpeningKey
+	// Only needed for proofs which commit to polynomials in coefficient form, use monomial()
+	monomialSRS *kzg.MonomialSRS
+	// Derives the monomial G1 points the first time that they are needed.
+	// This is nil unless the setup only contained lagrange points
+	deriveMonomialG1 *sync.Once
+	// FK20 preprocessing of the monomial SRS for computing cell proofs, use fk20Table().
+	// This is nil if the Context does not support cells
+	fk20 *lazyFK20Table
+
+	logger Logger
+	// Re-panic instead of converting internal panics into errors
+	debugPanics bool
+
+	limits Limits
+
+	// Cross check commitments against the monomial SRS
+	crossCheckCommitments bool
+
+	// Compare results against a second implementation, nil if disabled or unavailable
+	differentialCheck bool
+	differential      differentialBackend
+
+	// Records Fiat-Shamir transcripts, nil if disabled
+	transcriptTracer *transcriptTracer
+	// Deserialised commitments, nil if disabled
+	commitmentCache *commitmentCache
+	// Blob proofs which have been verified successfully, nil if disabled
+	verifiedProofs *verifiedProofCache
+	// Bounds the number of heavy operations running at once, nil if unbounded
+	cpuBudget *CPUBudget
+	// Priority of the operations waiting for the CPU budget
+	priority Priority
+
+	// Fiat-Shamir configuration for the aggregation protocols. This is SHA-256 unless the Context
+	// is inside of a ContextWithChallengeHash, there is deliberately no ContextOption which changes it
+	protocol agg_kzg.Protocol
+
+	// Maximum number of goroutines used for each MSM, zero means the number of CPUs
+	msmTasks int
+
+	// Sizes of blobs and cells
+	params Params
+
+	// Domain of size params.FieldElementsPerExtBlob() with its roots bit-reversed,
+	// the cells are made of the evaluations over this domain
+	extendedDomain *kzg.Domain
+	// Domain of size params.FieldElementsPerCell, nil if the polynomials are too small to be split into cells
+	cellDomain *kzg.Domain
 }
 
 // We could make this [32]byte and [48]byte respectively, but the idea is that the
@@ -29,6 +80,9 @@ type KZGProof = SerialisedG1Point
 type KZGCommitment = SerialisedG1Point
 type SerialisedCommitments = []SerialisedG1Point
 
+// Number of bytes in a SerialisedScalar
+const serialisedScalarSize = 32
+
 // These methods are used mainly for testing purposes.
 // One should not need to use the domain/commitKey/OpeningKey directly
 func (c *Context) Domain() kzg.Domain {
@@ -41,29 +95,123 @@ func (c *Context) OpenKeyKey() kzg.OpeningKey {
 	return *c.openKey
 }
 
-func NewContextInsecure(polyDegree int, trustedSetupSecret int) *Context {
-	secret := big.NewInt(int64(trustedSetupSecret))
-	domain := kzg.NewDomain(uint64(polyDegree))
+// Returns a copy of the G1 points in monomial basis : [α^i]G₁
+//
+// This is useful for SNARK circuits which verify KZG openings, since
+// their constants can be derived from the same setup.
+func (c *Context) MonomialG1Points() []curve.G1Affine {
+	monomialSRS := c.monomial()
+	points := make([]curve.G1Affine, len(monomialSRS.G1))
+	copy(points, monomialSRS.G1)
+	return points
+}
+
+// Returns [α]G₂ from the setup
+func (c *Context) AlphaG2() curve.G2Affine {
+	return c.openKey.AlphaG2
+}
 
-	srs, err := kzg.NewSRSInsecure(*domain, secret)
+func NewContextInsecure(polyDegree int, trustedSetupSecret int, opts ...ContextOption) *Context {
+	size := ecc.NextPowerOfTwo(uint64(polyDegree))
+	ctx, err := newContextInsecure(DefaultParams(size), trustedSetupSecret, opts)
 	if err != nil {
 		panic(fmt.Sprintf("could not create context %s", err))
 	}
+	return ctx
+}
+
+// Same as NewContextInsecure, except that the sizes of blobs and cells are taken from `params`.
+// DO NOT USE THIS METHOD IN PRODUCTION
+func NewContextInsecureWithParams(params Params, trustedSetupSecret int, opts ...ContextOption) (*Context, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return newContextInsecure(params, trustedSetupSecret, opts)
+}
+
+func newContextInsecure(params Params, trustedSetupSecret int, opts []ContextOption) (*Context, error) {
+	secret := big.NewInt(int64(trustedSetupSecret))
+
+	domains, err := kzg.NewDomainExtended(params.FieldElementsPerBlob, params.ExpansionFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	// The lagrange points are computed in natural order, whatever the order of the roots
+	srs, err := kzg.NewSRSInsecure(*domains.Original, secret)
+	if err != nil {
+		return nil, err
+	}
 
-	// Reverse the roots and the domain
+	monomialSRS, err := kzg.NewMonomialSRSInsecure(params.FieldElementsPerBlob, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reverse the points to match the roots of the domain
 	srs.CommitKey.ReversePoints()
-	domain.ReverseRoots()
 
-	return &Context{
-		domain:    domain,
-		commitKey: &srs.CommitKey,
-		openKey:   &srs.OpeningKey,
+	ctx, err := newContext(domains, srs, monomialSRS, params, opts)
+	if err != nil {
+		return nil, err
+	}
+	ctx.logger.Debug("created insecure context", "polyDegree", params.FieldElementsPerBlob)
+
+	return ctx, nil
+}
+
+// The params are assumed to be valid and to match the size of the domains, which are
+// created with kzg.NewDomainExtended so that the blob and cell domains share their roots
+func newContext(domains *kzg.ExtendedDomain, srs *kzg.SRS, monomialSRS *kzg.MonomialSRS, params Params, opts []ContextOption) (*Context, error) {
+	// The Miller loop lines for the opening key are computed once, instead of in every verification
+	srs.OpeningKey.Precompute()
+
+	ctx := &Context{
+		domain:         domains.Original,
+		extendedDomain: domains.Extended,
+		commitKey:      &srs.CommitKey,
+		openKey:        &srs.OpeningKey,
+		monomialSRS:    monomialSRS,
+		params:         params,
+		logger:         noopLogger{},
+	}
+
+	// The cell domain is derived from the extended domain, since its roots contain them
+	if params.CellsPerExtBlob() > 0 {
+		cellDomain, err := ctx.extendedDomain.SubDomain(params.FieldElementsPerCell)
+		if err != nil {
+			return nil, err
+		}
+		// Cosets are evaluated in natural order
+		cellDomain.ReverseRoots()
+		ctx.cellDomain = cellDomain
+		ctx.fk20 = &lazyFK20Table{}
+	}
+
+	for _, opt := range opts {
+		opt(ctx)
+	}
+
+	ctx.setupDifferentialBackend()
+
+	// A custom MSM backend takes precedence over the number of tasks
+	if ctx.msmTasks > 0 && ctx.commitKey.MultiExp == nil {
+		nbTasks := ctx.msmTasks
+		ctx.commitKey.MultiExp = func(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error) {
+			return multiexp.MultiExpWithTasks(scalars, points, nbTasks)
+		}
 	}
+	return ctx, nil
 }
 
 // Spec: compute_aggregate_kzg_proof
 // Note: We additionally return the commitments
-func (c *Context) ComputeAggregateKzgProof(serPolys []SerialisedPoly) (KZGProof, SerialisedCommitments, error) {
+func (c *Context) ComputeAggregateKzgProof(serPolys []SerialisedPoly) (_ KZGProof, _ SerialisedCommitments, err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return KZGProof{}, nil, err
+	}
 
 	// 1. Deserialise the polynomials
 	polys, err := deserialisePolys(serPolys)
@@ -71,13 +219,25 @@ func (c *Context) ComputeAggregateKzgProof(serPolys []SerialisedPoly) (KZGProof,
 		return KZGProof{}, nil, err
 	}
 
-	// 2. Create batch opening proof
-	proof, err := agg_kzg.BatchOpenSinglePoint(c.domain, polys, c.commitKey)
+	c.logger.Debug("computing aggregate proof", "numPolys", len(polys))
+
+	// 2. Commit to polynomials
+	comms, err := c.commitToPolynomials(polys)
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+
+	// 3. Create batch opening proof
+	var proof *agg_kzg.BatchOpeningProof
+	c.withCPUBudget(func() {
+		proof, err = c.protocol.BatchOpenSinglePointWithCommitments(c.domain, polys, comms, c.commitKey)
+	})
 	if err != nil {
 		return KZGProof{}, nil, err
 	}
+	c.traceTranscript(comms, polys)
 
-	// 3. Serialise points, so caller only needs to be concerned with
+	// 4. Serialise points, so caller only needs to be concerned with
 	// bytes
 	serComms := serialiseCommitments(proof.Commitments)
 	serProof := proof.QuotientComm.Bytes()
@@ -85,7 +245,15 @@ func (c *Context) ComputeAggregateKzgProof(serPolys []SerialisedPoly) (KZGProof,
 	return serProof[:], serComms, nil
 }
 
-func (c *Context) ComputeKzgProof(serPoly SerialisedPoly, inputPointBytes [32]byte) (KZGProof, SerialisedG1Point, [32]byte, error) {
+func (c *Context) ComputeKzgProof(serPoly SerialisedPoly, inputPointBytes [32]byte) (_ KZGProof, _ SerialisedG1Point, _ [32]byte, err error) {
+	defer c.recoverPanic(&err)
+
+	var differentialPoly SerialisedPoly
+	if differentialPolys := c.copyForDifferential([]SerialisedPoly{serPoly}); differentialPolys != nil {
+		differentialPoly = differentialPolys[0]
+	}
+	// deserialiseScalar reverses the input point in place
+	differentialInputPoint := inputPointBytes
 
 	// 1. Deserialise the polynomial
 
@@ -101,13 +269,16 @@ func (c *Context) ComputeKzgProof(serPoly SerialisedPoly, inputPointBytes [32]by
 	}
 
 	// 3. Commit to polynomial
-	comms, err := agg_kzg.CommitToPolynomials([]kzg.Polynomial{poly}, c.commitKey)
+	comms, err := c.commitToPolynomials([]kzg.Polynomial{poly})
 	if err != nil {
 		return nil, nil, [32]byte{}, err
 	}
 
 	//4. Create opening proof
-	openingProof, err := kzg.Open(c.domain, poly, inputPoint, c.commitKey)
+	var openingProof kzg.OpeningProof
+	c.withCPUBudget(func() {
+		openingProof, err = kzg.Open(c.domain, poly, inputPoint, c.commitKey)
+	})
 	if err != nil {
 		return nil, nil, [32]byte{}, err
 	}
@@ -125,10 +296,103 @@ func (c *Context) ComputeKzgProof(serPoly SerialisedPoly, inputPointBytes [32]by
 	claimedValueBytes := openingProof.ClaimedValue.Bytes()
 	utils.ReverseArray(&claimedValueBytes)
 
+	if differentialPoly != nil {
+		if err := c.checkKZGProofDifferential(differentialPoly, differentialInputPoint, serProof[:], claimedValueBytes); err != nil {
+			return nil, nil, [32]byte{}, err
+		}
+	}
+
 	return serProof[:], serComm[:], claimedValueBytes, nil
 }
 
-func (c *Context) VerifyKZGProof(polynomialKZG KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes [32]byte) error {
+// Computes the quotient polynomial (f(x) - y)/(x - z) in lagrange form, whose commitment is the
+// proof returned by ComputeKzgProof. `z` may be in the domain. This is intended for custom opening
+// flows and for debugging proofs which do not match another implementation.
+//
+// `y` is expected to be f(z), otherwise the result is not a polynomial of the expected degree.
+// Note: the polynomial is modified in place during deserialisation.
+func (c *Context) ComputeQuotientPoly(serPoly SerialisedPoly, inputPointBytes, claimedValueBytes [32]byte) (_ SerialisedPoly, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial and the scalars
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, err
+	}
+	inputPoint, err := deserialiseScalar(inputPointBytes[:])
+	if err != nil {
+		return nil, err
+	}
+	claimedValue, err := deserialiseScalar(claimedValueBytes[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Compute the quotient
+	quotient, err := kzg.ComputeQuotientPoly(c.domain, poly, inputPoint, claimedValue)
+	if err != nil {
+		return nil, err
+	}
+	return serialisePoly(quotient), nil
+}
+
+// Computes KZG proofs for many polynomials, all evaluated at the same point.
+// This is more efficient than calling ComputeKzgProof for each polynomial.
+//
+// Note: Unlike ComputeKzgProof, the commitments to the polynomials are not returned
+func (c *Context) ComputeKZGProofsSharedPoint(serPolys []SerialisedPoly, inputPointBytes [32]byte) (_ []KZGProof, _ [][32]byte, err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return nil, nil, err
+	}
+
+	// 1. Deserialise the polynomials
+	polys, err := deserialisePolys(serPolys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Deserialise input point
+	inputPoint, err := deserialiseScalar(inputPointBytes[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.logger.Debug("computing proofs at a shared point", "numPolys", len(polys))
+
+	// 3. Create opening proofs
+	var openingProofs []kzg.OpeningProof
+	c.withCPUBudget(func() {
+		openingProofs, err = kzg.OpenSharedPoint(c.domain, polys, inputPoint, c.commitKey)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Serialise values
+	serProofs := make([]KZGProof, len(openingProofs))
+	claimedValues := make([][32]byte, len(openingProofs))
+	for i := 0; i < len(openingProofs); i++ {
+		serProof := openingProofs[i].QuotientComm.Bytes()
+		serProofs[i] = serProof[:]
+
+		// Claimed value -- Reverse it to use little endian
+		claimedValues[i] = openingProofs[i].ClaimedValue.Bytes()
+		utils.ReverseArray(&claimedValues[i])
+	}
+
+	return serProofs, claimedValues, nil
+}
+
+func (c *Context) VerifyKZGProof(polynomialKZG KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes [32]byte) (err error) {
+	defer c.recoverPanic(&err)
+
+	err = c.verifyKZGProof(polynomialKZG, kzgProof, inputPointBytes, claimedValueBytes)
+	return c.checkVerifyKZGProofDifferential(polynomialKZG, kzgProof, inputPointBytes, claimedValueBytes, err)
+}
+
+func (c *Context) verifyKZGProof(polynomialKZG KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes [32]byte) error {
 	// gnark-library needs field element representations in big endian form
 	// Usually we reverse the bytes in `deserialiseScalar` but we are using
 	// big.Int, so we manually do it here
@@ -147,7 +411,7 @@ func (c *Context) VerifyKZGProof(polynomialKZG KZGCommitment, kzgProof KZGProof,
 		return errors.New("input point is not serialised canonically")
 	}
 
-	polyComm, err := deserialisePoint(polynomialKZG)
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
 	if err != nil {
 		return err
 	}
@@ -162,11 +426,21 @@ func (c *Context) VerifyKZGProof(polynomialKZG KZGCommitment, kzgProof KZGProof,
 		InputPointBigInt:   &inputPointBigInt,
 		ClaimedValueBigInt: &claimedValueBigInt,
 	}
-	return kzg.VerifyOpt(&polyComm, &proof, c.openKey)
+	c.withCPUBudget(func() {
+		err = kzg.VerifyOpt(&polyComm, &proof, c.openKey)
+	})
+	return err
 }
 
 // Specs: blob_to_kzg_commitment
-func (c *Context) PolyToCommitments(serPolys []SerialisedPoly) (SerialisedCommitments, error) {
+func (c *Context) PolyToCommitments(serPolys []SerialisedPoly) (_ SerialisedCommitments, err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return nil, err
+	}
+	differentialPolys := c.copyForDifferential(serPolys)
+
 	// 1. Deserialise the polynomials
 	polys, err := deserialisePolys(serPolys)
 	if err != nil {
@@ -174,19 +448,65 @@ func (c *Context) PolyToCommitments(serPolys []SerialisedPoly) (SerialisedCommit
 	}
 
 	// 2. Commit to polynomials
-	comms, err := agg_kzg.CommitToPolynomials(polys, c.commitKey)
+	comms, err := c.commitToPolynomials(polys)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Serialise commitments
 	serComms := serialiseCommitments(comms)
+	if err := c.checkCommitmentsDifferential(differentialPolys, serComms); err != nil {
+		return nil, err
+	}
 
 	return serComms, nil
 }
 
+// Converts a polynomial in lagrange form (as committed to by this library) into
+// coefficient form. The i'th serialised scalar returned is the coefficient of x^i.
+//
+// The lagrange form is over the bit-reversed roots of unity, this is accounted for.
+func (c *Context) BlobToCoefficients(serPoly SerialisedPoly) (_ SerialisedPoly, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Interpolate the polynomial
+	coeffs, err := c.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Serialise the coefficients
+	return serialisePoly(coeffs), nil
+}
+
 // Spec: verify_aggregate_kzg_proof
-func (c *Context) VerifyAggregateKzgProof(serPolys []SerialisedPoly, serProof KZGProof, serComms SerialisedCommitments) error {
+func (c *Context) VerifyAggregateKzgProof(serPolys []SerialisedPoly, serProof KZGProof, serComms SerialisedCommitments) (err error) {
+	defer c.recoverPanic(&err)
+	return c.verifyAggregateKzgProof(serPolys, serProof, serComms, nil)
+}
+
+// Same as VerifyAggregateKzgProof, and also returns a report of the work done.
+// The report is nil if the inputs could not be deserialised
+func (c *Context) VerifyAggregateKzgProofWithReport(serPolys []SerialisedPoly, serProof KZGProof, serComms SerialisedCommitments) (_ *VerificationReport, err error) {
+	defer c.recoverPanic(&err)
+
+	report := newVerificationReport()
+	err = c.verifyAggregateKzgProof(serPolys, serProof, serComms, report)
+	return report.ifFinished(), err
+}
+
+// The report is filled in if it is not nil
+func (c *Context) verifyAggregateKzgProof(serPolys []SerialisedPoly, serProof KZGProof, serComms SerialisedCommitments, report *VerificationReport) error {
+	if err := c.checkBatchSize(len(serPolys)); err != nil {
+		return err
+	}
+
 	// 1. Deserialise the polynomials
 	polys, err := deserialisePolys(serPolys)
 	if err != nil {
@@ -200,32 +520,35 @@ func (c *Context) VerifyAggregateKzgProof(serPolys []SerialisedPoly, serProof KZ
 	}
 
 	// 3. Deserialise the polynomial commitments
-	comms, err := deserialiseComms(serComms)
+	comms, cacheHits, err := c.deserialiseCommitments(serComms)
 	if err != nil {
 		return err
 	}
+	report.addCacheHits(cacheHits)
+
+	report.endDeserialisation()
+
+	c.logger.Debug("verifying aggregate proof", "numPolys", len(polys))
+	c.traceTranscript(comms, polys)
 
 	agg_proof := &agg_kzg.BatchOpeningProof{
 		QuotientComm: quotientComm,
 		Commitments:  comms,
 	}
-	return agg_kzg.VerifyBatchOpen(c.domain, polys, agg_proof, c.openKey)
+	c.withCPUBudget(func() {
+		err = c.protocol.VerifyBatchOpen(c.domain, polys, agg_proof, c.openKey)
+	})
+	report.finish(len(polys), len(polys))
+	if err != nil {
+		c.logger.Debug("aggregate proof verification failed", "err", err)
+	}
+	return err
 }
 
 func deserialiseComms(serComms SerialisedCommitments) ([]curve.G1Affine, error) {
-
-	comms := make([]curve.G1Affine, len(serComms))
-	for i := 0; i < len(serComms); i++ {
-		// This will do subgroup checks and is relatively expensive (bench)
-		// TODO: We _could_ do these on multiple threads, if bench shows them to be relatively slow
-		comm, err := deserialisePoint(serComms[i])
-		if err != nil {
-			return nil, err
-		}
-		comms[i] = comm
-	}
-
-	return comms, nil
+	// This will do subgroup checks and is relatively expensive, so the
+	// commitments are deserialised in parallel
+	return DeserialiseG1Points(serComms)
 }
 func deserialisePoint(serPoint SerialisedG1Point) (curve.G1Affine, error) {
 	var point curve.G1Affine
@@ -252,14 +575,14 @@ func deserialisePolys(serPolys []SerialisedPoly) ([]kzg.Polynomial, error) {
 	return polys, nil
 }
 func deserialisePoly(serPoly SerialisedPoly) (kzg.Polynomial, error) {
-	num_coeffs := len(serPoly)
-	poly := make(kzg.Polynomial, num_coeffs)
-	for i := 0; i < num_coeffs; i++ {
-		scalar, err := deserialiseScalar(serPoly[i])
-		if err != nil {
-			return nil, err
+	for i := 0; i < len(serPoly); i++ {
+		reverseBytes(serPoly[i]) // gnark uses big-endian but format is little-endian
+	}
+	poly, isCanon := utils.ReduceCanonicalBatch(serPoly)
+	for i := 0; i < len(isCanon); i++ {
+		if !isCanon[i] {
+			return nil, ErrNonCanonicalScalar
 		}
-		poly[i] = scalar
 	}
 	return poly, nil
 }
@@ -268,7 +591,7 @@ func deserialiseScalar(serScalar SerialisedScalar) (fr.Element, error) {
 	reverseBytes(serScalar) // gnark uses big-endian but format is little-endian
 	scalar, isCanon := utils.ReduceCanonical(serScalar)
 	if !isCanon {
-		return fr.Element{}, errors.New("scalar is not in canonical format")
+		return fr.Element{}, ErrNonCanonicalScalar
 	}
 	return scalar, nil
 }
@@ -281,3 +604,17 @@ func serialiseCommitments(comms []curve.G1Affine) SerialisedCommitments {
 	}
 	return serComms
 }
+
+func serialisePoly(poly kzg.Polynomial) SerialisedPoly {
+	serPoly := make(SerialisedPoly, len(poly))
+	for i := 0; i < len(poly); i++ {
+		serPoly[i] = serialiseScalar(poly[i])
+	}
+	return serPoly
+}
+
+func serialiseScalar(scalar fr.Element) SerialisedScalar {
+	serScalar := scalar.Bytes()
+	reverseBytes(serScalar[:]) // gnark uses big-endian but format is little-endian
+	return serScalar[:]
+}
@@ -0,0 +1,68 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func TestMonomialAccessors(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	points := ctx.MonomialG1Points()
+	if len(points) != 4 {
+		t.Fatal("unexpected number of monomial points")
+	}
+	// The first power of alpha is one
+	if !points[0].Equal(&ctx.openKey.GenG1) {
+		t.Error("first monomial point should be the generator")
+	}
+
+	// Modifying the returned points should not modify the context
+	points[0] = points[1]
+	if !ctx.MonomialG1Points()[0].Equal(&ctx.openKey.GenG1) {
+		t.Error("monomial points should be copied")
+	}
+
+	alphaG2 := ctx.AlphaG2()
+	if !alphaG2.Equal(&ctx.monomialSRS.G2[1]) {
+		t.Error("alpha G2 does not match the monomial SRS")
+	}
+}
+
+func TestComputeQuotientPoly(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	serPoly := testSerialisedPoly(8, 1)
+
+	var outsideDomain [32]byte
+	outsideDomain[0] = 123
+	var inDomain [32]byte
+	copy(inDomain[:], serialiseScalar(ctx.domain.Roots[3]))
+
+	for _, inputPoint := range [][32]byte{outsideDomain, inDomain} {
+		proof, _, claimedValue, err := ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		serQuotient, err := ctx.ComputeQuotientPoly(copyPoly(serPoly), inputPoint, claimedValue)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The proof is the commitment to the quotient
+		quotient, err := deserialisePoly(serQuotient)
+		if err != nil {
+			t.Fatal(err)
+		}
+		quotientComm, err := kzg.Commit(quotient, ctx.commitKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		serQuotientComm := quotientComm.Bytes()
+		if !bytes.Equal(serQuotientComm[:], proof) {
+			t.Error("proof is not the commitment to the quotient")
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// A blob archive is a sequence of records, with no header or padding between them.
+// Each record is:
+//
+//	blob:       BytesPerBlob bytes, the concatenated scalars of the blob
+//	commitment: 48 bytes, compressed G1 point
+//	proof:      48 bytes, compressed G1 point, the blob proof
+//
+// so the number of records is the size of the archive divided by the size of a record.
+
+// Number of records which are verified together when no chunk size is given
+const defaultArchiveChunkSize = 64
+
+var ErrArchiveTruncated = errors.New("blob archive ends part way through a record")
+
+// Called after each chunk of an archive has been verified, with the total number
+// of records verified so far
+type ArchiveProgressFunc func(numVerified int)
+
+// Verifies every blob proof in a blob archive read from `r`, see above for the layout.
+//
+// Records are read and verified `chunkSize` at a time, so memory usage is bounded by
+// the chunk size rather than the size of the archive. A chunk size of zero or less
+// uses a default, which is reduced to MaxBatchSize if that limit is set.
+// `progress` may be nil.
+//
+// Returns the number of records that were verified. If a chunk fails to verify,
+// the error says which records were in the chunk.
+func (c *Context) VerifyBlobArchive(r io.Reader, chunkSize int, progress ArchiveProgressFunc) (numVerified int, err error) {
+	defer c.recoverPanic(&err)
+
+	if chunkSize <= 0 {
+		chunkSize = defaultArchiveChunkSize
+		if c.limits.MaxBatchSize > 0 && c.limits.MaxBatchSize < chunkSize {
+			chunkSize = c.limits.MaxBatchSize
+		}
+	}
+
+	blobSize := int(c.params.BytesPerBlob())
+	scalarSize := int(c.params.BytesPerFieldElement)
+	recordSize := blobSize + 2*serialisedG1Size
+	chunk := make([]byte, chunkSize*recordSize)
+
+	for {
+		// 1. Read the next chunk of records
+		n, err := io.ReadFull(r, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return numVerified, err
+		}
+		if n%recordSize != 0 {
+			return numVerified, ErrArchiveTruncated
+		}
+		numRecords := n / recordSize
+		if numRecords == 0 {
+			return numVerified, nil
+		}
+
+		// 2. Split the records into blobs, commitments and proofs.
+		// These alias the chunk, which is overwritten by the next read
+		serPolys := make([]SerialisedPoly, numRecords)
+		serComms := make(SerialisedCommitments, numRecords)
+		kzgProofs := make([]KZGProof, numRecords)
+		for i := 0; i < numRecords; i++ {
+			record := chunk[i*recordSize : (i+1)*recordSize]
+
+			serPoly := make(SerialisedPoly, c.params.FieldElementsPerBlob)
+			for j := 0; j < len(serPoly); j++ {
+				serPoly[j] = record[j*scalarSize : (j+1)*scalarSize]
+			}
+			serPolys[i] = serPoly
+			serComms[i] = record[blobSize : blobSize+serialisedG1Size]
+			kzgProofs[i] = record[blobSize+serialisedG1Size:]
+		}
+
+		// 3. Verify the chunk
+		err = c.VerifyBlobKZGProofBatch(serPolys, serComms, kzgProofs)
+		if err != nil {
+			return numVerified, fmt.Errorf("blob archive records %d to %d: %w", numVerified, numVerified+numRecords-1, err)
+		}
+		numVerified += numRecords
+
+		if progress != nil {
+			progress(numVerified)
+		}
+
+		// A short read means the archive has been read to the end
+		if numRecords < chunkSize {
+			return numVerified, nil
+		}
+	}
+}
+
+// Same as VerifyBlobArchive, except that the archive is the file called `name` in `fsys`
+func (c *Context) VerifyBlobArchiveFS(fsys fs.FS, name string, chunkSize int, progress ArchiveProgressFunc) (int, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return c.VerifyBlobArchive(file, chunkSize, progress)
+}
+
+// Appends a record for the blob, commitment and proof to a blob archive.
+// The blob must be a serialised polynomial of the size expected by the Context
+func (c *Context) WriteBlobArchiveRecord(w io.Writer, serPoly SerialisedPoly, serComm KZGCommitment, kzgProof KZGProof) error {
+	// Check every size first, so that a partial record is never written
+	if uint64(len(serPoly)) != c.domain.Cardinality {
+		return ErrInvalidBlobSize
+	}
+	for _, serScalar := range serPoly {
+		if len(serScalar) != serialisedScalarSize {
+			return ErrInvalidScalarSize
+		}
+	}
+	if len(serComm) != serialisedG1Size || len(kzgProof) != serialisedG1Size {
+		return ErrInvalidPointBytesSize
+	}
+
+	for _, serScalar := range serPoly {
+		if _, err := w.Write(serScalar); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(serComm); err != nil {
+		return err
+	}
+	_, err := w.Write(kzgProof)
+	return err
+}
@@ -0,0 +1,85 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func testBlobArchive(t *testing.T, ctx *Context, numRecords int) []byte {
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numRecords)
+
+	var archive bytes.Buffer
+	for i := 0; i < numRecords; i++ {
+		if err := ctx.WriteBlobArchiveRecord(&archive, serPolys[i], serComms[i], proofs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return archive.Bytes()
+}
+
+func TestVerifyBlobArchive(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	numRecords := 7
+	archive := testBlobArchive(t, ctx, numRecords)
+
+	var progress []int
+	numVerified, err := ctx.VerifyBlobArchive(bytes.NewReader(append([]byte{}, archive...)), 3, func(n int) {
+		progress = append(progress, n)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numVerified != numRecords {
+		t.Errorf("expected %d records to be verified, got %d", numRecords, numVerified)
+	}
+	if len(progress) != 3 || progress[0] != 3 || progress[1] != 6 || progress[2] != 7 {
+		t.Errorf("unexpected progress %v", progress)
+	}
+
+	// An exact multiple of the chunk size
+	numVerified, err = ctx.VerifyBlobArchive(bytes.NewReader(append([]byte{}, archive...)), numRecords, nil)
+	if err != nil || numVerified != numRecords {
+		t.Errorf("expected %d records to be verified, got %d, %v", numRecords, numVerified, err)
+	}
+
+	// An empty archive is valid
+	numVerified, err = ctx.VerifyBlobArchive(bytes.NewReader(nil), 0, nil)
+	if err != nil || numVerified != 0 {
+		t.Errorf("empty archive should verify, got %d, %v", numVerified, err)
+	}
+
+	// A truncated archive is rejected after the complete chunks
+	truncated := append([]byte{}, archive[:len(archive)-1]...)
+	numVerified, err = ctx.VerifyBlobArchive(bytes.NewReader(truncated), 3, nil)
+	if !errors.Is(err, ErrArchiveTruncated) || numVerified != 6 {
+		t.Errorf("expected a truncated archive after 6 records, got %d, %v", numVerified, err)
+	}
+}
+
+func TestVerifyBlobArchiveFS(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	archive := testBlobArchive(t, ctx, 2)
+
+	// Corrupt the proof of the second record
+	recordSize := len(archive) / 2
+	corrupted := append([]byte{}, archive...)
+	copy(corrupted[2*recordSize-serialisedG1Size:], archive[recordSize-serialisedG1Size:recordSize])
+
+	fsys := fstest.MapFS{
+		"valid.bin":     {Data: archive},
+		"corrupted.bin": {Data: corrupted},
+	}
+
+	if _, err := ctx.VerifyBlobArchiveFS(fsys, "valid.bin", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	numVerified, err := ctx.VerifyBlobArchiveFS(fsys, "corrupted.bin", 1, nil)
+	if err == nil || numVerified != 1 {
+		t.Errorf("expected the second record to fail, got %d, %v", numVerified, err)
+	}
+	if _, err := ctx.VerifyBlobArchiveFS(fsys, "missing.bin", 1, nil); err == nil {
+		t.Error("missing archive should return an error")
+	}
+}
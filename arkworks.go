@@ -0,0 +1,165 @@
+package context
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// The encoding used to serialise compressed points.
+//
+// The rest of the library uses the zcash encoding, as is done in the consensus specs.
+// The arkworks encoding is provided so that test vectors and proofs can be exchanged
+// with Rust tooling which uses the generic arkworks serialisation, for differential testing.
+type PointEncoding int
+
+const (
+	// Big-endian x coordinate, with the flags in the three most significant bits of the first byte:
+	// compressed, point at infinity and y is lexicographically largest
+	EncodingZcash PointEncoding = iota
+	// Little-endian x coordinate, with the flags in the two most significant bits of the last byte:
+	// y is negative (lexicographically largest) and point at infinity.
+	// For G2, the x coordinate is serialised as c0 followed by c1
+	EncodingArkworks
+)
+
+var (
+	ErrUnknownPointEncoding = errors.New("unknown point encoding")
+	ErrInvalidPointLength   = errors.New("serialised point has an invalid length")
+	ErrArkworksInvalidFlags = errors.New("serialised arkworks point has invalid flags")
+)
+
+// Flags for the zcash encoding, found in the first byte
+const (
+	zcashCompressedFlag = 0x80
+	zcashInfinityFlag   = 0x40
+	zcashLargestFlag    = 0x20
+	zcashFlagsMask      = zcashCompressedFlag | zcashInfinityFlag | zcashLargestFlag
+)
+
+// Flags for the arkworks encoding, found in the last byte
+const (
+	arkworksNegativeFlag = 0x80
+	arkworksInfinityFlag = 0x40
+	arkworksFlagsMask    = arkworksNegativeFlag | arkworksInfinityFlag
+)
+
+// Serialises a G1 point into its 48 byte compressed form, using the given encoding
+func SerialiseG1PointWithEncoding(point curve.G1Affine, encoding PointEncoding) (SerialisedG1Point, error) {
+	serPoint := point.Bytes()
+	return fromZcashEncoding(serPoint[:], encoding)
+}
+
+// Deserialises a compressed G1 point, which was serialised using the given encoding.
+// This will do subgroup checks and is relatively expensive
+func DeserialiseG1PointWithEncoding(serPoint SerialisedG1Point, encoding PointEncoding) (curve.G1Affine, error) {
+	if len(serPoint) != curve.SizeOfG1AffineCompressed {
+		return curve.G1Affine{}, ErrInvalidPointLength
+	}
+	zcashPoint, err := toZcashEncoding(serPoint, encoding)
+	if err != nil {
+		return curve.G1Affine{}, err
+	}
+
+	var point curve.G1Affine
+	_, err = point.SetBytes(zcashPoint)
+	if err != nil {
+		return curve.G1Affine{}, err
+	}
+	return point, nil
+}
+
+// Serialises a G2 point into its 96 byte compressed form, using the given encoding
+func SerialiseG2PointWithEncoding(point curve.G2Affine, encoding PointEncoding) (SerialisedG2Point, error) {
+	serPoint := point.Bytes()
+	return fromZcashEncoding(serPoint[:], encoding)
+}
+
+// Deserialises a compressed G2 point, which was serialised using the given encoding.
+// This will do subgroup checks and is relatively expensive
+func DeserialiseG2PointWithEncoding(serPoint SerialisedG2Point, encoding PointEncoding) (curve.G2Affine, error) {
+	if len(serPoint) != curve.SizeOfG2AffineCompressed {
+		return curve.G2Affine{}, ErrInvalidPointLength
+	}
+	zcashPoint, err := toZcashEncoding(serPoint, encoding)
+	if err != nil {
+		return curve.G2Affine{}, err
+	}
+
+	var point curve.G2Affine
+	_, err = point.SetBytes(zcashPoint)
+	if err != nil {
+		return curve.G2Affine{}, err
+	}
+	return point, nil
+}
+
+// Converts a compressed point in the zcash encoding into the given encoding.
+//
+// The zcash encoding of G2 is c1 followed by c0, both big-endian, so reversing all of the
+// bytes gives c0 followed by c1, both little-endian, as arkworks expects.
+func fromZcashEncoding(zcashPoint []byte, encoding PointEncoding) ([]byte, error) {
+	switch encoding {
+	case EncodingZcash:
+		return zcashPoint, nil
+	case EncodingArkworks:
+		flags := zcashPoint[0] & zcashFlagsMask
+
+		serPoint := append([]byte{}, zcashPoint...)
+		serPoint[0] &^= zcashFlagsMask
+		utils.ReverseSlice(serPoint)
+
+		last := len(serPoint) - 1
+		if flags&zcashInfinityFlag != 0 {
+			serPoint[last] |= arkworksInfinityFlag
+		} else if flags&zcashLargestFlag != 0 {
+			serPoint[last] |= arkworksNegativeFlag
+		}
+		return serPoint, nil
+	default:
+		return nil, ErrUnknownPointEncoding
+	}
+}
+
+// Converts a compressed point in the given encoding into the zcash encoding
+func toZcashEncoding(serPoint []byte, encoding PointEncoding) ([]byte, error) {
+	switch encoding {
+	case EncodingZcash:
+		return serPoint, nil
+	case EncodingArkworks:
+		last := len(serPoint) - 1
+		flags := serPoint[last] & arkworksFlagsMask
+		if flags == arkworksFlagsMask {
+			return nil, ErrArkworksInvalidFlags
+		}
+
+		zcashPoint := append([]byte{}, serPoint...)
+		zcashPoint[last] &^= arkworksFlagsMask
+		// This bit is part of the x coordinate in arkworks, but it is always zero
+		// since the modulus is 381 bits. In the zcash encoding it is a flag.
+		if zcashPoint[last]&zcashLargestFlag != 0 {
+			return nil, ErrArkworksInvalidFlags
+		}
+		utils.ReverseSlice(zcashPoint)
+
+		zcashPoint[0] |= zcashCompressedFlag
+		if flags&arkworksInfinityFlag != 0 {
+			// The point at infinity is encoded with an x coordinate of zero
+			for _, b := range zcashPoint[1:] {
+				if b != 0 {
+					return nil, ErrArkworksInvalidFlags
+				}
+			}
+			if zcashPoint[0] != zcashCompressedFlag {
+				return nil, ErrArkworksInvalidFlags
+			}
+			zcashPoint[0] |= zcashInfinityFlag
+		} else if flags&arkworksNegativeFlag != 0 {
+			zcashPoint[0] |= zcashLargestFlag
+		}
+		return zcashPoint, nil
+	default:
+		return nil, ErrUnknownPointEncoding
+	}
+}
@@ -0,0 +1,117 @@
+package context
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+func TestArkworksEncodingG1Generator(t *testing.T) {
+	_, _, genG1, _ := curve.Generators()
+
+	serPoint, err := SerialiseG1PointWithEncoding(genG1, EncodingArkworks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The x coordinate of the generator is 0x17f1...c6bb, and its y coordinate
+	// is not lexicographically largest, so no flags are set
+	if serPoint[0] != 0xbb || serPoint[47] != 0x17 {
+		t.Errorf("unexpected arkworks encoding of the generator %x", serPoint)
+	}
+}
+
+func TestArkworksEncodingRoundTrip(t *testing.T) {
+	_, _, genG1, genG2 := curve.Generators()
+
+	for _, encoding := range []PointEncoding{EncodingZcash, EncodingArkworks} {
+		for i := int64(0); i < 4; i++ {
+			var g1 curve.G1Affine
+			g1.ScalarMultiplication(&genG1, big.NewInt(i))
+			serG1, err := SerialiseG1PointWithEncoding(g1, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotG1, err := DeserialiseG1PointWithEncoding(serG1, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotG1.Equal(&g1) {
+				t.Error("G1 point did not round trip")
+			}
+
+			// Negating the point should only change the sign flag
+			var negG1 curve.G1Affine
+			negG1.Neg(&g1)
+			serNegG1, err := SerialiseG1PointWithEncoding(negG1, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotNegG1, err := DeserialiseG1PointWithEncoding(serNegG1, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotNegG1.Equal(&negG1) {
+				t.Error("negated G1 point did not round trip")
+			}
+
+			var g2 curve.G2Affine
+			g2.ScalarMultiplication(&genG2, big.NewInt(i))
+			serG2, err := SerialiseG2PointWithEncoding(g2, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotG2, err := DeserialiseG2PointWithEncoding(serG2, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotG2.Equal(&g2) {
+				t.Error("G2 point did not round trip")
+			}
+		}
+	}
+}
+
+func TestArkworksEncodingZcashIsDefault(t *testing.T) {
+	_, _, genG1, _ := curve.Generators()
+
+	serPoint, err := SerialiseG1PointWithEncoding(genG1, EncodingZcash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := genG1.Bytes()
+	if !bytes.Equal(serPoint, expected[:]) {
+		t.Error("zcash encoding should match the default serialisation")
+	}
+}
+
+func TestArkworksEncodingInvalid(t *testing.T) {
+	_, _, genG1, _ := curve.Generators()
+
+	serPoint, err := SerialiseG1PointWithEncoding(genG1, EncodingArkworks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bothFlags := append([]byte{}, serPoint...)
+	bothFlags[47] |= arkworksFlagsMask
+	if _, err := DeserialiseG1PointWithEncoding(bothFlags, EncodingArkworks); err != ErrArkworksInvalidFlags {
+		t.Error("expected an error when both flags are set")
+	}
+
+	infinityNonZeroX := append([]byte{}, serPoint...)
+	infinityNonZeroX[47] |= arkworksInfinityFlag
+	if _, err := DeserialiseG1PointWithEncoding(infinityNonZeroX, EncodingArkworks); err != ErrArkworksInvalidFlags {
+		t.Error("expected an error for the point at infinity with a non-zero x coordinate")
+	}
+
+	if _, err := DeserialiseG1PointWithEncoding(serPoint[1:], EncodingArkworks); err != ErrInvalidPointLength {
+		t.Error("expected an error for a short point")
+	}
+
+	if _, err := SerialiseG1PointWithEncoding(genG1, PointEncoding(99)); err != ErrUnknownPointEncoding {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
@@ -0,0 +1,54 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrTooManyBlobs = errors.New("number of blobs exceeds the maximum per block")
+
+// Returned by ValidateBatchShapes when the number of items in a batch is invalid.
+//
+// errors.Is reports whether the error is ErrBlobBatchLengthMismatch or ErrTooManyBlobs,
+// the fields give the count that was rejected.
+type BatchShapeError struct {
+	// The items whose count was rejected, one of "commitments", "proofs" or "blobs"
+	Field    string
+	Expected int
+	Got      int
+
+	err error
+}
+
+func (e *BatchShapeError) Error() string {
+	if e.err == ErrTooManyBlobs {
+		return fmt.Sprintf("%s: maximum is %d, got %d", e.err, e.Expected, e.Got)
+	}
+	return fmt.Sprintf("%s: expected %d %s, got %d", e.err, e.Expected, e.Field, e.Got)
+}
+
+func (e *BatchShapeError) Unwrap() error {
+	return e.err
+}
+
+// Checks the number of blobs, commitments and proofs in a batch, before any of them are deserialised.
+//
+// There must be one commitment and one proof per blob, and there must be at most `maxPerBlock` blobs.
+// A maxPerBlock of zero means that there is no maximum.
+// The error is a *BatchShapeError describing the first check which failed.
+func ValidateBatchShapes(nBlobs, nCommitments, nProofs, maxPerBlock int) error {
+	// 1. Each blob has a commitment and a proof
+	if nCommitments != nBlobs {
+		return &BatchShapeError{Field: "commitments", Expected: nBlobs, Got: nCommitments, err: ErrBlobBatchLengthMismatch}
+	}
+	if nProofs != nBlobs {
+		return &BatchShapeError{Field: "proofs", Expected: nBlobs, Got: nProofs, err: ErrBlobBatchLengthMismatch}
+	}
+
+	// 2. The block does not contain too many blobs
+	if maxPerBlock > 0 && nBlobs > maxPerBlock {
+		return &BatchShapeError{Field: "blobs", Expected: maxPerBlock, Got: nBlobs, err: ErrTooManyBlobs}
+	}
+
+	return nil
+}
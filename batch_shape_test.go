@@ -0,0 +1,45 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateBatchShapes(t *testing.T) {
+	if err := ValidateBatchShapes(6, 6, 6, 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateBatchShapes(0, 0, 0, 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateBatchShapes(100, 100, 100, 0); err != nil {
+		t.Error("a maximum of zero should not limit the number of blobs")
+	}
+
+	tests := []struct {
+		nBlobs, nCommitments, nProofs, maxPerBlock int
+		sentinel                                   error
+		field                                      string
+		expected, got                              int
+	}{
+		{3, 2, 3, 6, ErrBlobBatchLengthMismatch, "commitments", 3, 2},
+		{3, 3, 4, 6, ErrBlobBatchLengthMismatch, "proofs", 3, 4},
+		{7, 7, 7, 6, ErrTooManyBlobs, "blobs", 6, 7},
+		// Mismatched lengths are reported before the maximum
+		{7, 6, 7, 6, ErrBlobBatchLengthMismatch, "commitments", 7, 6},
+	}
+	for i, test := range tests {
+		err := ValidateBatchShapes(test.nBlobs, test.nCommitments, test.nProofs, test.maxPerBlock)
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("test %d: expected %v, got %v", i, test.sentinel, err)
+			continue
+		}
+		var shapeErr *BatchShapeError
+		if !errors.As(err, &shapeErr) {
+			t.Fatalf("test %d: expected a *BatchShapeError", i)
+		}
+		if shapeErr.Field != test.field || shapeErr.Expected != test.expected || shapeErr.Got != test.got {
+			t.Errorf("test %d: unexpected error fields %+v", i, shapeErr)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package context
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var ErrBlobBatchLengthMismatch = errors.New("number of blobs, commitments and proofs must be the same")
+
+// A blob proof is an aggregate proof over a single polynomial.
+// It can be verified with VerifyAggregateKzgProof, passing in the single polynomial
+// and its commitment.
+
+// Computes the commitment to a polynomial and the blob proof for it.
+//
+// This is equivalent to calling PolyToCommitments and then ComputeAggregateKzgProof
+// with a single polynomial, however the polynomial is only deserialised once
+// and the commitment is only computed once.
+func (c *Context) ComputeCommitmentAndBlobProof(serPoly SerialisedPoly) (_ KZGCommitment, _ KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, nil, err
+	}
+	polys := []kzg.Polynomial{poly}
+
+	// 2. Commit to the polynomial
+	comms, err := c.commitToPolynomials(polys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Create the blob proof, reusing the commitment
+	serProof, err := c.computeBlobKZGProof(poly, comms[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Serialise the commitment, so caller only needs to be concerned with
+	// bytes
+	serComm := comms[0].Bytes()
+
+	return serComm[:], serProof, nil
+}
+
+// Computes the blob proof for a polynomial, given the commitment to it.
+func (c *Context) ComputeBlobKZGProof(serPoly SerialisedPoly, polynomialKZG KZGCommitment) (_ KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the commitment
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ComputeBlobKZGProofWithCommitment(serPoly, polyComm)
+}
+
+// Same as ComputeBlobKZGProof, except that the commitment has already been deserialised.
+//
+// This is useful when the caller has just computed the commitment, since it avoids
+// deserialising it and checking that it is in the correct subgroup.
+// The caller is responsible for ensuring that the commitment is a valid G1 point.
+func (c *Context) ComputeBlobKZGProofWithCommitment(serPoly SerialisedPoly, polyComm curve.G1Affine) (_ KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.computeBlobKZGProof(poly, polyComm)
+}
+
+func (c *Context) computeBlobKZGProof(poly kzg.Polynomial, polyComm curve.G1Affine) (KZGProof, error) {
+	var proof *agg_kzg.BatchOpeningProof
+	var err error
+	c.withCPUBudget(func() {
+		proof, err = c.protocol.BatchOpenSinglePointWithCommitments(c.domain, []kzg.Polynomial{poly}, []kzg.Commitment{polyComm}, c.commitKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.traceTranscript([]kzg.Commitment{polyComm}, []kzg.Polynomial{poly})
+
+	serProof := proof.QuotientComm.Bytes()
+	return serProof[:], nil
+}
+
+// Spec: verify_blob_kzg_proof
+//
+// This is equivalent to calling VerifyAggregateKzgProof with a single polynomial.
+// If WithVerificationMemo is used, proofs which have already been verified are not verified again.
+func (c *Context) VerifyBlobKZGProof(serPoly SerialisedPoly, polynomialKZG KZGCommitment, kzgProof KZGProof) (err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Check whether this proof has already been verified.
+	// The key is computed before deserialising, since that modifies the blob
+	var memoKey [32]byte
+	memoize := false
+	if c.verifiedProofs != nil {
+		memoKey, memoize = c.blobProofMemoKey(serPoly, polynomialKZG, kzgProof)
+		if memoize && c.verifiedProofs.contains(memoKey) {
+			c.logger.Debug("blob proof was already verified")
+			return nil
+		}
+	}
+
+	// 2. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return err
+	}
+
+	err = c.verifyBlobKZGProofPoly(poly, polynomialKZG, kzgProof)
+	if err == nil && memoize {
+		c.verifiedProofs.add(memoKey)
+	}
+	return err
+}
+
+// Same as VerifyBlobKZGProof, except that the polynomial has already been deserialised,
+// for example with DeserialiseBlob.
+//
+// `blobHash` must be the BlobHash of the blob that the polynomial was deserialised from.
+// It is only used as the key for WithVerificationMemo, since the Fiat-Shamir challenges are
+// computed from the scalars themselves. The polynomial is not checked against the hash.
+func (c *Context) VerifyBlobKZGProofPoly(poly kzg.Polynomial, blobHash [32]byte, polynomialKZG KZGCommitment, kzgProof KZGProof) (err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Check whether this proof has already been verified
+	var memoKey [32]byte
+	memoize := false
+	if c.verifiedProofs != nil && uint64(len(poly)) == c.domain.Cardinality {
+		memoKey, memoize = blobProofMemoKeyFromHash(blobHash, polynomialKZG, kzgProof)
+		if memoize && c.verifiedProofs.contains(memoKey) {
+			c.logger.Debug("blob proof was already verified")
+			return nil
+		}
+	}
+
+	// 2. Verify the proof
+	err = c.verifyBlobKZGProofPoly(poly, polynomialKZG, kzgProof)
+	if err == nil && memoize {
+		c.verifiedProofs.add(memoKey)
+	}
+	return err
+}
+
+func (c *Context) verifyBlobKZGProofPoly(poly kzg.Polynomial, polynomialKZG KZGCommitment, kzgProof KZGProof) (err error) {
+	// 1. Deserialise the commitment and the proof
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return err
+	}
+	quotientComm, err := deserialisePoint(kzgProof)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug("verifying blob proof")
+	c.traceTranscript([]kzg.Commitment{polyComm}, []kzg.Polynomial{poly})
+
+	// 2. Verify the proof as an aggregate proof over a single polynomial
+	proof := &agg_kzg.BatchOpeningProof{
+		QuotientComm: quotientComm,
+		Commitments:  []kzg.Commitment{polyComm},
+	}
+	c.withCPUBudget(func() {
+		err = c.protocol.VerifyBatchOpen(c.domain, []kzg.Polynomial{poly}, proof, c.openKey)
+	})
+	if err != nil {
+		c.logger.Debug("blob proof verification failed", "err", err)
+	}
+	return err
+}
+
+// Spec: verify_blob_kzg_proof_batch
+//
+// Verifies many blob proofs at once. The i'th proof is for the i'th blob and commitment.
+// This is faster than calling VerifyBlobKZGProof for each blob, since the pairings
+// are batched together.
+//
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) VerifyBlobKZGProofBatch(serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof) (err error) {
+	defer c.recoverPanic(&err)
+	return c.verifyBlobKZGProofBatch(serPolys, serComms, kzgProofs, nil)
+}
+
+// Same as VerifyBlobKZGProofBatch, and also returns a report of the work done.
+// The report is nil if the inputs could not be deserialised, it is returned whether or not the proofs are valid.
+//
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) VerifyBlobKZGProofBatchWithReport(serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof) (_ *VerificationReport, err error) {
+	defer c.recoverPanic(&err)
+
+	report := newVerificationReport()
+	err = c.verifyBlobKZGProofBatch(serPolys, serComms, kzgProofs, report)
+	return report.ifFinished(), err
+}
+
+// The report is filled in if it is not nil
+func (c *Context) verifyBlobKZGProofBatch(serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof, report *VerificationReport) error {
+	if len(serPolys) != len(serComms) || len(serPolys) != len(kzgProofs) {
+		return ErrBlobBatchLengthMismatch
+	}
+	if err := c.checkBatchSize(len(serPolys)); err != nil {
+		return err
+	}
+
+	// 1. Deserialise the inputs and compute the claim that each proof attests to
+	openings, err := c.prepareBlobOpenings(serPolys, serComms, kzgProofs, report)
+	if err != nil {
+		return err
+	}
+
+	report.endDeserialisation()
+
+	c.logger.Debug("verifying blob proof batch", "numBlobs", len(serPolys))
+
+	// 2. Verify the proofs together
+	err = c.verifyBlobOpenings(openings)
+	report.finish(len(serPolys), len(serPolys))
+	if err != nil {
+		c.logger.Debug("blob proof batch verification failed", "err", err)
+	}
+	return err
+}
+
+// Evaluates the polynomial represented by a blob at an arbitrary point.
+// Both the point and the returned evaluation are little-endian scalars.
+//
+// This computes the claimed value that a caller would submit alongside a proof
+// from ComputeKzgProof, without computing the proof.
+//
+// Note: the blob is modified in place during deserialisation.
+func (c *Context) EvaluateBlob(serPoly SerialisedPoly, inputPointBytes [32]byte) (_ [32]byte, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	// 2. Deserialise the input point
+	inputPoint, err := deserialiseScalar(inputPointBytes[:])
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	// 3. Evaluate the polynomial using the barycentric formula
+	outputPoint, err := kzg.EvaluateLagrangePolynomial(c.domain, poly, inputPoint)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	// 4. Serialise the evaluation in little-endian
+	return EncodeScalarLE(*outputPoint), nil
+}
+
+// Returns the SHA-256 hash of a blob, which is the hash of its scalars in order.
+// This is the hash that VerifyBlobKZGProofPoly expects
+func BlobHash(serPoly SerialisedPoly) [32]byte {
+	hasher := sha256.New()
+	for _, serScalar := range serPoly {
+		hasher.Write(serScalar)
+	}
+
+	var hash [32]byte
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+// Deserialises a blob into the polynomial that it represents.
+//
+// Unlike the methods on the Context, the blob is not modified.
+// An error is returned if any of the scalars are not canonical
+func DeserialiseBlob(serPoly SerialisedPoly) (kzg.Polynomial, error) {
+	serPolyCopy := make(SerialisedPoly, len(serPoly))
+	for i, serScalar := range serPoly {
+		serPolyCopy[i] = append([]byte{}, serScalar...)
+	}
+	return deserialisePoly(serPolyCopy)
+}
@@ -0,0 +1,180 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestComputeBlobKZGProofConsistency(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	poly := testSerialisedPoly(4, 2)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(poly))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotProof, err := ctx.ComputeBlobKZGProof(copyPoly(poly), comm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotProof, proof) {
+		t.Error("blob proofs do not match")
+	}
+
+	err = ctx.VerifyAggregateKzgProof([]SerialisedPoly{copyPoly(poly)}, proof, SerialisedCommitments{comm})
+	if err != nil {
+		t.Error("blob proof does not verify")
+	}
+}
+
+func TestVerifyBlobKZGProofPoly(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	serPoly := testSerialisedPoly(4, 2)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ctx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	poly, err := DeserialiseBlob(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobHash := BlobHash(serPoly)
+	if err := ctx.VerifyBlobKZGProofPoly(poly, blobHash, comm, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// The blob should be left untouched, so that it can be deserialised again
+	if _, err := DeserialiseBlob(serPoly); err != nil {
+		t.Fatal(err)
+	}
+
+	poly[0].SetOne()
+	if err := ctx.VerifyBlobKZGProofPoly(poly, blobHash, comm, proof); err == nil {
+		t.Error("proof should not verify for a different polynomial")
+	}
+}
+
+func TestEvaluateBlob(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	serPoly := testSerialisedPoly(4, 2)
+	inputPoint := EncodeScalarLE(fr.NewElement(987))
+	_, _, expected, err := ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ctx.EvaluateBlob(copyPoly(serPoly), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Error("evaluation does not match the claimed value of the proof")
+	}
+
+	// Points in the domain evaluate to the corresponding scalar in the blob
+	for i, root := range ctx.domain.Roots {
+		got, err := ctx.EvaluateBlob(copyPoly(serPoly), EncodeScalarLE(root))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got[:], serPoly[i]) {
+			t.Errorf("evaluation at root %d does not match the blob", i)
+		}
+	}
+}
+
+func TestVerifyBlobKZGProofBatch(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	numBlobs := 3
+	serPolys := make([]SerialisedPoly, numBlobs)
+	serComms := make(SerialisedCommitments, numBlobs)
+	proofs := make([]KZGProof, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		serPolys[i] = testSerialisedPoly(4, uint64(i*10))
+		comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPolys[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		serComms[i] = comm
+		proofs[i] = proof
+	}
+
+	if err := ctx.VerifyBlobKZGProofBatch(copyPolys(serPolys), serComms, proofs); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same batch as raw bytes
+	blobs := make([][]byte, numBlobs)
+	for i, serPoly := range serPolys {
+		for _, serScalar := range serPoly {
+			blobs[i] = append(blobs[i], serScalar...)
+		}
+	}
+	commitments := make([][]byte, numBlobs)
+	proofsBytes := make([][]byte, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		commitments[i] = serComms[i]
+		proofsBytes[i] = proofs[i]
+	}
+	copyBlobs := func() [][]byte {
+		res := make([][]byte, len(blobs))
+		for i, blob := range blobs {
+			res[i] = append([]byte{}, blob...)
+		}
+		return res
+	}
+	if err := ctx.VerifyBlobKZGProofBatchBytes(copyBlobs(), commitments, proofsBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// Malformed inputs are rejected
+	if err := ctx.VerifyBlobKZGProofBatchBytes(copyBlobs(), commitments[:2], proofsBytes); err != ErrBlobBatchLengthMismatch {
+		t.Errorf("expected a length mismatch, got %v", err)
+	}
+	shortBlobs := copyBlobs()
+	shortBlobs[1] = shortBlobs[1][:len(shortBlobs[1])-1]
+	if err := ctx.VerifyBlobKZGProofBatchBytes(shortBlobs, commitments, proofsBytes); err != ErrInvalidBlobBytesSize {
+		t.Errorf("expected an invalid blob size, got %v", err)
+	}
+	shortProofs := append([][]byte{}, proofsBytes...)
+	shortProofs[2] = shortProofs[2][:47]
+	if err := ctx.VerifyBlobKZGProofBatchBytes(copyBlobs(), commitments, shortProofs); err != ErrInvalidPointBytesSize {
+		t.Errorf("expected an invalid point size, got %v", err)
+	}
+
+	// A proof for the wrong blob fails
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	if err := ctx.VerifyBlobKZGProofBatch(copyPolys(serPolys), serComms, proofs); err == nil {
+		t.Error("batch with swapped proofs should not verify")
+	}
+}
+
+// Returns a serialised polynomial whose evaluations are offset, offset+1, ...
+func testSerialisedPoly(size int, offset uint64) SerialisedPoly {
+	poly := make(SerialisedPoly, size)
+	for i := 0; i < size; i++ {
+		poly[i] = serialiseScalar(fr.NewElement(offset + uint64(i)))
+	}
+	return poly
+}
+
+// Deserialisation reverses the bytes of the polynomial in place,
+// so tests copy the polynomial before each use
+func copyPoly(poly SerialisedPoly) SerialisedPoly {
+	res := make(SerialisedPoly, len(poly))
+	for i := 0; i < len(poly); i++ {
+		res[i] = append([]byte{}, poly[i]...)
+	}
+	return res
+}
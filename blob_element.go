@@ -0,0 +1,53 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// The i'th scalar in a blob is not the evaluation at ω^i, since the domain is bit-reversed.
+// These methods take the logical index i, so that the evaluation at ω^i is returned,
+// and account for the bit-reversal when accessing the blob.
+
+var (
+	ErrInvalidBlobSize   = errors.New("blob does not contain a scalar for every evaluation in the domain")
+	ErrInvalidEvalIndex  = errors.New("evaluation index is out of range")
+	ErrInvalidScalarSize = errors.New("serialised scalar is not 32 bytes")
+)
+
+// Returns the evaluation of the blob at ω^i, where ω generates the domain.
+// The blob is not modified
+func (c *Context) BlobElementAt(serPoly SerialisedPoly, i uint64) (fr.Element, error) {
+	position, err := c.blobPosition(serPoly, i)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	if len(serPoly[position]) != serialisedScalarSize {
+		return fr.Element{}, ErrInvalidScalarSize
+	}
+	return deserialiseScalar(append([]byte{}, serPoly[position]...))
+}
+
+// Sets the evaluation of the blob at ω^i, where ω generates the domain
+func (c *Context) SetBlobElementAt(serPoly SerialisedPoly, i uint64, value fr.Element) error {
+	position, err := c.blobPosition(serPoly, i)
+	if err != nil {
+		return err
+	}
+	serPoly[position] = serialiseScalar(value)
+	return nil
+}
+
+// Returns the position in the blob of the evaluation at ω^i
+func (c *Context) blobPosition(serPoly SerialisedPoly, i uint64) (uint64, error) {
+	n := c.domain.Cardinality
+	if uint64(len(serPoly)) != n {
+		return 0, ErrInvalidBlobSize
+	}
+	if i >= n {
+		return 0, ErrInvalidEvalIndex
+	}
+	return utils.BitReverseIndex(i, n), nil
+}
@@ -0,0 +1,51 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestBlobElementAt(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+
+	serPoly := testSerialisedPoly(8, 3)
+	poly, err := DeserialiseBlob(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coeffs, err := ctx.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	naturalEvals := ctx.domain.FFT(coeffs)
+
+	for i := uint64(0); i < 8; i++ {
+		got, err := ctx.BlobElementAt(serPoly, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&naturalEvals[i]) {
+			t.Errorf("element %d is not the evaluation at the %d'th power of the generator", i, i)
+		}
+	}
+
+	value := fr.NewElement(42)
+	if err := ctx.SetBlobElementAt(serPoly, 3, value); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ctx.BlobElementAt(serPoly, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&value) {
+		t.Error("element was not set at the logical index")
+	}
+
+	if _, err := ctx.BlobElementAt(serPoly, 8); err != ErrInvalidEvalIndex {
+		t.Error("expected an error for an index outside of the domain")
+	}
+	if _, err := ctx.BlobElementAt(serPoly[:4], 0); err != ErrInvalidBlobSize {
+		t.Error("expected an error for a blob of the wrong size")
+	}
+}
@@ -0,0 +1,103 @@
+package context
+
+import (
+	"sync"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// The claims made by a batch of blob proofs. The i'th proof attests that the polynomial
+// committed to by comms[i] evaluates to values[i] at points[i]
+type blobOpenings struct {
+	comms  []kzg.Commitment
+	points []fr.Element
+	values []fr.Element
+	proofs []curve.G1Affine
+}
+
+// Deserialises the blobs, commitments and proofs, and computes the claim that each proof attests to.
+//
+// Each worker handles every step for its items, so the decompression and subgroup checks of
+// the points are interleaved with hashing and evaluating the blobs, instead of being done
+// in a serial pass before any of the scalar work starts.
+// If more than one item is invalid, the error is for the item with the lowest index.
+func (c *Context) prepareBlobOpenings(serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof, report *VerificationReport) (*blobOpenings, error) {
+	n := len(serPolys)
+	openings := &blobOpenings{
+		comms:  make([]kzg.Commitment, n),
+		points: make([]fr.Element, n),
+		values: make([]fr.Element, n),
+		proofs: make([]curve.G1Affine, n),
+	}
+	errs := make([]error, n)
+
+	var cacheHitsMu sync.Mutex
+	cacheHits := 0
+	utils.ParallelFor(n, func(start, end int) {
+		chunkCacheHits := 0
+		for i := start; i < end; i++ {
+			hit, err := c.prepareBlobOpening(openings, i, serPolys[i], serComms[i], kzgProofs[i])
+			if hit {
+				chunkCacheHits++
+			}
+			if err != nil {
+				errs[i] = err
+				// The error for the lowest index is returned, so the rest of the chunk is not needed
+				return
+			}
+		}
+		cacheHitsMu.Lock()
+		cacheHits += chunkCacheHits
+		cacheHitsMu.Unlock()
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	report.addCacheHits(cacheHits)
+	return openings, nil
+}
+
+// Fills in the i'th claim of the openings. Returns true if the commitment was cached.
+//
+// This runs on a worker goroutine, a panic is forwarded to the exported method by utils.ParallelFor
+func (c *Context) prepareBlobOpening(openings *blobOpenings, i int, serPoly SerialisedPoly, serComm KZGCommitment, kzgProof KZGProof) (bool, error) {
+	// 1. Deserialise the polynomial, commitment and proof
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return false, err
+	}
+	comm, hit, err := c.deserialiseCommitment(serComm)
+	if err != nil {
+		return hit, err
+	}
+	proof, err := deserialisePoint(kzgProof)
+	if err != nil {
+		return hit, err
+	}
+
+	// 2. Compute the point and the evaluation
+	point, value, err := c.protocol.SinglePolyOpeningClaim(c.domain, poly, comm)
+	if err != nil {
+		return hit, err
+	}
+
+	openings.comms[i] = comm
+	openings.points[i] = point
+	openings.values[i] = value
+	openings.proofs[i] = proof
+	return hit, nil
+}
+
+// Verifies the claims together, within the CPU budget
+func (c *Context) verifyBlobOpenings(openings *blobOpenings) (err error) {
+	c.withCPUBudget(func() {
+		err = kzg.BatchVerifyOpeningProofs(openings.comms, openings.points, openings.values, openings.proofs, c.openKey)
+	})
+	return err
+}
@@ -0,0 +1,60 @@
+package context
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestPrepareBlobOpeningsAcrossWorkers(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	// More blobs than workers, so that every worker handles several blobs
+	numBlobs := 2*runtime.NumCPU() + 1
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numBlobs)
+
+	openings, err := ctx.prepareBlobOpenings(copyPolys(serPolys), serComms, proofs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.verifyBlobOpenings(openings); err != nil {
+		t.Fatal(err)
+	}
+
+	// An invalid proof is reported from whichever worker handles it
+	invalidProofs := append([]KZGProof{}, proofs...)
+	invalidProofs[numBlobs-1] = make(KZGProof, serialisedG1Size)
+	invalidProofs[numBlobs-1][0] = 0xff
+	if _, err := ctx.prepareBlobOpenings(copyPolys(serPolys), serComms, invalidProofs, nil); err == nil {
+		t.Error("invalid proof should not deserialise")
+	}
+
+	if err := ctx.VerifyBlobKZGProofBatch(copyPolys(serPolys), serComms, invalidProofs); err == nil {
+		t.Error("batch with an invalid proof should not verify")
+	}
+}
+
+func TestPrepareBlobOpeningsForwardsPanics(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	numBlobs := 2*runtime.NumCPU() + 1
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numBlobs)
+
+	// Too few commitments make the workers index out of range
+	prepare := func(ctx *Context) (err error) {
+		defer ctx.recoverPanic(&err)
+		_, err = ctx.prepareBlobOpenings(copyPolys(serPolys), serComms[:1], proofs, nil)
+		return err
+	}
+	if err := prepare(ctx); !errors.Is(err, ErrInternalPanic) {
+		t.Fatalf("expected an internal panic error, got %v", err)
+	}
+
+	// In debug mode, the panic reaches the caller instead of crashing on the worker
+	debugCtx := NewContextInsecure(4, 1234, WithDebugPanics())
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected the panic to be propagated in debug mode")
+		}
+	}()
+	_ = prepare(debugCtx)
+}
@@ -0,0 +1,191 @@
+package context
+
+import (
+	"errors"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+var (
+	ErrBlobWriterClosed    = errors.New("blob writer has been closed")
+	ErrBlobWriterNotClosed = errors.New("blob writer has not been closed")
+)
+
+// Blobs together with their commitments and blob proofs.
+// The i'th commitment and proof are for the i'th blob
+type CommittedBlobs struct {
+	Blobs       []SerialisedPoly
+	Commitments SerialisedCommitments
+	Proofs      []KZGProof
+}
+
+// An io.WriteCloser which packs the bytes written to it into blobs,
+// BytesPerDataChunk bytes per scalar, and commits to each blob as it is filled.
+//
+// The final blob is padded with zeroes. Once the writer has been closed,
+// the blobs, commitments and proofs are available from Result.
+// A BlobWriter is not safe for concurrent use.
+type BlobWriter struct {
+	ctx *Context
+
+	// Blob which is currently being filled and the builder for its commitment
+	blob    SerialisedPoly
+	builder *CommitmentBuilder
+	// Data which does not yet fill a scalar
+	pending []byte
+
+	blobs       []SerialisedPoly
+	commitments []curve.G1Affine
+
+	closed bool
+	result CommittedBlobs
+}
+
+// Returns a writer which packs the data written to it into blobs
+func (c *Context) NewBlobWriter() *BlobWriter {
+	return &BlobWriter{
+		ctx:     c,
+		pending: make([]byte, 0, BytesPerDataChunk),
+	}
+}
+
+// Number of bytes of data which fit into a single blob, when packed BytesPerDataChunk bytes per scalar.
+// This is less than Params.BytesPerBlob, which includes the byte of each scalar that is left empty
+func (c *Context) DataBytesPerBlob() int {
+	return int(c.domain.Cardinality) * BytesPerDataChunk
+}
+
+// Implements io.Writer
+func (w *BlobWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, ErrBlobWriterClosed
+	}
+
+	written := 0
+	for len(data) > 0 {
+		n := BytesPerDataChunk - len(w.pending)
+		if n > len(data) {
+			n = len(data)
+		}
+		w.pending = append(w.pending, data[:n]...)
+		data = data[n:]
+
+		if len(w.pending) == BytesPerDataChunk {
+			if err := w.addChunk(w.pending); err != nil {
+				return written, err
+			}
+			w.pending = w.pending[:0]
+		}
+		written += n
+	}
+	return written, nil
+}
+
+// Implements io.Closer.
+//
+// Commits to the final, partially filled, blob and computes the proofs for every blob.
+// Use CloseAndCommit to retrieve the result in the same call
+func (w *BlobWriter) Close() error {
+	_, err := w.CloseAndCommit()
+	return err
+}
+
+// Closes the writer and returns the blobs, their commitments and their proofs
+func (w *BlobWriter) CloseAndCommit() (_ CommittedBlobs, err error) {
+	defer w.ctx.recoverPanic(&err)
+
+	if w.closed {
+		return w.result, nil
+	}
+
+	// 1. Pack any remaining data into the final blob
+	if len(w.pending) > 0 {
+		if err := w.addChunk(w.pending); err != nil {
+			return CommittedBlobs{}, err
+		}
+		w.pending = w.pending[:0]
+	}
+	if w.blob != nil {
+		if err := w.finishBlob(); err != nil {
+			return CommittedBlobs{}, err
+		}
+	}
+
+	// 2. Compute the proof for each blob, reusing the commitments
+	proofs := make([]KZGProof, len(w.blobs))
+	for i, blob := range w.blobs {
+		// Deserialising reverses the scalars in place, so it is done on a copy
+		serPoly := make(SerialisedPoly, len(blob))
+		for j, serScalar := range blob {
+			serPoly[j] = append([]byte{}, serScalar...)
+		}
+		poly, err := deserialisePoly(serPoly)
+		if err != nil {
+			return CommittedBlobs{}, err
+		}
+		proofs[i], err = w.ctx.computeBlobKZGProof(poly, w.commitments[i])
+		if err != nil {
+			return CommittedBlobs{}, err
+		}
+	}
+
+	w.closed = true
+	w.result = CommittedBlobs{
+		Blobs:       w.blobs,
+		Commitments: serialiseCommitments(w.commitments),
+		Proofs:      proofs,
+	}
+	return w.result, nil
+}
+
+// Returns the blobs, commitments and proofs once the writer has been closed
+func (w *BlobWriter) Result() (CommittedBlobs, error) {
+	if !w.closed {
+		return CommittedBlobs{}, ErrBlobWriterNotClosed
+	}
+	return w.result, nil
+}
+
+// Adds a chunk of at most BytesPerDataChunk bytes to the current blob,
+// starting a new blob if needed
+func (w *BlobWriter) addChunk(chunk []byte) error {
+	if w.blob == nil {
+		if err := w.ctx.checkPolysPerCall(len(w.blobs) + 1); err != nil {
+			return err
+		}
+		w.builder = w.ctx.NewCommitmentBuilder()
+		w.blob = make(SerialisedPoly, 0, w.ctx.domain.Cardinality)
+	}
+
+	if err := w.builder.AddDataChunk(chunk); err != nil {
+		return err
+	}
+	serScalar := make([]byte, serialisedScalarSize)
+	copy(serScalar, chunk)
+	w.blob = append(w.blob, serScalar)
+
+	if uint64(len(w.blob)) == w.ctx.domain.Cardinality {
+		return w.finishBlob()
+	}
+	return nil
+}
+
+// Pads the current blob with zeroes and commits to it
+func (w *BlobWriter) finishBlob() error {
+	comm, err := w.builder.finalize()
+	if err != nil {
+		return err
+	}
+	for uint64(len(w.blob)) < w.ctx.domain.Cardinality {
+		w.blob = append(w.blob, make([]byte, serialisedScalarSize))
+	}
+
+	w.blobs = append(w.blobs, w.blob)
+	w.commitments = append(w.commitments, comm)
+	w.blob = nil
+	w.builder = nil
+	return nil
+}
+
+var _ io.WriteCloser = (*BlobWriter)(nil)
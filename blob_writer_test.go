@@ -0,0 +1,88 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBlobWriter(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	// Enough data for two blobs, with the second partially filled
+	data := make([]byte, ctx.DataBytesPerBlob()+40)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+
+	writer := ctx.NewBlobWriter()
+	// Write in pieces which do not line up with the scalars
+	for start := 0; start < len(data); start += 10 {
+		end := start + 10
+		if end > len(data) {
+			end = len(data)
+		}
+		if _, err := writer.Write(data[start:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := writer.CloseAndCommit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Blobs) != 2 || len(result.Commitments) != 2 || len(result.Proofs) != 2 {
+		t.Fatalf("expected two blobs, got %d", len(result.Blobs))
+	}
+
+	// The data should be packed into the low bytes of each scalar
+	if !bytes.Equal(result.Blobs[1][1][:9], data[ctx.DataBytesPerBlob()+BytesPerDataChunk:]) {
+		t.Error("data was not packed into the second blob correctly")
+	}
+
+	expectedComms, err := ctx.PolyToCommitments(copyPolys(result.Blobs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range result.Blobs {
+		if !bytes.Equal(result.Commitments[i], expectedComms[i]) {
+			t.Errorf("commitment %d does not match", i)
+		}
+		err := ctx.VerifyAggregateKzgProof([]SerialisedPoly{copyPoly(result.Blobs[i])}, result.Proofs[i], SerialisedCommitments{result.Commitments[i]})
+		if err != nil {
+			t.Errorf("proof %d is invalid: %v", i, err)
+		}
+	}
+
+	if _, err := writer.Write([]byte{1}); err != ErrBlobWriterClosed {
+		t.Error("expected an error when writing to a closed writer")
+	}
+}
+
+func TestBlobWriterEmpty(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	writer := ctx.NewBlobWriter()
+	if _, err := writer.Result(); err != ErrBlobWriterNotClosed {
+		t.Error("expected an error before the writer is closed")
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := writer.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Blobs) != 0 {
+		t.Error("no blobs should be created when no data is written")
+	}
+}
+
+func TestBlobWriterLimits(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithLimits(Limits{MaxPolysPerCall: 1}))
+
+	writer := ctx.NewBlobWriter()
+	_, err := writer.Write(make([]byte, ctx.DataBytesPerBlob()+BytesPerDataChunk))
+	if err == nil {
+		t.Error("expected an error when exceeding the number of blobs")
+	}
+}
@@ -0,0 +1,225 @@
+// Package blobpool indexes blobs by their commitment and versioned hash.
+//
+// Execution clients keep a pool of blobs from pending transactions, and the same blob
+// is often referenced by several transactions. The Pool stores each blob once, and
+// remembers whether its proof has been verified so that it is only verified once.
+package blobpool
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+const serialisedG1Size = 48
+
+var (
+	ErrInvalidCommitmentSize = errors.New("serialised commitment is not 48 bytes")
+	ErrInvalidProofSize      = errors.New("serialised proof is not 48 bytes")
+	ErrBlobMismatch          = errors.New("blob or proof differs from the one already stored for the commitment")
+	ErrUnknownCommitment     = errors.New("no blob is stored for the commitment")
+)
+
+// A blob stored in the pool
+type Entry struct {
+	Blob          context.SerialisedPoly
+	Commitment    [serialisedG1Size]byte
+	Proof         [serialisedG1Size]byte
+	VersionedHash [32]byte
+	// True if the blob proof has been verified
+	Verified bool
+}
+
+type entry struct {
+	Entry
+	// Number of times the blob was added and not yet removed
+	refs int
+}
+
+// A set of blobs indexed by commitment and by versioned hash.
+// It is safe for concurrent use.
+type Pool struct {
+	ctx *context.Context
+
+	mu              sync.RWMutex
+	byCommitment    map[[serialisedG1Size]byte]*entry
+	byVersionedHash map[[32]byte]*entry
+}
+
+// Creates an empty pool, which verifies blob proofs with `ctx`
+func New(ctx *context.Context) *Pool {
+	return &Pool{
+		ctx:             ctx,
+		byCommitment:    make(map[[serialisedG1Size]byte]*entry),
+		byVersionedHash: make(map[[32]byte]*entry),
+	}
+}
+
+// Adds a blob to the pool, along with its commitment and blob proof.
+// The blob is copied, and the proof is not verified; see Verify.
+//
+// If the commitment is already in the pool, the blob is not stored again and
+// isNew is false. The blob must then be identical to the stored one, since a commitment
+// only opens to a single blob. Each Add should be matched by a Remove.
+func (p *Pool) Add(serPoly context.SerialisedPoly, serComm context.KZGCommitment, kzgProof context.KZGProof) (isNew bool, err error) {
+	if len(serComm) != serialisedG1Size {
+		return false, ErrInvalidCommitmentSize
+	}
+	if len(kzgProof) != serialisedG1Size {
+		return false, ErrInvalidProofSize
+	}
+	var commitment [serialisedG1Size]byte
+	copy(commitment[:], serComm)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 1. Deduplicate blobs which are already in the pool
+	if existing, ok := p.byCommitment[commitment]; ok {
+		if !bytes.Equal(existing.Proof[:], kzgProof) || !blobsEqual(existing.Blob, serPoly) {
+			return false, ErrBlobMismatch
+		}
+		existing.refs++
+		return false, nil
+	}
+
+	// 2. Store a copy of the blob, since verifying it modifies it in place
+	stored := &entry{
+		Entry: Entry{
+			Blob:          copyBlob(serPoly),
+			Commitment:    commitment,
+			VersionedHash: context.KZGToVersionedHash(serComm),
+		},
+		refs: 1,
+	}
+	copy(stored.Proof[:], kzgProof)
+
+	p.byCommitment[commitment] = stored
+	p.byVersionedHash[stored.VersionedHash] = stored
+	return true, nil
+}
+
+// Verifies the blob proof for the blob stored under the commitment.
+// The result is remembered, so a verified blob is only verified once
+func (p *Pool) Verify(serComm context.KZGCommitment) error {
+	p.mu.RLock()
+	stored, ok := p.lookupCommitment(serComm)
+	if !ok {
+		p.mu.RUnlock()
+		return ErrUnknownCommitment
+	}
+	if stored.Verified {
+		p.mu.RUnlock()
+		return nil
+	}
+	// Verification modifies the blob, and the stored entry is immutable apart from
+	// the verified flag, so it is safe to copy outside of the lock
+	blob := copyBlob(stored.Blob)
+	commitment := stored.Commitment
+	proof := stored.Proof
+	p.mu.RUnlock()
+
+	// The lock is not held while verifying, since that is slow
+	if err := p.ctx.VerifyBlobKZGProof(blob, commitment[:], proof[:]); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	stored.Verified = true
+	p.mu.Unlock()
+	return nil
+}
+
+// Returns true if the pool has a blob for the commitment whose proof has been verified
+func (p *Pool) IsVerified(serComm context.KZGCommitment) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stored, ok := p.lookupCommitment(serComm)
+	return ok && stored.Verified
+}
+
+// Returns the blob stored under the commitment.
+// The returned blob is shared with the pool and must not be modified
+func (p *Pool) Get(serComm context.KZGCommitment) (Entry, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stored, ok := p.lookupCommitment(serComm)
+	if !ok {
+		return Entry{}, false
+	}
+	return stored.Entry, true
+}
+
+// Returns the blob whose commitment has the versioned hash.
+// The returned blob is shared with the pool and must not be modified
+func (p *Pool) GetByVersionedHash(versionedHash [32]byte) (Entry, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stored, ok := p.byVersionedHash[versionedHash]
+	if !ok {
+		return Entry{}, false
+	}
+	return stored.Entry, true
+}
+
+// Removes one reference to the blob stored under the commitment. The blob is deleted
+// once it has been removed as many times as it was added.
+// Returns false if the commitment is not in the pool
+func (p *Pool) Remove(serComm context.KZGCommitment) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stored, ok := p.lookupCommitment(serComm)
+	if !ok {
+		return false
+	}
+	stored.refs--
+	if stored.refs == 0 {
+		delete(p.byCommitment, stored.Commitment)
+		delete(p.byVersionedHash, stored.VersionedHash)
+	}
+	return true
+}
+
+// Returns the number of distinct blobs in the pool
+func (p *Pool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.byCommitment)
+}
+
+// The lock must be held by the caller
+func (p *Pool) lookupCommitment(serComm context.KZGCommitment) (*entry, bool) {
+	if len(serComm) != serialisedG1Size {
+		return nil, false
+	}
+	var commitment [serialisedG1Size]byte
+	copy(commitment[:], serComm)
+	stored, ok := p.byCommitment[commitment]
+	return stored, ok
+}
+
+func copyBlob(serPoly context.SerialisedPoly) context.SerialisedPoly {
+	copied := make(context.SerialisedPoly, len(serPoly))
+	for i, serScalar := range serPoly {
+		copied[i] = append([]byte{}, serScalar...)
+	}
+	return copied
+}
+
+func blobsEqual(a, b context.SerialisedPoly) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package blobpool
+
+import (
+	"testing"
+
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+func testBlob(size int, offset byte) context.SerialisedPoly {
+	serPoly := make(context.SerialisedPoly, size)
+	for i := range serPoly {
+		serPoly[i] = make([]byte, 32)
+		serPoly[i][0] = byte(i) + offset
+	}
+	return serPoly
+}
+
+func TestPoolDeduplicatesBlobs(t *testing.T) {
+	ctx := context.NewContextInsecure(8, 1234)
+	pool := New(ctx)
+
+	blob := testBlob(8, 1)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyBlob(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isNew, err := pool.Add(blob, comm, proof)
+	if err != nil || !isNew {
+		t.Fatal("first blob should be added")
+	}
+	isNew, err = pool.Add(blob, comm, proof)
+	if err != nil || isNew {
+		t.Fatal("identical blob should be deduplicated")
+	}
+	if pool.Len() != 1 {
+		t.Errorf("expected 1 blob, got %d", pool.Len())
+	}
+
+	// A different blob under the same commitment is rejected
+	if _, err := pool.Add(testBlob(8, 2), comm, proof); err != ErrBlobMismatch {
+		t.Error("expected an error for a different blob with the same commitment")
+	}
+
+	entry, ok := pool.GetByVersionedHash(context.KZGToVersionedHash(comm))
+	if !ok || !blobsEqual(entry.Blob, blob) {
+		t.Error("blob should be found by its versioned hash")
+	}
+
+	// The blob is kept until every reference is removed
+	pool.Remove(comm)
+	if _, ok := pool.Get(comm); !ok {
+		t.Error("blob was removed while still referenced")
+	}
+	pool.Remove(comm)
+	if _, ok := pool.Get(comm); ok {
+		t.Error("blob should have been removed")
+	}
+	if _, ok := pool.GetByVersionedHash(context.KZGToVersionedHash(comm)); ok {
+		t.Error("versioned hash should have been removed")
+	}
+	if pool.Remove(comm) {
+		t.Error("removing an unknown commitment should return false")
+	}
+}
+
+func TestPoolVerify(t *testing.T) {
+	ctx := context.NewContextInsecure(8, 1234)
+	pool := New(ctx)
+
+	blob := testBlob(8, 1)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyBlob(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Add(blob, comm, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	if pool.IsVerified(comm) {
+		t.Error("blob should not be verified when added")
+	}
+	if err := pool.Verify(comm); err != nil {
+		t.Fatal(err)
+	}
+	if !pool.IsVerified(comm) {
+		t.Error("blob should be verified")
+	}
+	// Verifying must not modify the stored blob
+	entry, _ := pool.Get(comm)
+	if !blobsEqual(entry.Blob, blob) {
+		t.Error("verifying modified the stored blob")
+	}
+
+	// A blob with an invalid proof is not marked as verified
+	otherBlob := testBlob(8, 3)
+	otherComm, _, err := ctx.ComputeCommitmentAndBlobProof(copyBlob(otherBlob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Add(otherBlob, otherComm, proof); err != nil {
+		t.Fatal(err)
+	}
+	if pool.Verify(otherComm) == nil {
+		t.Error("blob with an invalid proof should not verify")
+	}
+	if pool.IsVerified(otherComm) {
+		t.Error("blob with an invalid proof should not be marked as verified")
+	}
+
+	if pool.Verify(make([]byte, 48)) != ErrUnknownCommitment {
+		t.Error("expected an error for an unknown commitment")
+	}
+}
@@ -0,0 +1,74 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Helpers which map between cells, the points that they are evaluated at and the
+// elements of the polynomial.
+//
+// The extended evaluations are in bit-reversed order, so the first half of them are exactly the
+// evaluations in the SerialisedPoly. This means that cell i, for i < CellsPerExtBlob() / ExpansionFactor,
+// contains the polynomial's elements [i * FieldElementsPerCell, (i + 1) * FieldElementsPerCell),
+// where both are taken from the Params of the Context
+// and the remaining cells only contain the extension.
+
+var ErrInvalidPolyIndex = errors.New("index is out of range for the polynomial")
+
+// Returns h_i, the shift of the coset that the cell is evaluated over
+func (c *Context) CosetShiftForCell(cellIndex uint64) (fr.Element, error) {
+	if err := c.checkCellIndex(cellIndex); err != nil {
+		return fr.Element{}, err
+	}
+	return c.cosetShiftForCell(cellIndex), nil
+}
+
+// Returns the points that the cell is evaluated at, in the same order as the cell
+func (c *Context) CosetForCell(cellIndex uint64) ([]fr.Element, error) {
+	if err := c.checkCellIndex(cellIndex); err != nil {
+		return nil, err
+	}
+	cellSize := c.params.FieldElementsPerCell
+	start := cellIndex * cellSize
+	coset := make([]fr.Element, cellSize)
+	copy(coset, c.extendedDomain.Roots[start:start+cellSize])
+	return coset, nil
+}
+
+// Returns the range [start, end) of the polynomial's elements which the cell contains.
+// ok is false if the cell only contains the extension
+func (c *Context) PolyIndicesForCell(cellIndex uint64) (start uint64, end uint64, ok bool, err error) {
+	if err := c.checkCellIndex(cellIndex); err != nil {
+		return 0, 0, false, err
+	}
+	cellSize := c.params.FieldElementsPerCell
+	start = cellIndex * cellSize
+	if start >= c.domain.Cardinality {
+		return 0, 0, false, nil
+	}
+	return start, start + cellSize, true, nil
+}
+
+// Returns the cell which contains the polynomial's element at `polyIndex`,
+// and the position of the element within that cell
+func (c *Context) CellForPolyIndex(polyIndex uint64) (cellIndex uint64, position uint64, err error) {
+	if c.cellDomain == nil {
+		return 0, 0, ErrCellsNotSupported
+	}
+	if polyIndex >= c.domain.Cardinality {
+		return 0, 0, ErrInvalidPolyIndex
+	}
+	return polyIndex / c.params.FieldElementsPerCell, polyIndex % c.params.FieldElementsPerCell, nil
+}
+
+func (c *Context) checkCellIndex(cellIndex uint64) error {
+	if c.cellDomain == nil {
+		return ErrCellsNotSupported
+	}
+	if cellIndex >= c.CellsPerExtBlob() {
+		return ErrInvalidCellIndex
+	}
+	return nil
+}
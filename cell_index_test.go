@@ -0,0 +1,81 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestCellIndexMapping(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	// The first half of the cells contain the polynomial's evaluations
+	for i := uint64(0); i < ctx.CellsPerExtBlob(); i++ {
+		start, end, ok, err := ctx.PolyIndicesForCell(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != (i < ctx.CellsPerExtBlob()/ExpansionFactor) {
+			t.Fatalf("unexpected result for cell %d", i)
+		}
+		if !ok {
+			continue
+		}
+
+		coset, err := ctx.CosetForCell(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for polyIndex := start; polyIndex < end; polyIndex++ {
+			cellIndex, position, err := ctx.CellForPolyIndex(polyIndex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cellIndex != i {
+				t.Fatalf("element %d should be in cell %d, got %d", polyIndex, i, cellIndex)
+			}
+			if !coset[position].Equal(&ctx.domain.Roots[polyIndex]) {
+				t.Fatalf("element %d is not evaluated at the same point as the cell", polyIndex)
+			}
+		}
+	}
+
+	if _, _, err := ctx.CellForPolyIndex(ctx.domain.Cardinality); err != ErrInvalidPolyIndex {
+		t.Error("expected an error for an out of range index")
+	}
+	if _, err := ctx.CosetForCell(ctx.CellsPerExtBlob()); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+}
+
+func TestCosetShiftForCell(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	for i := uint64(0); i < ctx.CellsPerExtBlob(); i++ {
+		shift, err := ctx.CosetShiftForCell(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coset, err := ctx.CosetForCell(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Every point in the coset is the shift multiplied by a FieldElementsPerCell'th root of unity,
+		// so raising them to the power of 64 = 2^6 gives the same result
+		var shiftPow, pointPow fr.Element
+		shiftPow = shift
+		for k := 0; k < 6; k++ {
+			shiftPow.Square(&shiftPow)
+		}
+		for _, point := range coset {
+			pointPow = point
+			for k := 0; k < 6; k++ {
+				pointPow.Square(&pointPow)
+			}
+			if !pointPow.Equal(&shiftPow) {
+				t.Fatalf("point in cell %d is not in the coset", i)
+			}
+		}
+	}
+}
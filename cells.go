@@ -0,0 +1,326 @@
+package context
+
+import (
+	"errors"
+	"sync"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Cells (EIP-7594)
+//
+// The polynomial is evaluated over a domain which is ExpansionFactor times larger than
+// the one that it is defined over. These evaluations are in bit-reversed order, like the
+// evaluations in a SerialisedPoly, and are split into contiguous cells of FieldElementsPerCell
+// evaluations.
+//
+// Because of the bit-reversal, the points for cell i form a coset h_i * <ω>,
+// where <ω> are the FieldElementsPerCell'th roots of unity and h_i is the first point for the cell.
+// Within the cell, the points are h_i * ω^j with j in bit-reversed order.
+// Each cell can therefore be proven with a single coset opening proof.
+
+// The default cell layout, which is used unless the Context is created with different Params
+const (
+	// Number of evaluations in each cell
+	FieldElementsPerCell = 64
+	// Ratio between the size of the extended domain and the size of the polynomial
+	ExpansionFactor = 2
+)
+
+// A cell is Params.FieldElementsPerCell serialised scalars
+type SerialisedCell = []SerialisedScalar
+
+var (
+	ErrCellsNotSupported = errors.New("polynomial size is too small to be split into cells")
+	ErrInvalidCellIndex  = errors.New("cell index is out of range")
+	ErrInvalidCellSize   = errors.New("cell does not contain the correct number of scalars")
+
+	ErrCellBatchLengthMismatch = errors.New("number of commitments, cell indices, cells and proofs must be the same")
+)
+
+// Returns the number of cells that the extended evaluations of a polynomial are split into.
+// This is zero if the Context does not support cells
+func (c *Context) CellsPerExtBlob() uint64 {
+	if c.cellDomain == nil {
+		return 0
+	}
+	return c.params.CellsPerExtBlob()
+}
+
+// Spec: compute_cells_and_kzg_proofs
+//
+// Extends the polynomial and splits the extended evaluations into cells, returning each cell
+// together with its proof. The proofs for all of the cells are computed at once with FK20.
+//
+// Note: the polynomial is modified in place during deserialisation.
+func (c *Context) ComputeCellsAndKZGProofs(serPoly SerialisedPoly) (_ []SerialisedCell, _ []KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	if c.cellDomain == nil {
+		return nil, nil, ErrCellsNotSupported
+	}
+
+	// 1. Deserialise the polynomial and convert it to coefficient form
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, nil, err
+	}
+	coeffs, err := c.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Compute the cells and their proofs
+	return c.computeCellsAndProofsFromCoeffs(coeffs)
+}
+
+// Computes every cell and its proof from the polynomial in coefficient form.
+// The cells are the extended evaluations, and the proofs are computed together with FK20
+func (c *Context) computeCellsAndProofsFromCoeffs(coeffs []fr.Element) ([]SerialisedCell, []KZGProof, error) {
+	var extendedEvals []fr.Element
+	var proofs []curve.G1Affine
+	var err error
+	c.withCPUBudget(func() {
+		// 1. Extend the polynomial, the evaluations are in bit-reversed order like the cells
+		extendedEvals = c.extendedDomain.FFT(coeffs)
+		c.extendedDomain.BitReverse(extendedEvals)
+
+		// 2. Compute the proofs
+		var table *kzg.FK20Table
+		table, err = c.fk20Table()
+		if err != nil {
+			return
+		}
+		proofs, err = table.ComputeCellProofs(coeffs)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Split the extended evaluations into cells
+	numCells := c.CellsPerExtBlob()
+	cellSize := c.params.FieldElementsPerCell
+	cells := make([]SerialisedCell, numCells)
+	serProofs := make([]KZGProof, numCells)
+	for i := uint64(0); i < numCells; i++ {
+		cells[i] = serialisePoly(extendedEvals[i*cellSize : (i+1)*cellSize])
+		serProof := proofs[i].Bytes()
+		serProofs[i] = serProof[:]
+	}
+	return cells, serProofs, nil
+}
+
+// Same as ComputeCellsAndKZGProofs, except that only the cells at `cellIndices` and their proofs
+// are computed, in the same order as the indices.
+//
+// When at most half of the cells are requested, each cell is opened separately, so the work is proportional
+// to the number of cells requested. Otherwise every proof is computed at once, like in ComputeCellsAndKZGProofs.
+//
+// Note: the polynomial is modified in place during deserialisation.
+func (c *Context) ComputeCellsAndKZGProofsForIndices(serPoly SerialisedPoly, cellIndices []uint64) (_ []SerialisedCell, _ []KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	if c.cellDomain == nil {
+		return nil, nil, ErrCellsNotSupported
+	}
+	seen := make(map[uint64]bool, len(cellIndices))
+	for _, cellIndex := range cellIndices {
+		if err := c.checkCellIndex(cellIndex); err != nil {
+			return nil, nil, err
+		}
+		if seen[cellIndex] {
+			return nil, nil, ErrDuplicateCellIndex
+		}
+		seen[cellIndex] = true
+	}
+
+	// 1. Deserialise the polynomial and convert it to coefficient form
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, nil, err
+	}
+	coeffs, err := c.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. When most of the cells are requested, it is faster to compute all of them with FK20
+	numCells := len(cellIndices)
+	if uint64(numCells)*c.params.ExpansionFactor > c.CellsPerExtBlob() {
+		allCells, allProofs, err := c.computeCellsAndProofsFromCoeffs(coeffs)
+		if err != nil {
+			return nil, nil, err
+		}
+		cells := make([]SerialisedCell, numCells)
+		proofs := make([]KZGProof, numCells)
+		for i, cellIndex := range cellIndices {
+			cells[i] = allCells[cellIndex]
+			proofs[i] = allProofs[cellIndex]
+		}
+		return cells, proofs, nil
+	}
+
+	// 3. Otherwise, open the polynomial over the coset for each requested cell, which also gives the cell's evaluations
+	cells := make([]SerialisedCell, numCells)
+	proofs := make([]KZGProof, numCells)
+	errs := make([]error, numCells)
+	c.withCPUBudget(func() {
+		utils.ParallelFor(numCells, func(start, end int) {
+			for i := start; i < end; i++ {
+				proof, evaluations, err := kzg.OpenCoset(c.cellDomain, coeffs, c.cosetShiftForCell(cellIndices[i]), c.monomial())
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				// The cell is in bit-reversed order, like the extended evaluations
+				c.cellDomain.BitReverse(evaluations)
+				cells[i] = serialisePoly(evaluations)
+				serProof := proof.Bytes()
+				proofs[i] = serProof[:]
+			}
+		})
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cells, proofs, nil
+}
+
+// Builds the FK20 table the first time that it is needed, since it is only used by
+// block producers and recovery. The table is shared by copies of the Context
+type lazyFK20Table struct {
+	once  sync.Once
+	table *kzg.FK20Table
+	err   error
+}
+
+func (c *Context) fk20Table() (*kzg.FK20Table, error) {
+	c.fk20.once.Do(func() {
+		c.fk20.table, c.fk20.err = kzg.NewFK20Table(c.monomial(), c.domain.Cardinality, c.params.FieldElementsPerCell, c.CellsPerExtBlob())
+	})
+	return c.fk20.table, c.fk20.err
+}
+
+// Spec: verify_cell_kzg_proof
+//
+// Verifies the proof for a single cell.
+//
+// This is intended for sampling nodes which verify cells as they arrive.
+// The cell must contain the evaluations of the committed polynomial at the points
+// for `cellIndex`, in the order described above.
+// It accepts the same cells as VerifyCellKZGProofBatch with a batch of one, but does not
+// need to sample a random scalar, and its errors are only ever about this cell.
+//
+// Note: the cell is modified in place during deserialisation.
+func (c *Context) VerifyCellKZGProof(polynomialKZG KZGCommitment, cellIndex uint64, cell SerialisedCell, kzgProof KZGProof) (err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkCell(cellIndex, cell); err != nil {
+		return err
+	}
+
+	// 1. Deserialise the commitment and the proof
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return err
+	}
+	proof, err := deserialisePoint(kzgProof)
+	if err != nil {
+		return err
+	}
+
+	// 2. Deserialise the cell, and put the evaluations into natural order over the coset
+	evaluations, err := deserialisePoly(cell)
+	if err != nil {
+		return err
+	}
+	c.cellDomain.BitReverse(evaluations)
+
+	c.logger.Debug("verifying cell proof", "cellIndex", cellIndex)
+
+	c.withCPUBudget(func() {
+		err = kzg.VerifyCoset(c.cellDomain, &polyComm, &proof, c.cosetShiftForCell(cellIndex), evaluations, c.monomial())
+	})
+	if err != nil {
+		c.logger.Debug("cell proof verification failed", "cellIndex", cellIndex, "err", err)
+	}
+	return err
+}
+
+// Spec: verify_cell_kzg_proof_batch
+//
+// Verifies many cell proofs, which may be for different blobs, at once.
+// The i'th cell is at index cellIndices[i] in the extension of the polynomial committed to by commitments[i],
+// and is proven by proofs[i]. The proofs are combined with a random linear combination,
+// so only a single pairing check is needed.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) VerifyCellKZGProofBatch(commitments SerialisedCommitments, cellIndices []uint64, cells []SerialisedCell, proofs []KZGProof) (err error) {
+	defer c.recoverPanic(&err)
+
+	n := len(cells)
+	if len(commitments) != n || len(cellIndices) != n || len(proofs) != n {
+		return ErrCellBatchLengthMismatch
+	}
+	if err := c.checkBatchSize(n); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := c.checkCell(cellIndices[i], cells[i]); err != nil {
+			return err
+		}
+	}
+
+	// 1. Deserialise the commitments and the proofs
+	comms, _, err := c.deserialiseCommitments(commitments)
+	if err != nil {
+		return err
+	}
+	deserialisedProofs, err := DeserialiseG1Points(proofs)
+	if err != nil {
+		return err
+	}
+
+	// 2. Deserialise the cells, putting the evaluations into natural order over their cosets
+	evaluations := make([][]fr.Element, n)
+	cosetShifts := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		evaluations[i], err = deserialisePoly(cells[i])
+		if err != nil {
+			return err
+		}
+		c.cellDomain.BitReverse(evaluations[i])
+		cosetShifts[i] = c.cosetShiftForCell(cellIndices[i])
+	}
+
+	c.logger.Debug("verifying cell proof batch", "numCells", n)
+
+	c.withCPUBudget(func() {
+		err = kzg.BatchVerifyCosets(c.cellDomain, comms, deserialisedProofs, cosetShifts, evaluations, c.monomial())
+	})
+	if err != nil {
+		c.logger.Debug("cell proof batch verification failed", "numCells", n, "err", err)
+	}
+	return err
+}
+
+func (c *Context) checkCell(cellIndex uint64, cell SerialisedCell) error {
+	if err := c.checkCellIndex(cellIndex); err != nil {
+		return err
+	}
+	if uint64(len(cell)) != c.params.FieldElementsPerCell {
+		return ErrInvalidCellSize
+	}
+	return nil
+}
+
+// Returns h_i, the first point for the cell
+func (c *Context) cosetShiftForCell(cellIndex uint64) fr.Element {
+	return c.extendedDomain.Roots[cellIndex*c.params.FieldElementsPerCell]
+}
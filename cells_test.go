@@ -0,0 +1,234 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func TestVerifyCellKZGProof(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+	if ctx.CellsPerExtBlob() != 4 {
+		t.Fatalf("unexpected number of cells %d", ctx.CellsPerExtBlob())
+	}
+
+	serPoly := testSerialisedPoly(128, 3)
+	comm, cells, proofs := testCellsAndProofs(t, ctx, copyPoly(serPoly))
+
+	for i := uint64(0); i < ctx.CellsPerExtBlob(); i++ {
+		err := ctx.VerifyCellKZGProof(comm, i, copyPoly(cells[i]), proofs[i])
+		if err != nil {
+			t.Fatalf("cell %d did not verify: %v", i, err)
+		}
+	}
+
+	// A cell should not verify at another index
+	err := ctx.VerifyCellKZGProof(comm, 1, copyPoly(cells[0]), proofs[0])
+	if err == nil {
+		t.Error("expected the cell to fail at the wrong index")
+	}
+
+	err = ctx.VerifyCellKZGProof(comm, ctx.CellsPerExtBlob(), copyPoly(cells[0]), proofs[0])
+	if err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+	err = ctx.VerifyCellKZGProof(comm, 0, copyPoly(cells[0][1:]), proofs[0])
+	if err != ErrInvalidCellSize {
+		t.Error("expected an error for a cell of the wrong size")
+	}
+}
+
+func TestComputeCellsAndKZGProofs(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	serPoly := testSerialisedPoly(128, 3)
+	comm, expectedCells, expectedProofs := testCellsAndProofs(t, ctx, copyPoly(serPoly))
+
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofs(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(cells)) != ctx.CellsPerExtBlob() || len(proofs) != len(cells) {
+		t.Fatalf("expected %d cells and proofs, got %d and %d", ctx.CellsPerExtBlob(), len(cells), len(proofs))
+	}
+	for i := range cells {
+		for j := range cells[i] {
+			if !bytes.Equal(cells[i][j], expectedCells[i][j]) {
+				t.Fatalf("cell %d differs at index %d", i, j)
+			}
+		}
+		if !bytes.Equal(proofs[i], expectedProofs[i]) {
+			t.Fatalf("proof for cell %d differs", i)
+		}
+		if err := ctx.VerifyCellKZGProof(comm, uint64(i), copyPoly(cells[i]), proofs[i]); err != nil {
+			t.Fatalf("cell %d did not verify: %v", i, err)
+		}
+	}
+
+	// The first cells are the polynomial itself
+	for i := 0; i < len(serPoly); i++ {
+		cellSize := int(ctx.params.FieldElementsPerCell)
+		if !bytes.Equal(cells[i/cellSize][i%cellSize], serPoly[i]) {
+			t.Fatalf("cells do not start with the polynomial, index %d differs", i)
+		}
+	}
+
+	if _, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(64, 3)); err == nil {
+		t.Error("expected an error for a polynomial of the wrong size")
+	}
+}
+
+func TestComputeCellsAndKZGProofsForIndices(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	serPoly := testSerialisedPoly(128, 3)
+	allCells, allProofs, err := ctx.ComputeCellsAndKZGProofs(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Half of the cells are opened separately, more than half are computed with FK20
+	for _, cellIndices := range [][]uint64{{3, 0}, {2, 0, 3}} {
+		cells, proofs, err := ctx.ComputeCellsAndKZGProofsForIndices(copyPoly(serPoly), cellIndices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cells) != len(cellIndices) || len(proofs) != len(cellIndices) {
+			t.Fatalf("expected %d cells and proofs", len(cellIndices))
+		}
+		for i, cellIndex := range cellIndices {
+			for j := range cells[i] {
+				if !bytes.Equal(cells[i][j], allCells[cellIndex][j]) {
+					t.Fatalf("cell %d differs at index %d", cellIndex, j)
+				}
+			}
+			if !bytes.Equal(proofs[i], allProofs[cellIndex]) {
+				t.Fatalf("proof for cell %d differs", cellIndex)
+			}
+		}
+	}
+
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofsForIndices(copyPoly(serPoly), nil)
+	if err != nil || len(cells) != 0 || len(proofs) != 0 {
+		t.Error("no indices should give no cells")
+	}
+	if _, _, err := ctx.ComputeCellsAndKZGProofsForIndices(copyPoly(serPoly), []uint64{1, 1}); err != ErrDuplicateCellIndex {
+		t.Error("expected an error for duplicate cell indices")
+	}
+	if _, _, err := ctx.ComputeCellsAndKZGProofsForIndices(copyPoly(serPoly), []uint64{ctx.CellsPerExtBlob()}); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+}
+
+func TestVerifyCellKZGProofBatch(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	// Sample some of the cells from two blobs
+	commA, cellsA, proofsA := testCellsAndProofs(t, ctx, testSerialisedPoly(128, 3))
+	commB, cellsB, proofsB := testCellsAndProofs(t, ctx, testSerialisedPoly(128, 1000))
+
+	commitments := SerialisedCommitments{commA, commB, commA, commB, commB}
+	cellIndices := []uint64{0, 1, 3, 3, 2}
+	cells := []SerialisedCell{cellsA[0], cellsB[1], cellsA[3], cellsB[3], cellsB[2]}
+	proofs := []KZGProof{proofsA[0], proofsB[1], proofsA[3], proofsB[3], proofsB[2]}
+
+	copyCells := func() []SerialisedCell {
+		return copyPolys(cells)
+	}
+
+	if err := ctx.VerifyCellKZGProofBatch(commitments, cellIndices, copyCells(), proofs); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyCellKZGProofBatch(nil, nil, nil, nil); err != nil {
+		t.Error("an empty batch should verify")
+	}
+
+	// A cell at the wrong index should fail the batch
+	wrongIndices := []uint64{0, 1, 3, 2, 2}
+	if err := ctx.VerifyCellKZGProofBatch(commitments, wrongIndices, copyCells(), proofs); err != kzg.ErrVerifyCosetProof {
+		t.Error("expected the batch to fail with a wrong cell index")
+	}
+
+	// A cell for the wrong commitment should fail the batch
+	wrongComms := SerialisedCommitments{commA, commA, commA, commB, commB}
+	if err := ctx.VerifyCellKZGProofBatch(wrongComms, cellIndices, copyCells(), proofs); err != kzg.ErrVerifyCosetProof {
+		t.Error("expected the batch to fail with a wrong commitment")
+	}
+
+	if err := ctx.VerifyCellKZGProofBatch(commitments[:4], cellIndices, copyCells(), proofs); err != ErrCellBatchLengthMismatch {
+		t.Error("expected a length mismatch")
+	}
+	badIndices := []uint64{0, 1, 3, 3, ctx.CellsPerExtBlob()}
+	if err := ctx.VerifyCellKZGProofBatch(commitments, badIndices, copyCells(), proofs); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+}
+
+func TestCellsNotSupported(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	if ctx.CellsPerExtBlob() != 0 {
+		t.Error("small polynomials should not be split into cells")
+	}
+	err := ctx.VerifyCellKZGProof(nil, 0, nil, nil)
+	if err != ErrCellsNotSupported {
+		t.Error("expected cells to not be supported")
+	}
+	if _, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(4, 3)); err != ErrCellsNotSupported {
+		t.Error("expected cells to not be supported")
+	}
+}
+
+// Computes the cells and the proofs for each one, by opening each coset separately.
+// It also checks that the cells are the evaluations over the extended domain in bit-reversed order.
+func testCellsAndProofs(t *testing.T, ctx *Context, serPoly SerialisedPoly) (KZGCommitment, []SerialisedCell, []KZGProof) {
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comms, err := ctx.commitToPolynomials([]kzg.Polynomial{poly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serComm := comms[0].Bytes()
+
+	coeffs, err := ctx.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numCells := ctx.CellsPerExtBlob()
+	cells := make([]SerialisedCell, numCells)
+	proofs := make([]KZGProof, numCells)
+	for i := uint64(0); i < numCells; i++ {
+		proof, evaluations, err := kzg.OpenCoset(ctx.cellDomain, coeffs, ctx.cosetShiftForCell(i), ctx.monomialSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx.cellDomain.BitReverse(evaluations)
+
+		cellSize := ctx.params.FieldElementsPerCell
+		for j := uint64(0); j < cellSize; j++ {
+			point := ctx.extendedDomain.Roots[i*cellSize+j]
+			expected := evalCoefficients(coeffs, point)
+			if !evaluations[j].Equal(&expected) {
+				t.Fatalf("cell %d does not match the extended evaluations at index %d", i, j)
+			}
+		}
+
+		cells[i] = serialisePoly(evaluations)
+		serProof := proof.Bytes()
+		proofs[i] = serProof[:]
+	}
+
+	return serComm[:], cells, proofs
+}
+
+func evalCoefficients(coeffs []fr.Element, point fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, &point).Add(&result, &coeffs[i])
+	}
+	return result
+}
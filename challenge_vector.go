@@ -0,0 +1,89 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var ErrUnexpectedTrace = errors.New("transcript trace does not have the expected structure")
+
+// Every intermediate value of the Fiat-Shamir transform for a blob proof.
+//
+// Other clients can compare these against their own implementation, to find the
+// first step at which their challenges differ.
+type ChallengeVector struct {
+	// Every byte absorbed into the transcript, starting with the domain separator
+	TranscriptInput []byte
+	// The hash of TranscriptInput
+	CompressedState []byte
+	// One step for each challenge, in the order that they are squeezed.
+	// For a blob proof, the first challenge folds the polynomials and
+	// the second is the point that the folded polynomial is evaluated at
+	Challenges []ChallengeStep
+}
+
+// The values used to compute a single challenge
+type ChallengeStep struct {
+	// The compressed state followed by the index of the challenge
+	HashInput []byte
+	// The hash of HashInput
+	RawHash []byte
+	// RawHash reduced modulo the order of the scalar field, in little-endian
+	Challenge [32]byte
+}
+
+// Returns the intermediate Fiat-Shamir values for the blob proof of a blob and its commitment.
+//
+// Note: the blob is modified in place during deserialisation.
+func (c *Context) ComputeChallengeVector(serPoly SerialisedPoly, polynomialKZG KZGCommitment) (_ ChallengeVector, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial and the commitment
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return ChallengeVector{}, err
+	}
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return ChallengeVector{}, err
+	}
+
+	// 2. Record the transcript while computing the challenges
+	trace := c.protocol.TraceChallenges([]kzg.Commitment{polyComm}, []kzg.Polynomial{poly})
+
+	// 3. Extract the values from the trace
+	return challengeVectorFromTrace(trace)
+}
+
+func challengeVectorFromTrace(trace *fiatshamir.Trace) (ChallengeVector, error) {
+	var vector ChallengeVector
+	entries := trace.Entries
+
+	// Everything before the compressed state is absorbed into the transcript
+	i := 0
+	for ; i < len(entries) && entries[i].Kind != fiatshamir.TraceCompressedState; i++ {
+		vector.TranscriptInput = append(vector.TranscriptInput, entries[i].Data...)
+	}
+	if i == len(entries) {
+		return ChallengeVector{}, ErrUnexpectedTrace
+	}
+	vector.CompressedState = entries[i].Data
+	i++
+
+	// Each challenge is recorded as its input, then its digest, then the reduced challenge
+	for ; i+2 < len(entries) && entries[i].Kind == fiatshamir.TraceChallengeInput; i += 3 {
+		if entries[i+1].Kind != fiatshamir.TraceChallengeDigest || entries[i+2].Kind != fiatshamir.TraceChallenge {
+			return ChallengeVector{}, ErrUnexpectedTrace
+		}
+		step := ChallengeStep{
+			HashInput: entries[i].Data,
+			RawHash:   entries[i+1].Data,
+		}
+		copy(step.Challenge[:], entries[i+2].Data)
+		vector.Challenges = append(vector.Challenges, step)
+	}
+
+	return vector, nil
+}
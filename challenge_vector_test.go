@@ -0,0 +1,59 @@
+package context
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+)
+
+func TestComputeChallengeVector(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	serPoly := testSerialisedPoly(4, 9)
+	comm, _, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vector, err := ctx.ComputeChallengeVector(copyPoly(serPoly), comm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(vector.TranscriptInput, []byte(agg_kzg.DOM_SEP_PROTOCOL)) {
+		t.Error("transcript input should start with the domain separator")
+	}
+	if !bytes.HasSuffix(vector.TranscriptInput, comm) {
+		t.Error("transcript input should end with the commitment")
+	}
+	compressedState := sha256.Sum256(vector.TranscriptInput)
+	if !bytes.Equal(vector.CompressedState, compressedState[:]) {
+		t.Error("compressed state should be the hash of the transcript input")
+	}
+
+	if len(vector.Challenges) != 2 {
+		t.Fatalf("expected 2 challenges, got %d", len(vector.Challenges))
+	}
+	for i, step := range vector.Challenges {
+		expectedInput := append(append([]byte{}, compressedState[:]...), byte(i))
+		if !bytes.Equal(step.HashInput, expectedInput) {
+			t.Errorf("challenge %d has an unexpected hash input", i)
+		}
+		rawHash := sha256.Sum256(step.HashInput)
+		if !bytes.Equal(step.RawHash, rawHash[:]) {
+			t.Errorf("challenge %d has an unexpected raw hash", i)
+		}
+
+		// The challenge is the little-endian raw hash reduced into the field
+		reduced := append([]byte{}, rawHash[:]...)
+		reverseBytes(reduced)
+		var challenge fr.Element
+		challenge.SetBytes(reduced)
+		if EncodeScalarLE(challenge) != step.Challenge {
+			t.Errorf("challenge %d is not the reduced raw hash", i)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package context
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// A payload is packed into blobs as:
+//
+//	length: uint32, little-endian
+//	data:   `length` bytes
+//
+// using BytesPerDataChunk bytes per scalar, in the same way as BlobWriter.
+// The final blob is padded with zeroes.
+const payloadLengthSize = 4
+
+var (
+	ErrPayloadTooLarge        = errors.New("payload is too large to be encoded")
+	ErrInvalidPayloadEncoding = errors.New("blobs do not contain a valid payload encoding")
+)
+
+// A payload split into blobs, together with the commitments, proofs and
+// versioned hashes for each blob
+type ChunkedPayload struct {
+	CommittedBlobs
+	VersionedHashes [][32]byte
+}
+
+// Splits a payload into the minimal number of blobs and commits to them.
+//
+// The payload can be recovered from the blobs with ReassembleData
+func (c *Context) ChunkAndCommit(data []byte) (_ ChunkedPayload, err error) {
+	defer c.recoverPanic(&err)
+
+	if uint64(len(data)) > math.MaxUint32 {
+		return ChunkedPayload{}, ErrPayloadTooLarge
+	}
+
+	// 1. Pack the length prefixed payload into blobs
+	var length [payloadLengthSize]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(data)))
+
+	writer := c.NewBlobWriter()
+	if _, err := writer.Write(length[:]); err != nil {
+		return ChunkedPayload{}, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return ChunkedPayload{}, err
+	}
+
+	// 2. Commit to each blob and compute its proof
+	committed, err := writer.CloseAndCommit()
+	if err != nil {
+		return ChunkedPayload{}, err
+	}
+
+	// 3. Compute the versioned hash of each commitment
+	versionedHashes := make([][32]byte, len(committed.Commitments))
+	for i, comm := range committed.Commitments {
+		versionedHashes[i] = KZGToVersionedHash(comm)
+	}
+
+	return ChunkedPayload{
+		CommittedBlobs:  committed,
+		VersionedHashes: versionedHashes,
+	}, nil
+}
+
+// Recovers the payload from blobs created by ChunkAndCommit.
+//
+// The encoding is checked to be canonical: the number of blobs must be minimal
+// and the padding must be zero
+func (c *Context) ReassembleData(blobs []SerialisedPoly) ([]byte, error) {
+	// 1. Unpack the data chunks from each scalar
+	packed := make([]byte, 0, len(blobs)*c.DataBytesPerBlob())
+	for _, blob := range blobs {
+		if uint64(len(blob)) != c.domain.Cardinality {
+			return nil, ErrInvalidPayloadEncoding
+		}
+		for _, serScalar := range blob {
+			if len(serScalar) != serialisedScalarSize || serScalar[BytesPerDataChunk] != 0 {
+				return nil, ErrInvalidPayloadEncoding
+			}
+			packed = append(packed, serScalar[:BytesPerDataChunk]...)
+		}
+	}
+
+	// 2. Read the length prefix
+	if len(packed) < payloadLengthSize {
+		return nil, ErrInvalidPayloadEncoding
+	}
+	length := uint64(binary.LittleEndian.Uint32(packed[:payloadLengthSize]))
+	end := payloadLengthSize + length
+	if end > uint64(len(packed)) {
+		return nil, ErrInvalidPayloadEncoding
+	}
+
+	// 3. Check that no blobs are superfluous and that the padding is zero
+	bytesPerBlob := uint64(c.DataBytesPerBlob())
+	if (end+bytesPerBlob-1)/bytesPerBlob != uint64(len(blobs)) {
+		return nil, ErrInvalidPayloadEncoding
+	}
+	for _, b := range packed[end:] {
+		if b != 0 {
+			return nil, ErrInvalidPayloadEncoding
+		}
+	}
+
+	return packed[payloadLengthSize:end], nil
+}
@@ -0,0 +1,64 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestChunkAndCommitRoundTrip(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	for _, size := range []int{0, 1, ctx.DataBytesPerBlob() - payloadLengthSize, ctx.DataBytesPerBlob(), 3 * ctx.DataBytesPerBlob()} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+
+		payload, err := ctx.ChunkAndCommit(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedBlobs := (size + payloadLengthSize + ctx.DataBytesPerBlob() - 1) / ctx.DataBytesPerBlob()
+		if len(payload.Blobs) != expectedBlobs {
+			t.Errorf("expected %d blobs for %d bytes, got %d", expectedBlobs, size, len(payload.Blobs))
+		}
+		for i, comm := range payload.Commitments {
+			if payload.VersionedHashes[i] != KZGToVersionedHash(comm) {
+				t.Error("versioned hash does not match the commitment")
+			}
+		}
+
+		got, err := ctx.ReassembleData(payload.Blobs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("reassembled data of size %d does not match", size)
+		}
+	}
+}
+
+func TestReassembleDataNonCanonical(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	payload, err := ctx.ChunkAndCommit([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Non-zero padding
+	blobs := copyPolys(payload.Blobs)
+	blobs[0][3][0] = 1
+	if _, err := ctx.ReassembleData(blobs); err != ErrInvalidPayloadEncoding {
+		t.Error("expected an error for non-zero padding")
+	}
+
+	// Superfluous blob
+	blobs = append(copyPolys(payload.Blobs), testSerialisedPoly(4, 0))
+	for i := range blobs[1] {
+		blobs[1][i] = make([]byte, 32)
+	}
+	if _, err := ctx.ReassembleData(blobs); err != ErrInvalidPayloadEncoding {
+		t.Error("expected an error for a superfluous blob")
+	}
+}
@@ -0,0 +1,141 @@
+package context
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+// Number of scalars buffered before they are added to the running commitment
+const commitmentBuilderChunkSize = 256
+
+// Number of bytes of data which are packed into each scalar by AddDataChunk.
+// The most significant byte of the scalar is zero, so it is always canonical
+const BytesPerDataChunk = 31
+
+var (
+	ErrCommitmentBuilderFull = errors.New("commitment builder already contains a scalar for every evaluation")
+	ErrDataChunkTooLarge     = errors.New("data chunk is larger than BytesPerDataChunk")
+)
+
+// Computes a commitment to a polynomial whose evaluations arrive incrementally,
+// for example while they are being streamed from the network or disk.
+//
+// Scalars are buffered and added to a running MSM in chunks, so the work is done
+// as the data arrives instead of all at once at the end.
+// A CommitmentBuilder is not safe for concurrent use.
+type CommitmentBuilder struct {
+	commitKey *kzg.CommitKey
+	// Sum of the chunks which have already been committed to
+	acc curve.G1Jac
+	// Index of the first evaluation in the buffer
+	bufferStart int
+	buffer      []fr.Element
+}
+
+// Returns a builder for a commitment to a polynomial, whose evaluations
+// are given in the same order as in a SerialisedPoly
+func (c *Context) NewCommitmentBuilder() *CommitmentBuilder {
+	return &CommitmentBuilder{
+		commitKey: c.commitKey,
+		buffer:    make([]fr.Element, 0, commitmentBuilderChunkSize),
+	}
+}
+
+// Adds the next evaluation of the polynomial.
+// Unlike the methods on the Context, the serialised scalar is not modified
+func (b *CommitmentBuilder) AddScalar(serScalar SerialisedScalar) error {
+	if b.len() >= len(b.commitKey.G1) {
+		return ErrCommitmentBuilderFull
+	}
+	scalar, err := deserialiseScalar(append([]byte{}, serScalar...))
+	if err != nil {
+		return err
+	}
+	return b.addScalar(scalar)
+}
+
+// Adds the next evaluations of the polynomial
+func (b *CommitmentBuilder) AddScalars(serScalars []SerialisedScalar) error {
+	for _, serScalar := range serScalars {
+		if err := b.AddScalar(serScalar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Packs up to BytesPerDataChunk bytes of data into the next evaluation of the polynomial.
+// The data is placed in the least significant bytes of the scalar, in little-endian
+func (b *CommitmentBuilder) AddDataChunk(chunk []byte) error {
+	if len(chunk) > BytesPerDataChunk {
+		return ErrDataChunkTooLarge
+	}
+	if b.len() >= len(b.commitKey.G1) {
+		return ErrCommitmentBuilderFull
+	}
+	var serScalar [serialisedScalarSize]byte
+	copy(serScalar[:], chunk)
+	scalar, err := deserialiseScalar(serScalar[:])
+	if err != nil {
+		return err
+	}
+	return b.addScalar(scalar)
+}
+
+// Returns the commitment to the polynomial.
+// Any evaluations which were not added are taken to be zero
+func (b *CommitmentBuilder) Finalize() (KZGCommitment, error) {
+	comm, err := b.finalize()
+	if err != nil {
+		return nil, err
+	}
+	serComm := comm.Bytes()
+	return serComm[:], nil
+}
+
+func (b *CommitmentBuilder) finalize() (curve.G1Affine, error) {
+	var comm curve.G1Affine
+	if err := b.flush(); err != nil {
+		return comm, err
+	}
+	comm.FromJacobian(&b.acc)
+	return comm, nil
+}
+
+// Number of evaluations which have been added
+func (b *CommitmentBuilder) len() int {
+	return b.bufferStart + len(b.buffer)
+}
+
+func (b *CommitmentBuilder) addScalar(scalar fr.Element) error {
+	b.buffer = append(b.buffer, scalar)
+	if len(b.buffer) == commitmentBuilderChunkSize {
+		return b.flush()
+	}
+	return nil
+}
+
+// Adds the buffered evaluations to the running commitment
+func (b *CommitmentBuilder) flush() error {
+	if len(b.buffer) == 0 {
+		return nil
+	}
+
+	end := b.bufferStart + len(b.buffer)
+	chunkKey := kzg.CommitKey{
+		G1:       b.commitKey.G1[b.bufferStart:end],
+		MultiExp: b.commitKey.MultiExp,
+	}
+	chunkComm, err := kzg.Commit(b.buffer, &chunkKey)
+	if err != nil {
+		return err
+	}
+	b.acc.AddMixed(chunkComm)
+
+	b.bufferStart = end
+	b.buffer = b.buffer[:0]
+	return nil
+}
@@ -0,0 +1,85 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCommitmentBuilderMatchesPolyToCommitments(t *testing.T) {
+	size := 2*commitmentBuilderChunkSize + 3
+	ctx := NewContextInsecure(1024, 1234)
+
+	poly := testSerialisedPoly(1024, 5)
+	for i := size; i < len(poly); i++ {
+		poly[i] = make([]byte, 32)
+	}
+	expected, err := ctx.PolyToCommitments([]SerialisedPoly{copyPoly(poly)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Add the evaluations in uneven batches, leaving the zero suffix implicit
+	builder := ctx.NewCommitmentBuilder()
+	for start := 0; start < size; start += 100 {
+		end := start + 100
+		if end > size {
+			end = size
+		}
+		if err := builder.AddScalars(poly[start:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	comm, err := builder.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(comm, expected[0]) {
+		t.Error("commitment from the builder does not match")
+	}
+}
+
+func TestCommitmentBuilderDataChunks(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	chunks := [][]byte{{1, 2, 3}, bytes.Repeat([]byte{0xff}, BytesPerDataChunk)}
+
+	poly := make(SerialisedPoly, 4)
+	for i := range poly {
+		poly[i] = make([]byte, 32)
+	}
+	builder := ctx.NewCommitmentBuilder()
+	for i, chunk := range chunks {
+		if err := builder.AddDataChunk(chunk); err != nil {
+			t.Fatal(err)
+		}
+		copy(poly[i], chunk)
+	}
+	comm, err := builder.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ctx.PolyToCommitments([]SerialisedPoly{poly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(comm, expected[0]) {
+		t.Error("commitment to the data chunks does not match")
+	}
+
+	if err := builder.AddDataChunk(make([]byte, BytesPerDataChunk+1)); err != ErrDataChunkTooLarge {
+		t.Error("expected an error for a large data chunk")
+	}
+}
+
+func TestCommitmentBuilderFull(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	builder := ctx.NewCommitmentBuilder()
+	if err := builder.AddScalars(testSerialisedPoly(4, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.AddScalar(make([]byte, 32)); err != ErrCommitmentBuilderFull {
+		t.Error("expected an error when adding too many scalars")
+	}
+}
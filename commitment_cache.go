@@ -0,0 +1,96 @@
+package context
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// Bounded LRU cache of commitments which have already been deserialised and subgroup checked.
+//
+// The same commitment is often verified many times, for example when a blob is seen in the
+// mempool and then in a block, so the cache lets those commitments skip the decompression
+// and subgroup checks.
+type commitmentCache struct {
+	lru *lruCache
+}
+
+func newCommitmentCache(capacity int) *commitmentCache {
+	return &commitmentCache{lru: newLRUCache(capacity)}
+}
+
+func (cc *commitmentCache) get(key [serialisedG1Size]byte) (curve.G1Affine, bool) {
+	point, ok := cc.lru.get(key[:])
+	if !ok {
+		return curve.G1Affine{}, false
+	}
+	return point.(curve.G1Affine), true
+}
+
+func (cc *commitmentCache) add(key [serialisedG1Size]byte, point curve.G1Affine) {
+	cc.lru.add(key[:], point)
+}
+
+func (cc *commitmentCache) len() int {
+	return cc.lru.len()
+}
+
+// Makes the Context cache up to `capacity` commitments after they have been
+// deserialised and subgroup checked, evicting the least recently used commitment
+// once the cache is full.
+//
+// Only commitments which are passed in to the Context are cached, proofs are not,
+// since a proof is usually only seen once. A capacity of zero or less disables the cache.
+func WithCommitmentCache(capacity int) ContextOption {
+	return func(c *Context) {
+		if capacity <= 0 {
+			c.commitmentCache = nil
+			return
+		}
+		c.commitmentCache = newCommitmentCache(capacity)
+	}
+}
+
+// Deserialises a commitment, using the commitment cache if it is enabled.
+// Returns true if the commitment was found in the cache
+func (c *Context) deserialiseCommitment(serComm SerialisedG1Point) (curve.G1Affine, bool, error) {
+	if c.commitmentCache == nil || len(serComm) != serialisedG1Size {
+		point, err := deserialisePoint(serComm)
+		return point, false, err
+	}
+
+	var key [serialisedG1Size]byte
+	copy(key[:], serComm)
+	if point, ok := c.commitmentCache.get(key); ok {
+		return point, true, nil
+	}
+
+	point, err := deserialisePoint(serComm)
+	if err != nil {
+		return curve.G1Affine{}, false, err
+	}
+	c.commitmentCache.add(key, point)
+	return point, false, nil
+}
+
+// Deserialises the commitments, using the commitment cache if it is enabled.
+// Returns the number of commitments that were found in the cache
+func (c *Context) deserialiseCommitments(serComms SerialisedCommitments) ([]curve.G1Affine, int, error) {
+	if c.commitmentCache == nil {
+		comms, err := deserialiseComms(serComms)
+		return comms, 0, err
+	}
+
+	comms := make([]curve.G1Affine, len(serComms))
+	cacheHits := 0
+	for i := 0; i < len(serComms); i++ {
+		comm, hit, err := c.deserialiseCommitment(serComms[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		if hit {
+			cacheHits++
+		}
+		comms[i] = comm
+	}
+
+	return comms, cacheHits, nil
+}
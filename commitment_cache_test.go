@@ -0,0 +1,77 @@
+package context
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+func TestCommitmentCacheEviction(t *testing.T) {
+	cache := newCommitmentCache(2)
+	_, _, g1Gen, _ := curve.Generators()
+
+	keys := [][serialisedG1Size]byte{{1}, {2}, {3}}
+	cache.add(keys[0], g1Gen)
+	cache.add(keys[1], g1Gen)
+
+	// Use the first key, so that the second is the least recently used
+	if _, ok := cache.get(keys[0]); !ok {
+		t.Fatal("expected the first key to be cached")
+	}
+	cache.add(keys[2], g1Gen)
+
+	if cache.len() != 2 {
+		t.Errorf("expected 2 cached commitments, got %d", cache.len())
+	}
+	if _, ok := cache.get(keys[1]); ok {
+		t.Error("least recently used key should have been evicted")
+	}
+	if _, ok := cache.get(keys[0]); !ok {
+		t.Error("recently used key should not have been evicted")
+	}
+}
+
+func TestCommitmentCacheHits(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithCommitmentCache(8))
+
+	serPolys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 5)}
+	serComms := make(SerialisedCommitments, len(serPolys))
+	proofs := make([]KZGProof, len(serPolys))
+	for i, serPoly := range serPolys {
+		comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+		if err != nil {
+			t.Fatal(err)
+		}
+		serComms[i] = comm
+		proofs[i] = proof
+	}
+
+	// The first verification populates the cache
+	report, err := ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys), serComms, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits := report.CacheHits; hits != 0 {
+		t.Errorf("expected no cache hits, got %d", hits)
+	}
+
+	report, err = ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys), serComms, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits := report.CacheHits; hits != len(serComms) {
+		t.Errorf("expected %d cache hits, got %d", len(serComms), hits)
+	}
+
+	// Invalid commitments are never cached
+	invalidComm := make(SerialisedG1Point, serialisedG1Size)
+	invalidComm[0] = 0xff
+	for i := 0; i < 2; i++ {
+		if _, _, err := ctx.deserialiseCommitment(invalidComm); err == nil {
+			t.Fatal("invalid commitment should not deserialise")
+		}
+	}
+	if ctx.commitmentCache.len() != len(serComms) {
+		t.Errorf("expected %d cached commitments, got %d", len(serComms), ctx.commitmentCache.len())
+	}
+}
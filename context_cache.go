@@ -0,0 +1,141 @@
+package context
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Binary encoding of a Context, so that services which restart often can reload it
+// from a local cache file instead of parsing and checking the setup again.
+//
+// The encoding is:
+//   - magic and version, as little-endian uint32s
+//   - the commit key, monomial G1 and monomial G2 points, each as a uint32 length followed by uncompressed points
+//   - the G1 generator, G2 generator and [α]G₂, uncompressed
+//   - the SHA256 hash of everything before it
+//
+// The points are not checked to be in the correct subgroup when reading, since the cache is assumed
+// to have been written by WriteTo. The hash is checked to catch corrupted files.
+// Options are not part of the encoding, they are passed again to ReadContextFrom.
+
+const (
+	contextCacheMagic   uint32 = 0x504b5a47
+	contextCacheVersion uint32 = 1
+)
+
+var (
+	ErrContextCacheMagic      = errors.New("context cache has an invalid magic number")
+	ErrContextCacheVersion    = errors.New("context cache has an unsupported version")
+	ErrContextCacheCorrupted  = errors.New("context cache hash does not match its contents")
+	ErrContextCacheCustomHash = errors.New("context with a custom challenge hash cannot be cached")
+)
+
+// Writes the setup of the Context to `w`, so that it can be read with ReadContextFrom.
+//
+// ReadContextFrom always returns a SHA-256 Context, so a ContextWithChallengeHash is not written
+func (c *Context) WriteTo(w io.Writer) (int64, error) {
+	if c.protocol.NewHash != nil {
+		return 0, ErrContextCacheCustomHash
+	}
+
+	hasher := sha256.New()
+	hashedWriter := io.MultiWriter(w, hasher)
+
+	var header [8]byte
+	binary.LittleEndian.PutUint32(header[:4], contextCacheMagic)
+	binary.LittleEndian.PutUint32(header[4:], contextCacheVersion)
+	written, err := hashedWriter.Write(header[:])
+	if err != nil {
+		return int64(written), err
+	}
+
+	enc := curve.NewEncoder(hashedWriter, curve.RawEncoding())
+	toEncode := []interface{}{
+		c.commitKey.G1,
+		c.monomial().G1,
+		c.monomial().G2,
+		&c.openKey.GenG1,
+		&c.openKey.GenG2,
+		&c.openKey.AlphaG2,
+	}
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return int64(written) + enc.BytesWritten(), err
+		}
+	}
+
+	hashWritten, err := w.Write(hasher.Sum(nil))
+	return int64(written) + enc.BytesWritten() + int64(hashWritten), err
+}
+
+// Reads a Context which was written with WriteTo
+func ReadContextFrom(r io.Reader, opts ...ContextOption) (*Context, error) {
+	hasher := sha256.New()
+	hashedReader := io.TeeReader(r, hasher)
+
+	var header [8]byte
+	if _, err := io.ReadFull(hashedReader, header[:]); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(header[:4]) != contextCacheMagic {
+		return nil, ErrContextCacheMagic
+	}
+	if binary.LittleEndian.Uint32(header[4:]) != contextCacheVersion {
+		return nil, ErrContextCacheVersion
+	}
+
+	var commitKey kzg.CommitKey
+	monomialSRS := &kzg.MonomialSRS{}
+	var openKey kzg.OpeningKey
+
+	dec := curve.NewDecoder(hashedReader, curve.NoSubgroupChecks())
+	toDecode := []interface{}{
+		&commitKey.G1,
+		&monomialSRS.G1,
+		&monomialSRS.G2,
+		&openKey.GenG1,
+		&openKey.GenG2,
+		&openKey.AlphaG2,
+	}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var expectedHash [sha256.Size]byte
+	if _, err := io.ReadFull(r, expectedHash[:]); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hasher.Sum(nil), expectedHash[:]) {
+		return nil, ErrContextCacheCorrupted
+	}
+
+	size := uint64(len(commitKey.G1))
+	if size < 2 || !utils.IsPowerOfTwo(size) {
+		return nil, ErrSetupSizeNotPow2
+	}
+
+	// The commit key was written with the bit-reversal already applied
+	params := DefaultParams(size)
+	domains, err := kzg.NewDomainExtended(size, params.ExpansionFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	srs := &kzg.SRS{CommitKey: commitKey, OpeningKey: openKey}
+	ctx, err := newContext(domains, srs, monomialSRS, params, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.logger.Debug("read context from cache", "polyDegree", size)
+	return ctx, nil
+}
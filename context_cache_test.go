@@ -0,0 +1,77 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestContextCacheRoundTrip(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+
+	var buf bytes.Buffer
+	written, err := ctx.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buf.Len()) {
+		t.Errorf("reported %d bytes written, but wrote %d", written, buf.Len())
+	}
+
+	got, err := ReadContextFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Features().SetupHash != ctx.Features().SetupHash {
+		t.Error("setup did not round trip")
+	}
+
+	// The cached context should produce the same proofs
+	polys := []SerialisedPoly{testSerialisedPoly(8, 1), testSerialisedPoly(8, 9)}
+	expectedProof, expectedComms, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, comms, err := got.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(proof, expectedProof) || !bytes.Equal(comms[1], expectedComms[1]) {
+		t.Error("cached context produced a different proof")
+	}
+	if err := got.VerifyAggregateKzgProof(copyPolys(polys), proof, comms); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestContextCacheCorrupted(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+
+	var buf bytes.Buffer
+	if _, err := ctx.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	serCtx := buf.Bytes()
+
+	badMagic := append([]byte{}, serCtx...)
+	badMagic[0] ^= 1
+	if _, err := ReadContextFrom(bytes.NewReader(badMagic)); err != ErrContextCacheMagic {
+		t.Error("expected an error for an invalid magic number")
+	}
+
+	badHash := append([]byte{}, serCtx...)
+	badHash[len(badHash)-1] ^= 1
+	if _, err := ReadContextFrom(bytes.NewReader(badHash)); err != ErrContextCacheCorrupted {
+		t.Error("expected an error for a corrupted cache")
+	}
+
+	// This byte is in the y coordinate of [α]G₂, which is not checked when decoding
+	badPoint := append([]byte{}, serCtx...)
+	badPoint[len(badPoint)-33] ^= 1
+	if _, err := ReadContextFrom(bytes.NewReader(badPoint)); err != ErrContextCacheCorrupted {
+		t.Error("expected an error for a corrupted point")
+	}
+
+	if _, err := ReadContextFrom(bytes.NewReader(serCtx[:len(serCtx)/2])); err == nil {
+		t.Error("expected an error for a truncated cache")
+	}
+}
@@ -0,0 +1,116 @@
+package context
+
+import (
+	"math/bits"
+	"time"
+)
+
+// An operation on the Context, for use with EstimateCost
+type Operation int
+
+const (
+	OpPolyToCommitments Operation = iota
+	OpComputeKzgProof
+	OpVerifyKZGProof
+	OpComputeAggregateKzgProof
+	OpVerifyAggregateKzgProof
+	OpVerifyCellKZGProof
+)
+
+// Rough prediction of the resources needed for an operation
+type CostEstimate struct {
+	Duration    time.Duration
+	MemoryBytes uint64
+}
+
+// Costs of the primitive operations, calibrated on a single core of a
+// cloud machine with the pure Go backend. These are only meant to be correct to
+// within a small factor, and to scale correctly with the batch size.
+const (
+	costFieldMul        = 30 * time.Nanosecond
+	costScalarDecode    = 100 * time.Nanosecond
+	costTranscriptPerFr = 100 * time.Nanosecond
+	costMSMPerPoint     = 17 * time.Microsecond
+	costG1Decompress    = 90 * time.Microsecond
+	costG1ScalarMul     = 95 * time.Microsecond
+	costG2ScalarMul     = 3 * costG1ScalarMul
+	costPairingCheck    = 900 * time.Microsecond
+
+	sizeOfScalar = 32
+	sizeOfG1Jac  = 144
+)
+
+// Estimates the time and memory needed to run `op` over `batchSize` polynomials.
+// For operations on a single polynomial, the batch size is the number of times that
+// the operation is run sequentially.
+//
+// This is intended to help schedulers decide between verifying immediately and waiting to batch.
+// The estimates come from constants calibrated on one machine, so they are only rough.
+func (c *Context) EstimateCost(op Operation, batchSize int) CostEstimate {
+	n := c.domain.Cardinality
+	b := uint64(batchSize)
+	if batchSize <= 0 {
+		return CostEstimate{}
+	}
+
+	decodePolys := time.Duration(b*n) * costScalarDecode
+	polysMemory := b * n * sizeOfScalar
+	// Evaluating or dividing a polynomial in lagrange form costs a few multiplications per element
+	polyArithmetic := time.Duration(4*n) * costFieldMul
+
+	switch op {
+	case OpPolyToCommitments:
+		return CostEstimate{
+			Duration:    decodePolys + time.Duration(b)*c.msmCost(n),
+			MemoryBytes: polysMemory + c.msmMemory(n),
+		}
+	case OpComputeKzgProof:
+		return CostEstimate{
+			Duration:    decodePolys + time.Duration(b)*(polyArithmetic+2*c.msmCost(n)),
+			MemoryBytes: 2*polysMemory + c.msmMemory(n),
+		}
+	case OpVerifyKZGProof:
+		return CostEstimate{
+			Duration:    time.Duration(b) * (2*costG1Decompress + 2*costG1ScalarMul + costPairingCheck),
+			MemoryBytes: 0,
+		}
+	case OpComputeAggregateKzgProof:
+		transcript := time.Duration(b*n) * costTranscriptPerFr
+		folding := time.Duration(b*n) * costFieldMul
+		return CostEstimate{
+			Duration:    decodePolys + time.Duration(b)*c.msmCost(n) + transcript + folding + polyArithmetic + c.msmCost(n),
+			MemoryBytes: polysMemory + 2*n*sizeOfScalar + c.msmMemory(n),
+		}
+	case OpVerifyAggregateKzgProof:
+		transcript := time.Duration(b*n) * costTranscriptPerFr
+		folding := time.Duration(b*n) * costFieldMul
+		return CostEstimate{
+			Duration:    decodePolys + time.Duration(b+1)*costG1Decompress + transcript + folding + c.msmCost(b) + polyArithmetic + 2*costG1ScalarMul + costPairingCheck,
+			MemoryBytes: polysMemory + n*sizeOfScalar + c.msmMemory(b),
+		}
+	case OpVerifyCellKZGProof:
+		k := c.params.FieldElementsPerCell
+		ifft := time.Duration(k*uint64(bits.Len64(k))) * costFieldMul
+		return CostEstimate{
+			Duration:    time.Duration(b) * (2*costG1Decompress + ifft + c.msmCost(k) + costG2ScalarMul + costPairingCheck),
+			MemoryBytes: 2 * k * sizeOfScalar,
+		}
+	default:
+		return CostEstimate{}
+	}
+}
+
+// MSMs are split between the tasks, so this assumes that
+// each task has its own core
+func (c *Context) msmCost(numPoints uint64) time.Duration {
+	return time.Duration(numPoints) * costMSMPerPoint / time.Duration(c.msmTaskCount())
+}
+
+// Each task holds 2^(w-1) jacobian buckets, where the window size w is roughly log2(numPoints) - 3
+func (c *Context) msmMemory(numPoints uint64) uint64 {
+	window := bits.Len64(numPoints) - 3
+	if window < 1 {
+		window = 1
+	}
+	return uint64(c.msmTaskCount()) * (uint64(1) << (window - 1)) * sizeOfG1Jac
+}
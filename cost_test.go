@@ -0,0 +1,65 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEstimateCostScalesWithBatchSize(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	ops := []Operation{
+		OpPolyToCommitments,
+		OpComputeKzgProof,
+		OpVerifyKZGProof,
+		OpComputeAggregateKzgProof,
+		OpVerifyAggregateKzgProof,
+		OpVerifyCellKZGProof,
+	}
+	for _, op := range ops {
+		single := ctx.EstimateCost(op, 1)
+		batch := ctx.EstimateCost(op, 16)
+		if single.Duration <= 0 {
+			t.Errorf("operation %d should have a positive cost", op)
+		}
+		if batch.Duration <= single.Duration {
+			t.Errorf("operation %d should cost more for a larger batch", op)
+		}
+	}
+
+	if ctx.EstimateCost(OpVerifyKZGProof, 0) != (CostEstimate{}) {
+		t.Error("an empty batch should cost nothing")
+	}
+}
+
+func TestEstimateCostAggregateIsCheaper(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	// Verifying an aggregate proof should be cheaper than committing to the polynomials
+	aggregate := ctx.EstimateCost(OpVerifyAggregateKzgProof, 16)
+	commit := ctx.EstimateCost(OpPolyToCommitments, 16)
+	if aggregate.Duration >= commit.Duration {
+		t.Error("expected aggregate verification to be cheaper than committing")
+	}
+}
+
+func TestWithMSMTasks(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithMSMTasks(3))
+	if ctx.Features().MSMTasks != 3 {
+		t.Error("number of MSM tasks was not set")
+	}
+
+	defaultCtx := NewContextInsecure(4, 1234)
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1)}
+	comms, err := ctx.PolyToCommitments(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := defaultCtx.PolyToCommitments(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(comms[0], expected[0]) {
+		t.Error("commitment should not depend on the number of MSM tasks")
+	}
+}
@@ -0,0 +1,138 @@
+package context
+
+import "sync"
+
+// Bounds the number of heavy operations, such as MSMs and pairings, which run at the same time.
+//
+// A single CPUBudget can be shared between every Context in the process with WithCPUBudget,
+// so that a flood of verifications from gossip cannot starve block processing of CPU time.
+// Operations which would exceed the budget wait until another operation finishes,
+// with high priority operations being scheduled before low priority ones.
+type CPUBudget struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	capacity int
+	inUse    int
+	// Number of high priority operations waiting for the budget
+	highWaiting int
+}
+
+// The priority of the work done by a Context, see Context.WithPriority
+type Priority int
+
+const (
+	// Work which is latency sensitive, such as verifying the blobs in a block being imported.
+	// This is the default
+	PriorityHigh Priority = iota
+	// Work which can be delayed, such as verifying blobs from the mempool or gossip
+	PriorityLow
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+// Creates a budget which allows `capacity` heavy operations to run at the same time.
+// A capacity of less than one is treated as one.
+func NewCPUBudget(capacity int) *CPUBudget {
+	if capacity < 1 {
+		capacity = 1
+	}
+	budget := &CPUBudget{capacity: capacity}
+	budget.cond = sync.NewCond(&budget.mu)
+	return budget
+}
+
+// Returns the number of heavy operations which can run at the same time
+func (b *CPUBudget) Capacity() int {
+	return b.capacity
+}
+
+// Returns the number of heavy operations which are currently running.
+// This is intended for monitoring, the value may be stale as soon as it is returned.
+func (b *CPUBudget) InUse() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.inUse
+}
+
+// Blocks until the budget allows another operation to run.
+// Low priority operations also wait while any high priority operation is waiting
+func (b *CPUBudget) acquire(priority Priority) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if priority == PriorityLow {
+		for b.inUse >= b.capacity || b.highWaiting > 0 {
+			b.cond.Wait()
+		}
+		b.inUse++
+		return
+	}
+
+	b.highWaiting++
+	for b.inUse >= b.capacity {
+		b.cond.Wait()
+	}
+	b.highWaiting--
+	b.inUse++
+}
+
+func (b *CPUBudget) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inUse--
+	// Wake every waiter, since a low priority waiter may not be allowed to take the slot
+	b.cond.Broadcast()
+}
+
+// Makes the Context run its heavy operations within the budget.
+// Passing the same budget to several Contexts bounds their operations in total.
+func WithCPUBudget(budget *CPUBudget) ContextOption {
+	return func(c *Context) {
+		c.cpuBudget = budget
+	}
+}
+
+// Returns the budget that the Context runs its heavy operations within, or nil if there is none
+func (c *Context) CPUBudget() *CPUBudget {
+	return c.cpuBudget
+}
+
+// Returns a Context which shares everything with this one, except that its heavy operations
+// are scheduled with the given priority when they wait for the CPU budget.
+//
+// For example, a node could verify gossiped blobs with ctx.WithPriority(PriorityLow) and
+// blocks with ctx, so that block verification is not delayed by a busy mempool.
+// Without a CPU budget the priority has no effect.
+func (c *Context) WithPriority(priority Priority) *Context {
+	prioritised := *c
+	prioritised.priority = priority
+	return &prioritised
+}
+
+// Returns the priority of the Context's heavy operations
+func (c *Context) Priority() Priority {
+	return c.priority
+}
+
+// Runs a heavy operation within the CPU budget, if there is one.
+//
+// Operations must not be nested, since an operation which waits for the budget while
+// holding part of it could deadlock.
+func (c *Context) withCPUBudget(op func()) {
+	if c.cpuBudget == nil {
+		op()
+		return
+	}
+	c.cpuBudget.acquire(c.priority)
+	defer c.cpuBudget.release()
+	op()
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCPUBudgetBlocksWhenFull(t *testing.T) {
+	budget := NewCPUBudget(1)
+	ctx := NewContextInsecure(4, 1234, WithCPUBudget(budget))
+	if ctx.CPUBudget() != budget {
+		t.Fatal("context should use the budget it was given")
+	}
+
+	serPoly := testSerialisedPoly(4, 1)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if budget.InUse() != 0 {
+		t.Fatalf("budget should be released after each operation, %d in use", budget.InUse())
+	}
+
+	// Take the whole budget, so that verification has to wait
+	budget.acquire(PriorityHigh)
+	if budget.InUse() != budget.Capacity() {
+		t.Fatal("budget should be full")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- ctx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("verification should wait for the budget")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	budget.release()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if budget.InUse() != 0 {
+		t.Errorf("budget should be released after verification, %d in use", budget.InUse())
+	}
+}
+
+func TestCPUBudgetMinimumCapacity(t *testing.T) {
+	if NewCPUBudget(0).Capacity() != 1 {
+		t.Error("capacity should be at least one")
+	}
+}
+
+func TestCPUBudgetPriority(t *testing.T) {
+	budget := NewCPUBudget(1)
+	budget.acquire(PriorityHigh)
+
+	order := make(chan Priority, 2)
+	run := func(priority Priority) {
+		budget.acquire(priority)
+		order <- priority
+		budget.release()
+	}
+
+	// The low priority operation starts waiting first
+	go run(PriorityLow)
+	time.Sleep(20 * time.Millisecond)
+	go run(PriorityHigh)
+	time.Sleep(20 * time.Millisecond)
+
+	budget.release()
+	if first := <-order; first != PriorityHigh {
+		t.Errorf("expected the high priority operation to run first, got %s", first)
+	}
+	if second := <-order; second != PriorityLow {
+		t.Errorf("expected the low priority operation to run second, got %s", second)
+	}
+}
+
+func TestWithPrioritySharesContext(t *testing.T) {
+	budget := NewCPUBudget(2)
+	ctx := NewContextInsecure(4, 1234, WithCPUBudget(budget))
+	lowCtx := ctx.WithPriority(PriorityLow)
+
+	if ctx.Priority() != PriorityHigh || lowCtx.Priority() != PriorityLow {
+		t.Fatal("unexpected priorities")
+	}
+	if lowCtx.CPUBudget() != budget {
+		t.Error("prioritised context should share the budget")
+	}
+
+	serPoly := testSerialisedPoly(4, 1)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lowCtx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof); err != nil {
+		t.Fatal(err)
+	}
+}
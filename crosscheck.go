@@ -0,0 +1,61 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var ErrCommitmentCrossCheck = errors.New("commitment in lagrange basis does not match commitment in monomial basis")
+
+// Makes the Context compute every commitment twice; once with the lagrange SRS
+// and once by interpolating the polynomial and using the monomial SRS.
+// If the two commitments differ, an error is returned.
+//
+// This roughly doubles the cost of committing, so it is intended for CI
+// and soak tests, where it catches FFT and bit-reversal ordering bugs.
+func WithCommitmentCrossCheck() ContextOption {
+	return func(c *Context) {
+		c.crossCheckCommitments = true
+	}
+}
+
+// Commits to the polynomials, cross checking the result if enabled
+func (c *Context) commitToPolynomials(polys []kzg.Polynomial) ([]kzg.Commitment, error) {
+	var comms []kzg.Commitment
+	var err error
+	c.withCPUBudget(func() {
+		comms, err = agg_kzg.CommitToPolynomials(polys, c.commitKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.crossCheckCommitments {
+		for i := 0; i < len(polys); i++ {
+			if err := c.crossCheckCommitment(polys[i], &comms[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return comms, nil
+}
+
+func (c *Context) crossCheckCommitment(poly kzg.Polynomial, comm *kzg.Commitment) error {
+	coeffs, err := c.domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		return err
+	}
+	monomialComm, err := kzg.CommitCoefficients(coeffs, c.monomial())
+	if err != nil {
+		return err
+	}
+
+	if !monomialComm.Equal(comm) {
+		c.logger.Debug("commitment cross check failed")
+		return ErrCommitmentCrossCheck
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package context
+
+import (
+	"testing"
+)
+
+func TestCommitmentCrossCheck(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234, WithCommitmentCrossCheck())
+
+	polys := []SerialisedPoly{testSerialisedPoly(8, 1), testSerialisedPoly(8, 5)}
+	_, err := ctx.PolyToCommitments(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate an ordering bug in the setup
+	ctx.monomialSRS.G1[1], ctx.monomialSRS.G1[2] = ctx.monomialSRS.G1[2], ctx.monomialSRS.G1[1]
+	_, err = ctx.PolyToCommitments(copyPolys(polys))
+	if err != ErrCommitmentCrossCheck {
+		t.Error("expected the cross check to fail")
+	}
+}
+
+func copyPolys(polys []SerialisedPoly) []SerialisedPoly {
+	res := make([]SerialisedPoly, len(polys))
+	for i := 0; i < len(polys); i++ {
+		res[i] = copyPoly(polys[i])
+	}
+	return res
+}
@@ -0,0 +1,126 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var ErrCustodyCheckFailed = errors.New("custody check failed")
+
+// Returned by CustodyAccumulator.Verify, it identifies the first cell whose proof is invalid
+type CustodyCheckError struct {
+	// Position of the cell in the order that it was absorbed
+	Index  int
+	Column uint64
+}
+
+func (e *CustodyCheckError) Error() string {
+	return fmt.Sprintf("%s: cell %d in column %d has an invalid proof", ErrCustodyCheckFailed, e.Index, e.Column)
+}
+
+func (e *CustodyCheckError) Unwrap() error {
+	return ErrCustodyCheckFailed
+}
+
+// Collects cells and their proofs, for example every cell that a node has custody of over an epoch,
+// and verifies them all at once with a single pairing check.
+//
+// Each cell is checked and deserialised when it is absorbed, so that malformed cells are rejected
+// straight away, while the expensive verification is deferred until Verify is called.
+// A CustodyAccumulator is not safe for concurrent use.
+type CustodyAccumulator struct {
+	ctx *Context
+
+	columns     []uint64
+	comms       []kzg.Commitment
+	proofs      []curve.G1Affine
+	cosetShifts []fr.Element
+	// Evaluations of each cell in natural order over its coset
+	evaluations [][]fr.Element
+}
+
+// Returns an empty accumulator for cells from this Context
+func (c *Context) NewCustodyAccumulator() *CustodyAccumulator {
+	return &CustodyAccumulator{ctx: c}
+}
+
+// Adds the cell at index `column` of the extension of the blob committed to by `commitment`.
+// Unlike the methods on the Context, the cell is not modified
+func (a *CustodyAccumulator) Absorb(column uint64, commitment KZGCommitment, cell SerialisedCell, proof KZGProof) error {
+	c := a.ctx
+	if err := c.checkCell(column, cell); err != nil {
+		return err
+	}
+
+	// 1. Deserialise the commitment and the proof
+	comm, _, err := c.deserialiseCommitment(commitment)
+	if err != nil {
+		return err
+	}
+	deserialisedProof, err := deserialisePoint(proof)
+	if err != nil {
+		return err
+	}
+
+	// 2. Deserialise a copy of the cell, and put the evaluations into natural order over the coset
+	cellCopy := make(SerialisedCell, len(cell))
+	for i := range cell {
+		cellCopy[i] = append([]byte{}, cell[i]...)
+	}
+	evaluations, err := deserialisePoly(cellCopy)
+	if err != nil {
+		return err
+	}
+	c.cellDomain.BitReverse(evaluations)
+
+	a.columns = append(a.columns, column)
+	a.comms = append(a.comms, comm)
+	a.proofs = append(a.proofs, deserialisedProof)
+	a.cosetShifts = append(a.cosetShifts, c.cosetShiftForCell(column))
+	a.evaluations = append(a.evaluations, evaluations)
+	return nil
+}
+
+// Returns the number of cells that have been absorbed
+func (a *CustodyAccumulator) Len() int {
+	return len(a.comms)
+}
+
+// Verifies every cell that has been absorbed.
+//
+// If the batch does not verify, the cells are verified separately to find the first invalid one,
+// and the error is a *CustodyCheckError. The accumulator is not reset.
+func (a *CustodyAccumulator) Verify() (err error) {
+	c := a.ctx
+	defer c.recoverPanic(&err)
+
+	c.logger.Debug("verifying custody accumulator", "numCells", a.Len())
+
+	c.withCPUBudget(func() {
+		err = kzg.BatchVerifyCosets(c.cellDomain, a.comms, a.proofs, a.cosetShifts, a.evaluations, c.monomial())
+	})
+	if err != kzg.ErrVerifyCosetProof {
+		return err
+	}
+
+	// Find the first invalid cell
+	for i := range a.comms {
+		if kzg.VerifyCoset(c.cellDomain, &a.comms[i], &a.proofs[i], a.cosetShifts[i], a.evaluations[i], c.monomial()) != nil {
+			return &CustodyCheckError{Index: i, Column: a.columns[i]}
+		}
+	}
+	return err
+}
+
+// Removes every cell from the accumulator, so that it can be reused
+func (a *CustodyAccumulator) Reset() {
+	a.columns = a.columns[:0]
+	a.comms = a.comms[:0]
+	a.proofs = a.proofs[:0]
+	a.cosetShifts = a.cosetShifts[:0]
+	a.evaluations = a.evaluations[:0]
+}
@@ -0,0 +1,63 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCustodyAccumulator(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	commA, cellsA, proofsA := testCellsAndProofs(t, ctx, testSerialisedPoly(128, 3))
+	commB, cellsB, proofsB := testCellsAndProofs(t, ctx, testSerialisedPoly(128, 1000))
+
+	acc := ctx.NewCustodyAccumulator()
+	if err := acc.Verify(); err != nil {
+		t.Error("an empty accumulator should verify")
+	}
+
+	// Custody of columns 1 and 2 for both blobs
+	for _, column := range []uint64{1, 2} {
+		if err := acc.Absorb(column, commA, cellsA[column], proofsA[column]); err != nil {
+			t.Fatal(err)
+		}
+		if err := acc.Absorb(column, commB, cellsB[column], proofsB[column]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if acc.Len() != 4 {
+		t.Fatalf("expected 4 cells, got %d", acc.Len())
+	}
+	if err := acc.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cells are not modified, so they can be absorbed again
+	if err := acc.Absorb(3, commA, cellsA[3], proofsA[3]); err != nil {
+		t.Fatal(err)
+	}
+	// A cell for the wrong commitment is found
+	if err := acc.Absorb(3, commB, cellsA[3], proofsA[3]); err != nil {
+		t.Fatal(err)
+	}
+	err := acc.Verify()
+	var custodyErr *CustodyCheckError
+	if !errors.As(err, &custodyErr) || !errors.Is(err, ErrCustodyCheckFailed) {
+		t.Fatalf("expected a custody check error, got %v", err)
+	}
+	if custodyErr.Index != 5 || custodyErr.Column != 3 {
+		t.Errorf("expected the invalid cell to be found, got %+v", custodyErr)
+	}
+
+	acc.Reset()
+	if acc.Len() != 0 || acc.Verify() != nil {
+		t.Error("a reset accumulator should be empty")
+	}
+
+	if err := acc.Absorb(ctx.CellsPerExtBlob(), commA, cellsA[0], proofsA[0]); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+	if err := acc.Absorb(0, commA, cellsA[0][1:], proofsA[0]); err != ErrInvalidCellSize {
+		t.Error("expected an error for a cell of the wrong size")
+	}
+}
@@ -0,0 +1,106 @@
+package context
+
+import (
+	stdcontext "context"
+	"fmt"
+	"time"
+)
+
+// Number of blobs that are verified together before checking the deadline again
+const deadlineSubBatchSize = 8
+
+// Returned when a deadline passes before every item in a batch has been verified.
+//
+// The items before NumVerified were verified successfully, so only the remaining
+// items need to be requeued.
+// errors.Is(err, context.DeadlineExceeded) reports true for this error.
+type DeadlineExceededError struct {
+	NumVerified  int
+	NumRemaining int
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("deadline exceeded after verifying %d items, %d items remain", e.NumVerified, e.NumRemaining)
+}
+
+func (e *DeadlineExceededError) Is(target error) bool {
+	return target == stdcontext.DeadlineExceeded
+}
+
+// Same as VerifyBlobKZGProofBatch, except that verification stops once the deadline has passed.
+//
+// The blobs are verified in small batches, with the deadline being checked before each one.
+// The number of blobs at the start of the batch whose proofs were verified is always returned,
+// so when the deadline passes or a proof is invalid, the work done so far is not lost.
+// If the deadline passes, the error is a *DeadlineExceededError.
+//
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) VerifyBlobKZGProofBatchWithDeadline(deadline time.Time, serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof) (numVerified int, err error) {
+	defer c.recoverPanic(&err)
+	return c.verifyBlobKZGProofBatchWithDeadline(deadline, serPolys, serComms, kzgProofs, nil)
+}
+
+// Same as VerifyBlobKZGProofBatchWithDeadline, and also returns a report of the work done.
+// The report covers the batches which reached verification, and is nil if there were none.
+//
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) VerifyBlobKZGProofBatchWithDeadlineAndReport(deadline time.Time, serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof) (numVerified int, _ *VerificationReport, err error) {
+	defer c.recoverPanic(&err)
+
+	report := newVerificationReport()
+	numVerified, err = c.verifyBlobKZGProofBatchWithDeadline(deadline, serPolys, serComms, kzgProofs, report)
+	return numVerified, report.ifFinished(), err
+}
+
+// The report is filled in if it is not nil
+func (c *Context) verifyBlobKZGProofBatchWithDeadline(deadline time.Time, serPolys []SerialisedPoly, serComms SerialisedCommitments, kzgProofs []KZGProof, report *VerificationReport) (numVerified int, err error) {
+	if len(serPolys) != len(serComms) || len(serPolys) != len(kzgProofs) {
+		return 0, ErrBlobBatchLengthMismatch
+	}
+	if err := c.checkBatchSize(len(serPolys)); err != nil {
+		return 0, err
+	}
+
+	// The report covers every batch that reached verification, including when verification stops early
+	var subBatchSizes []int
+	defer func() {
+		if len(subBatchSizes) > 0 {
+			report.finish(numVerified, subBatchSizes...)
+		}
+	}()
+
+	for numVerified < len(serPolys) {
+		// 1. Check that there is still time to verify another batch
+		if !time.Now().Before(deadline) {
+			c.logger.Debug("blob proof batch deadline exceeded", "numVerified", numVerified)
+			return numVerified, &DeadlineExceededError{NumVerified: numVerified, NumRemaining: len(serPolys) - numVerified}
+		}
+
+		start := numVerified
+		end := start + deadlineSubBatchSize
+		if end > len(serPolys) {
+			end = len(serPolys)
+		}
+
+		// 2. Deserialise the batch
+		openings, err := c.prepareBlobOpenings(serPolys[start:end], serComms[start:end], kzgProofs[start:end], report)
+		if err != nil {
+			return numVerified, err
+		}
+		report.endDeserialisation()
+
+		// 3. Verify the batch
+		err = c.verifyBlobOpenings(openings)
+		report.endVerification()
+		if report != nil {
+			subBatchSizes = append(subBatchSizes, end-start)
+		}
+		if err != nil {
+			c.logger.Debug("blob proof batch verification failed", "numVerified", numVerified, "err", err)
+			return numVerified, err
+		}
+		numVerified = end
+	}
+
+	return numVerified, nil
+}
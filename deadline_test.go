@@ -0,0 +1,61 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testBlobProofBatch(t *testing.T, ctx *Context, numBlobs int) ([]SerialisedPoly, SerialisedCommitments, []KZGProof) {
+	serPolys := make([]SerialisedPoly, numBlobs)
+	serComms := make(SerialisedCommitments, numBlobs)
+	proofs := make([]KZGProof, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		serPolys[i] = testSerialisedPoly(4, uint64(i))
+		comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPolys[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		serComms[i] = comm
+		proofs[i] = proof
+	}
+	return serPolys, serComms, proofs
+}
+
+func TestVerifyBlobKZGProofBatchWithDeadline(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	numBlobs := 2*deadlineSubBatchSize + 1
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numBlobs)
+
+	numVerified, err := ctx.VerifyBlobKZGProofBatchWithDeadline(time.Now().Add(time.Minute), copyPolys(serPolys), serComms, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numVerified != numBlobs {
+		t.Errorf("expected %d blobs to be verified, got %d", numBlobs, numVerified)
+	}
+
+	// A deadline in the past stops before any work is done
+	numVerified, err = ctx.VerifyBlobKZGProofBatchWithDeadline(time.Now().Add(-time.Second), copyPolys(serPolys), serComms, proofs)
+	var deadlineErr *DeadlineExceededError
+	if !errors.As(err, &deadlineErr) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Error("deadline error should match context.DeadlineExceeded")
+	}
+	if numVerified != 0 || deadlineErr.NumVerified != 0 || deadlineErr.NumRemaining != numBlobs {
+		t.Errorf("unexpected partial result %d, %+v", numVerified, deadlineErr)
+	}
+
+	// An invalid proof in the second sub-batch keeps the first sub-batch
+	proofs[deadlineSubBatchSize], proofs[deadlineSubBatchSize+1] = proofs[deadlineSubBatchSize+1], proofs[deadlineSubBatchSize]
+	numVerified, err = ctx.VerifyBlobKZGProofBatchWithDeadline(time.Now().Add(time.Minute), copyPolys(serPolys), serComms, proofs)
+	if err == nil {
+		t.Fatal("batch with swapped proofs should not verify")
+	}
+	if numVerified != deadlineSubBatchSize {
+		t.Errorf("expected %d blobs to be verified, got %d", deadlineSubBatchSize, numVerified)
+	}
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Differential checking runs each call against a second implementation of the
+// consensus spec, and returns an error if the results differ.
+//
+// Only the methods with an equivalent in the current spec are checked:
+// PolyToCommitments, ComputeKzgProof and VerifyKZGProof.
+// The second implementation is c-kzg-4844, which is only linked in when building
+// with the `ckzg` tag. See differential_ckzg.go
+
+var ErrDifferentialMismatch = errors.New("result differs from the differential backend")
+
+// A second implementation to compare results against.
+// Scalars are little-endian, as in the rest of this package
+type differentialBackend interface {
+	BlobToKZGCommitment(serPoly SerialisedPoly) (KZGCommitment, error)
+	ComputeKZGProof(serPoly SerialisedPoly, inputPoint [32]byte) (KZGProof, [32]byte, error)
+	VerifyKZGProof(comm KZGCommitment, inputPoint, claimedValue [32]byte, proof KZGProof) (bool, error)
+}
+
+// Creates the differential backend for a Context whose polynomials have `polyDegree` evaluations.
+// This is nil unless a file with a build tag provides a backend
+var newDifferentialBackend func(polyDegree uint64) (differentialBackend, error)
+
+// Makes the Context check every supported call against c-kzg-4844.
+//
+// This is intended for canary nodes, and roughly doubles the cost of each checked call.
+// The library must be built with the `ckzg` tag, and c-kzg-4844 must have loaded the
+// same trusted setup as the Context. Otherwise this option has no effect, which is logged
+// when the Context is created.
+func WithDifferentialCheck() ContextOption {
+	return func(c *Context) {
+		c.differentialCheck = true
+	}
+}
+
+// Creates the differential backend if it was requested
+func (c *Context) setupDifferentialBackend() {
+	if !c.differentialCheck {
+		return
+	}
+	if newDifferentialBackend == nil {
+		c.logger.Debug("differential check requested, but the library was not built with the ckzg tag")
+		return
+	}
+	backend, err := newDifferentialBackend(c.domain.Cardinality)
+	if err != nil {
+		c.logger.Debug("differential check requested, but the backend is not available", "err", err)
+		return
+	}
+	c.differential = backend
+}
+
+// Copies the polynomials if they will be needed for the differential check,
+// since deserialisation modifies them in place
+func (c *Context) copyForDifferential(serPolys []SerialisedPoly) []SerialisedPoly {
+	if c.differential == nil {
+		return nil
+	}
+	polysCopy := make([]SerialisedPoly, len(serPolys))
+	for i, serPoly := range serPolys {
+		polysCopy[i] = make(SerialisedPoly, len(serPoly))
+		for j, serScalar := range serPoly {
+			polysCopy[i][j] = append([]byte{}, serScalar...)
+		}
+	}
+	return polysCopy
+}
+
+func (c *Context) checkCommitmentsDifferential(serPolys []SerialisedPoly, serComms SerialisedCommitments) error {
+	if c.differential == nil {
+		return nil
+	}
+	for i, serPoly := range serPolys {
+		expected, err := c.differential.BlobToKZGCommitment(serPoly)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(expected, serComms[i]) {
+			c.logger.Debug("differential check failed", "method", "PolyToCommitments", "index", i)
+			return ErrDifferentialMismatch
+		}
+	}
+	return nil
+}
+
+func (c *Context) checkKZGProofDifferential(serPoly SerialisedPoly, inputPoint [32]byte, serProof KZGProof, claimedValue [32]byte) error {
+	if c.differential == nil {
+		return nil
+	}
+	expectedProof, expectedValue, err := c.differential.ComputeKZGProof(serPoly, inputPoint)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expectedProof, serProof) || expectedValue != claimedValue {
+		c.logger.Debug("differential check failed", "method", "ComputeKzgProof")
+		return ErrDifferentialMismatch
+	}
+	return nil
+}
+
+func (c *Context) checkVerifyKZGProofDifferential(comm KZGCommitment, proof KZGProof, inputPoint, claimedValue [32]byte, verifyErr error) error {
+	if c.differential == nil {
+		return verifyErr
+	}
+	ok, err := c.differential.VerifyKZGProof(comm, inputPoint, claimedValue, proof)
+	// c-kzg returns an error for malformed inputs, which this package also rejects
+	if err != nil {
+		ok = false
+	}
+	if ok != (verifyErr == nil) {
+		c.logger.Debug("differential check failed", "method", "VerifyKZGProof", "err", verifyErr)
+		return ErrDifferentialMismatch
+	}
+	return verifyErr
+}
@@ -0,0 +1,94 @@
+//go:build ckzg
+
+package context
+
+import (
+	"errors"
+
+	ckzg4844 "github.com/ethereum/c-kzg-4844/bindings/go"
+)
+
+// Building with the `ckzg` tag makes WithDifferentialCheck compare results against c-kzg-4844.
+// This requires cgo.
+// The caller must load the trusted setup into c-kzg-4844, with ckzg4844.LoadTrustedSetupFile,
+// before creating the Context.
+
+const ckzgFieldElementsPerBlob = 4096
+
+func init() {
+	buildTags = append(buildTags, "ckzg")
+	newDifferentialBackend = newCKZGBackend
+}
+
+type ckzgBackend struct{}
+
+func newCKZGBackend(polyDegree uint64) (differentialBackend, error) {
+	// c-kzg-4844 is compiled for the mainnet blob size
+	if polyDegree != ckzgFieldElementsPerBlob {
+		return nil, errors.New("c-kzg-4844 only supports blobs of 4096 field elements")
+	}
+	return ckzgBackend{}, nil
+}
+
+func (ckzgBackend) BlobToKZGCommitment(serPoly SerialisedPoly) (KZGCommitment, error) {
+	blob, err := toCKZGBlob(serPoly)
+	if err != nil {
+		return nil, err
+	}
+	comm, err := ckzg4844.BlobToKZGCommitment(blob)
+	if err != nil {
+		return nil, err
+	}
+	return comm[:], nil
+}
+
+func (ckzgBackend) ComputeKZGProof(serPoly SerialisedPoly, inputPoint [32]byte) (KZGProof, [32]byte, error) {
+	blob, err := toCKZGBlob(serPoly)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	proof, claimedValue, err := ckzg4844.ComputeKZGProof(blob, toCKZGScalar(inputPoint))
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	return proof[:], fromCKZGScalar(claimedValue), nil
+}
+
+func (ckzgBackend) VerifyKZGProof(comm KZGCommitment, inputPoint, claimedValue [32]byte, proof KZGProof) (bool, error) {
+	var ckzgComm, ckzgProof ckzg4844.Bytes48
+	if len(comm) != len(ckzgComm) || len(proof) != len(ckzgProof) {
+		return false, errors.New("serialised point is not 48 bytes")
+	}
+	copy(ckzgComm[:], comm)
+	copy(ckzgProof[:], proof)
+	return ckzg4844.VerifyKZGProof(ckzgComm, toCKZGScalar(inputPoint), toCKZGScalar(claimedValue), ckzgProof)
+}
+
+// c-kzg-4844 follows the current spec, where scalars are big-endian
+func toCKZGBlob(serPoly SerialisedPoly) (*ckzg4844.Blob, error) {
+	var blob ckzg4844.Blob
+	if len(serPoly) != ckzgFieldElementsPerBlob {
+		return nil, errors.New("blob does not contain 4096 field elements")
+	}
+	for i, serScalar := range serPoly {
+		if len(serScalar) != serialisedScalarSize {
+			return nil, errors.New("serialised scalar is not 32 bytes")
+		}
+		for j := 0; j < serialisedScalarSize; j++ {
+			blob[i*serialisedScalarSize+j] = serScalar[serialisedScalarSize-1-j]
+		}
+	}
+	return &blob, nil
+}
+
+func toCKZGScalar(scalar [32]byte) ckzg4844.Bytes32 {
+	var res ckzg4844.Bytes32
+	for i := 0; i < len(scalar); i++ {
+		res[i] = scalar[len(scalar)-1-i]
+	}
+	return res
+}
+
+func fromCKZGScalar(scalar ckzg4844.Bytes32) [32]byte {
+	return [32]byte(toCKZGScalar([32]byte(scalar)))
+}
@@ -0,0 +1,79 @@
+package context
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Differential backend which uses a second Context, optionally corrupting its results
+type contextBackend struct {
+	ctx     *Context
+	corrupt bool
+}
+
+func (b contextBackend) BlobToKZGCommitment(serPoly SerialisedPoly) (KZGCommitment, error) {
+	comms, err := b.ctx.PolyToCommitments([]SerialisedPoly{serPoly})
+	if err != nil {
+		return nil, err
+	}
+	if b.corrupt {
+		comms[0][len(comms[0])-1] ^= 1
+	}
+	return comms[0], nil
+}
+
+func (b contextBackend) ComputeKZGProof(serPoly SerialisedPoly, inputPoint [32]byte) (KZGProof, [32]byte, error) {
+	proof, _, claimedValue, err := b.ctx.ComputeKzgProof(serPoly, inputPoint)
+	if b.corrupt {
+		claimedValue[0] ^= 1
+	}
+	return proof, claimedValue, err
+}
+
+func (b contextBackend) VerifyKZGProof(comm KZGCommitment, inputPoint, claimedValue [32]byte, proof KZGProof) (bool, error) {
+	err := b.ctx.VerifyKZGProof(comm, proof, inputPoint, claimedValue)
+	return (err == nil) != b.corrupt, nil
+}
+
+func TestDifferentialCheck(t *testing.T) {
+	for _, corrupt := range []bool{false, true} {
+		ctx := NewContextInsecure(4, 1234)
+		ctx.differential = contextBackend{ctx: NewContextInsecure(4, 1234), corrupt: corrupt}
+
+		check := func(method string, err error) {
+			if corrupt && !errors.Is(err, ErrDifferentialMismatch) {
+				t.Errorf("%s: expected a mismatch, got %v", method, err)
+			}
+			if !corrupt && err != nil {
+				t.Errorf("%s: %v", method, err)
+			}
+		}
+
+		serPoly := testSerialisedPoly(4, 1)
+		_, err := ctx.PolyToCommitments([]SerialisedPoly{copyPoly(serPoly)})
+		check("PolyToCommitments", err)
+
+		inputPoint := EncodeScalarLE(fr.NewElement(99))
+		_, _, _, err = ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+		check("ComputeKzgProof", err)
+
+		honest := NewContextInsecure(4, 1234)
+		proof, comm, claimedValue, err := honest.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("VerifyKZGProof", ctx.VerifyKZGProof(comm, proof, inputPoint, claimedValue))
+	}
+}
+
+func TestDifferentialCheckUnavailable(t *testing.T) {
+	if newDifferentialBackend != nil {
+		t.Skip("built with a differential backend")
+	}
+	ctx := NewContextInsecure(4, 1234, WithDifferentialCheck())
+	if ctx.differential != nil {
+		t.Error("no backend should be set without the ckzg build tag")
+	}
+}
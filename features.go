@@ -0,0 +1,93 @@
+package context
+
+import (
+	"crypto/sha256"
+	"runtime"
+	"runtime/debug"
+)
+
+// The name of the backend used for group operations and pairings.
+// Files with build tags that change the backend overwrite this in an init function
+var backendName = "gnark-crypto"
+
+const gnarkModulePath = "github.com/consensys/gnark-crypto"
+
+// Build tags which change the behaviour of the library.
+// Files with build tags register themselves here in an init function
+var buildTags []string
+
+// Describes the configuration that the library is running with.
+//
+// This is intended to be attached to bug reports and benchmarks, so that
+// they state exactly what was running.
+type Features struct {
+	// Library used for group operations and pairings
+	Backend string
+	// Version of gnark-crypto that was linked in, if known
+	GnarkCryptoVersion string
+	// Whether MSMs use precomputed tables for the fixed SRS points
+	MSMPrecompute bool
+	// Number of goroutines used by default for parallel operations
+	DefaultParallelism int
+	// Maximum number of goroutines used for each MSM
+	MSMTasks int
+	// Number of evaluations in each polynomial
+	PolyDegree uint64
+	// SHA256 hash of the serialised setup: the commit key followed by the opening key
+	SetupHash [32]byte
+	// Build tags that the library was compiled with
+	BuildTags []string
+}
+
+// Reports the configuration that the context is running with
+func (c *Context) Features() Features {
+	return Features{
+		Backend:            backendName,
+		GnarkCryptoVersion: gnarkCryptoVersion(),
+		MSMPrecompute:      false,
+		DefaultParallelism: runtime.NumCPU(),
+		MSMTasks:           c.msmTaskCount(),
+		PolyDegree:         c.domain.Cardinality,
+		SetupHash:          c.setupHash(),
+		BuildTags:          append([]string{}, buildTags...),
+	}
+}
+
+func (c *Context) msmTaskCount() int {
+	if c.msmTasks > 0 {
+		return c.msmTasks
+	}
+	return runtime.NumCPU()
+}
+
+func (c *Context) setupHash() [32]byte {
+	hasher := sha256.New()
+	for i := 0; i < len(c.commitKey.G1); i++ {
+		serPoint := c.commitKey.G1[i].Bytes()
+		hasher.Write(serPoint[:])
+	}
+
+	genG1 := c.openKey.GenG1.Bytes()
+	hasher.Write(genG1[:])
+	genG2 := c.openKey.GenG2.Bytes()
+	hasher.Write(genG2[:])
+	alphaG2 := c.openKey.AlphaG2.Bytes()
+	hasher.Write(alphaG2[:])
+
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+	return digest
+}
+
+func gnarkCryptoVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path == gnarkModulePath {
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,8 @@
+//go:build blst
+
+package context
+
+func init() {
+	backendName = "blst"
+	buildTags = append(buildTags, "blst")
+}
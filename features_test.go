@@ -0,0 +1,31 @@
+package context
+
+import "testing"
+
+func TestFeatures(t *testing.T) {
+	ctxA := NewContextInsecure(4, 1234)
+	ctxB := NewContextInsecure(4, 1235)
+
+	featuresA := ctxA.Features()
+	if featuresA.PolyDegree != 4 {
+		t.Error("unexpected polynomial degree")
+	}
+	if featuresA.SetupHash != ctxA.Features().SetupHash {
+		t.Error("setup hash should be deterministic")
+	}
+	if featuresA.SetupHash == ctxB.Features().SetupHash {
+		t.Error("different setups should have different hashes")
+	}
+}
+
+func TestSupportedForks(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	if !ctx.SupportsFork(ForkDeneb) {
+		t.Error("expected deneb to be supported")
+	}
+	forks := ctx.SupportedForks()
+	if len(forks) == 0 || forks[0] != ForkDeneb {
+		t.Error("expected deneb to be in the supported forks")
+	}
+}
@@ -0,0 +1,50 @@
+package fiatshamir
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ErrInvalidDomainSeparator = errors.New("domain separation tag must be between 1 and 255 bytes")
+var ErrInvalidHashToFieldCount = errors.New("number of field elements to hash to must be between 1 and 170")
+
+// Number of bytes that are reduced to a single scalar.
+// L = ceil((ceil(log2(r)) + k) / 8), where k = 128 is the security parameter,
+// so that the bias of the reduction is negligible.
+// See: https://www.rfc-editor.org/rfc/rfc9380#section-5
+const hashToFieldBytes = (fr.Bits + 128 + 7) / 8
+
+// The largest count that expand_message_xmd can produce bytes for,
+// it is limited to 255 SHA-256 blocks
+const maxHashToFieldCount = 255 * 32 / hashToFieldBytes
+
+// Hashes `msg` to `count` scalars, following hash_to_field from RFC 9380
+// with expand_message_xmd and SHA-256.
+//
+// `dst` is the domain separation tag, which should be unique to the protocol
+// and to the purpose of the scalars, for example "MYPROTOCOL-V01-CHALLENGE".
+// Protocols building on this library should use this to derive scalars from bytes,
+// instead of reducing a hash modulo the order of the scalar field.
+func HashToField(msg, dst []byte, count int) ([]fr.Element, error) {
+	if len(dst) == 0 || len(dst) > 255 {
+		return nil, ErrInvalidDomainSeparator
+	}
+	if count <= 0 || count > maxHashToFieldCount {
+		return nil, ErrInvalidHashToFieldCount
+	}
+
+	// 1. Expand the message into enough uniform bytes for every scalar
+	uniformBytes, err := ecc.ExpandMsgXmd(msg, dst, count*hashToFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Reduce each chunk, which is interpreted as a big-endian integer
+	scalars := make([]fr.Element, count)
+	for i := 0; i < count; i++ {
+		scalars[i].SetBytes(uniformBytes[i*hashToFieldBytes : (i+1)*hashToFieldBytes])
+	}
+	return scalars, nil
+}
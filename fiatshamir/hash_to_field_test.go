@@ -0,0 +1,83 @@
+package fiatshamir
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestExpandMsgXmdVector(t *testing.T) {
+	// Test vector from RFC 9380, appendix K.1
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	expected, _ := hex.DecodeString("68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235")
+
+	got, err := ecc.ExpandMsgXmd([]byte{}, dst, 0x20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("expand_message_xmd does not match RFC 9380, got %x", got)
+	}
+}
+
+func TestHashToField(t *testing.T) {
+	msg := []byte("abc")
+	dst := []byte("TEST-V01-CHALLENGE")
+
+	scalars, err := HashToField(msg, dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scalars) != 3 {
+		t.Fatalf("expected 3 scalars, got %d", len(scalars))
+	}
+
+	// Each scalar is a 48 byte chunk of the expanded message, reduced modulo r
+	uniformBytes, err := ecc.ExpandMsgXmd(msg, dst, 3*hashToFieldBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		var chunk, reduced big.Int
+		chunk.SetBytes(uniformBytes[i*hashToFieldBytes : (i+1)*hashToFieldBytes])
+		reduced.Mod(&chunk, fr.Modulus())
+
+		var expected fr.Element
+		expected.SetBigInt(&reduced)
+		if scalars[i] != expected {
+			t.Errorf("scalar %d is not the reduced chunk", i)
+		}
+	}
+
+	// The output is deterministic, and separated by the tag
+	again, _ := HashToField(msg, dst, 3)
+	if again[0] != scalars[0] {
+		t.Error("hashing to the field is not deterministic")
+	}
+	other, _ := HashToField(msg, []byte("TEST-V01-OTHER"), 3)
+	if other[0] == scalars[0] {
+		t.Error("different domain separation tags should give different scalars")
+	}
+}
+
+func TestHashToFieldInvalidInputs(t *testing.T) {
+	if _, err := HashToField([]byte("abc"), nil, 1); err != ErrInvalidDomainSeparator {
+		t.Error("expected an error for an empty domain separation tag")
+	}
+	if _, err := HashToField([]byte("abc"), make([]byte, 256), 1); err != ErrInvalidDomainSeparator {
+		t.Error("expected an error for a long domain separation tag")
+	}
+	if _, err := HashToField([]byte("abc"), []byte("TEST"), 0); err != ErrInvalidHashToFieldCount {
+		t.Error("expected an error for a count of zero")
+	}
+	if _, err := HashToField([]byte("abc"), []byte("TEST"), maxHashToFieldCount+1); err != ErrInvalidHashToFieldCount {
+		t.Error("expected an error for a count that is too large")
+	}
+	if _, err := HashToField([]byte("abc"), []byte("TEST"), maxHashToFieldCount); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,89 @@
+package fiatshamir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Kind of data recorded in a Trace
+type TraceKind int
+
+const (
+	// A domain separator label absorbed into the transcript
+	TraceDomainSeparator TraceKind = iota
+	// A message absorbed into the transcript
+	TraceMessage
+	// The hash of the transcript state, computed before squeezing challenges
+	TraceCompressedState
+	// The bytes which were hashed to produce a challenge
+	TraceChallengeInput
+	// A challenge scalar, in little-endian
+	TraceChallenge
+	// The hash of a challenge input, before it is reduced into a challenge scalar
+	TraceChallengeDigest
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceDomainSeparator:
+		return "domain_separator"
+	case TraceMessage:
+		return "message"
+	case TraceCompressedState:
+		return "compressed_state"
+	case TraceChallengeInput:
+		return "challenge_input"
+	case TraceChallenge:
+		return "challenge"
+	case TraceChallengeDigest:
+		return "challenge_digest"
+	default:
+		return "unknown"
+	}
+}
+
+type TraceEntry struct {
+	Kind TraceKind
+	Data []byte
+}
+
+// Records every byte absorbed by a Transcript and every intermediate hash,
+// in the order that they were processed.
+//
+// This is intended for debugging mismatched challenges between two implementations;
+// comparing their traces shows the first point at which they diverge
+type Trace struct {
+	Entries []TraceEntry
+}
+
+// Same as NewTranscript, except that everything including the label is recorded into `trace`
+func NewTranscriptWithTrace(label string, trace *Trace) *Transcript {
+	return NewTranscriptWithHash(label, sha256.New, trace)
+}
+
+// Makes the transcript record into `trace`.
+// Only data absorbed after this call is recorded
+func (t *Transcript) SetTrace(trace *Trace) {
+	t.trace = trace
+}
+
+func (t *Transcript) record(kind TraceKind, data []byte) {
+	if t.trace == nil {
+		return
+	}
+	t.trace.Entries = append(t.trace.Entries, TraceEntry{
+		Kind: kind,
+		Data: append([]byte{}, data...),
+	})
+}
+
+// Returns the trace with one entry per line, with the data hex encoded
+func (tr *Trace) String() string {
+	var sb strings.Builder
+	for i, entry := range tr.Entries {
+		fmt.Fprintf(&sb, "%d %s %s\n", i, entry.Kind, hex.EncodeToString(entry.Data))
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package fiatshamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestTraceRecordsTranscript(t *testing.T) {
+	trace := &Trace{}
+	tr := NewTranscriptWithTrace("my_protocol", trace)
+	tr.AppendScalar(fr.NewElement(12))
+	challenges := tr.ChallengeScalars(2)
+
+	expectedKinds := []TraceKind{
+		TraceDomainSeparator,
+		TraceMessage,
+		TraceCompressedState,
+		TraceChallengeInput, TraceChallengeDigest, TraceChallenge,
+		TraceChallengeInput, TraceChallengeDigest, TraceChallenge,
+		// The compressed state is absorbed after squeezing
+		TraceMessage,
+	}
+	if len(trace.Entries) != len(expectedKinds) {
+		t.Fatalf("expected %d entries, got %d", len(expectedKinds), len(trace.Entries))
+	}
+	for i, kind := range expectedKinds {
+		if trace.Entries[i].Kind != kind {
+			t.Errorf("entry %d is %s, expected %s", i, trace.Entries[i].Kind, kind)
+		}
+	}
+
+	if !bytes.Equal(trace.Entries[0].Data, []byte("my_protocol")) {
+		t.Error("domain separator was not recorded")
+	}
+	challengeBytes := challenges[1].Bytes()
+	reverse(challengeBytes[:])
+	if !bytes.Equal(trace.Entries[8].Data, challengeBytes[:]) {
+		t.Error("recorded challenge does not match")
+	}
+
+	// Tracing must not change the challenges
+	untraced := NewTranscript("my_protocol")
+	untraced.AppendScalar(fr.NewElement(12))
+	if untraced.ChallengeScalars(2)[1] != challenges[1] {
+		t.Error("tracing changed the challenges")
+	}
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -15,13 +15,32 @@ import (
 // / See: Fiat-Shamir
 type Transcript struct {
 	state hash.Hash
+	// Creates the hash used to compute each challenge
+	newHash HashFunc
+	// Records the absorbed data and intermediate hashes, nil if disabled
+	trace *Trace
 }
 
+// Creates the hash function used by a Transcript
+type HashFunc func() hash.Hash
+
 func NewTranscript(label string) *Transcript {
-	digest := sha256.New()
+	return NewTranscriptWithHash(label, sha256.New, nil)
+}
 
+// Same as NewTranscript, except that `newHash` is used instead of SHA-256 for the state
+// and the challenges. A nil `newHash` uses SHA-256, and a nil `trace` disables recording.
+//
+// Ethereum deployments must use SHA-256, which NewTranscript does.
+// This is for other deployments, which may use a different hash.
+func NewTranscriptWithHash(label string, newHash HashFunc, trace *Trace) *Transcript {
+	if newHash == nil {
+		newHash = sha256.New
+	}
 	transcript := &Transcript{
-		state: digest,
+		state:   newHash(),
+		newHash: newHash,
+		trace:   trace,
 	}
 	transcript.NewProtocol(label)
 
@@ -29,10 +48,12 @@ func NewTranscript(label string) *Transcript {
 }
 
 func (t *Transcript) domainSep(label string) {
+	t.record(TraceDomainSeparator, []byte(label))
 	t.state.Write([]byte(label))
 }
 
 func (t *Transcript) appendMessage(message []byte) {
+	t.record(TraceMessage, message)
 	t.state.Write(message)
 }
 
@@ -140,6 +161,7 @@ func (t *Transcript) ChallengeScalars(numChallenges uint8) []fr.Element {
 
 	// First compress the state
 	compressedState := t.compressState()
+	t.record(TraceCompressedState, compressedState)
 	challenges := make([]fr.Element, numChallenges)
 	for challengeIndex := uint8(0); challengeIndex < numChallenges; challengeIndex++ {
 
@@ -151,19 +173,28 @@ func (t *Transcript) ChallengeScalars(numChallenges uint8) []fr.Element {
 		hashedData[len(hashedData)-1] = challengeIndex
 
 		// Hash the compressed state with the challenged index
-		digest := sha256.Sum256(hashedData)
+		t.record(TraceChallengeInput, hashedData)
+		hasher := t.newHash()
+		hasher.Write(hashedData)
+		digest := hasher.Sum(nil)
+		t.record(TraceChallengeDigest, digest)
 
 		// Reverse the digest, so that we reduce the little-endian
 		// representation
-		utils.ReverseSlice(digest[:])
+		utils.ReverseSlice(digest)
 
 		// Now interpret those bytes as a field element
 		// If gnark had a SetBytesLE method, we would not need to reverse
 		// the bytes
 		var challenge fr.Element
-		challenge.SetBytes(digest[:])
+		challenge.SetBytes(digest)
 
 		challenges[int(challengeIndex)] = challenge
+		if t.trace != nil {
+			challengeBytes := challenge.Bytes()
+			utils.ReverseSlice(challengeBytes[:])
+			t.record(TraceChallenge, challengeBytes[:])
+		}
 	}
 
 	// Clear the state
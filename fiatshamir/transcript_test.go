@@ -1,6 +1,7 @@
 package fiatshamir
 
 import (
+	"crypto/sha512"
 	"testing"
 
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
@@ -67,3 +68,16 @@ func TestSameMessage(t *testing.T) {
 		t.Error("expected different challenges, even though we added the same message")
 	}
 }
+
+func TestTranscriptWithHash(t *testing.T) {
+	defaultHash := NewTranscriptWithHash("my_protocol", nil, nil)
+	sha256Transcript := NewTranscript("my_protocol")
+	if defaultHash.challengeScalar() != sha256Transcript.challengeScalar() {
+		t.Error("a nil hash should use SHA-256")
+	}
+
+	sha512Transcript := NewTranscriptWithHash("my_protocol", sha512.New, nil)
+	if sha512Transcript.challengeScalar() == NewTranscript("my_protocol").challengeScalar() {
+		t.Error("a different hash should give different challenges")
+	}
+}
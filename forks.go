@@ -0,0 +1,58 @@
+package context
+
+// Forks group the exported methods on the Context by the consensus upgrade
+// that introduced them, so that a client can serve logic from before and after
+// an upgrade using the same Context.
+//
+// Deneb (EIP-4844):
+//   - PolyToCommitments (blob_to_kzg_commitment)
+//   - ComputeKzgProof / VerifyKZGProof
+//   - ComputeAggregateKzgProof / VerifyAggregateKzgProof
+//   - ComputeBlobKZGProof / ComputeCommitmentAndBlobProof / VerifyBlobKZGProof / VerifyBlobKZGProofBatch
+//
+// PeerDAS (EIP-7594):
+//   - ComputeCellsAndKZGProofs (compute_cells_and_kzg_proofs) / ComputeCellsAndKZGProofsForIndices
+//   - VerifyCellKZGProof (verify_cell_kzg_proof)
+//   - VerifyCellKZGProofBatch (verify_cell_kzg_proof_batch)
+//   - RecoverCellsAndKZGProofs (recover_cells_and_kzg_proofs)
+type Fork int
+
+const (
+	ForkDeneb Fork = iota
+	ForkPeerDAS
+)
+
+func (f Fork) String() string {
+	switch f {
+	case ForkDeneb:
+		return "deneb"
+	case ForkPeerDAS:
+		return "peerdas"
+	default:
+		return "unknown"
+	}
+}
+
+// Returns true if the Context is able to serve all of the methods for the given fork
+func (c *Context) SupportsFork(fork Fork) bool {
+	switch fork {
+	case ForkDeneb:
+		return true
+	case ForkPeerDAS:
+		// Polynomials which are too small cannot be split into cells
+		return c.cellDomain != nil
+	default:
+		return false
+	}
+}
+
+// Returns all of the forks that the Context is able to serve
+func (c *Context) SupportedForks() []Fork {
+	var forks []Fork
+	for _, fork := range []Fork{ForkDeneb, ForkPeerDAS} {
+		if c.SupportsFork(fork) {
+			forks = append(forks, fork)
+		}
+	}
+	return forks
+}
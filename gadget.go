@@ -0,0 +1,140 @@
+package context
+
+import (
+	"errors"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var ErrInvalidLimbSize = errors.New("limb size must be between 1 and 64 bits")
+
+// The inputs of a KZG opening verification, split into limbs for an in-circuit verifier.
+//
+// Circuits over a different field represent each base field element of BLS12-381 as
+// several limbs. Every value here is split into limbs of the requested size,
+// least significant limb first.
+//
+// The proof is valid if and only if e(PairingG1[0], PairingG2[0]) * e(PairingG1[1], PairingG2[1]) = 1,
+// which is the check done by VerifyKZGProof.
+type GadgetInputs struct {
+	Commitment   G1Limbs
+	Proof        G1Limbs
+	InputPoint   []uint64
+	ClaimedValue []uint64
+
+	PairingG1 [2]G1Limbs
+	PairingG2 [2]G2Limbs
+}
+
+// An affine G1 point, with each coordinate split into limbs.
+// The identity has both coordinates set to zero
+type G1Limbs struct {
+	X []uint64
+	Y []uint64
+}
+
+// An affine G2 point, with each coordinate a0 + a1 * u in Fp2 split into limbs
+type G2Limbs struct {
+	X0, X1 []uint64
+	Y0, Y1 []uint64
+}
+
+// Returns the inputs for verifying a KZG opening proof inside of a circuit.
+// The input point and claimed value are little-endian scalars, as in VerifyKZGProof.
+//
+// The proof is not verified, so that invalid instances can also be exported.
+func (c *Context) ExportGadgetInputs(polynomialKZG KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes [32]byte, limbBits uint) (_ GadgetInputs, err error) {
+	defer c.recoverPanic(&err)
+
+	if limbBits == 0 || limbBits > 64 {
+		return GadgetInputs{}, ErrInvalidLimbSize
+	}
+
+	// 1. Deserialise the points and the scalars
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return GadgetInputs{}, err
+	}
+	quotientComm, err := deserialisePoint(kzgProof)
+	if err != nil {
+		return GadgetInputs{}, err
+	}
+	inputPoint, err := deserialiseScalar(append([]byte{}, inputPointBytes[:]...))
+	if err != nil {
+		return GadgetInputs{}, err
+	}
+	claimedValue, err := deserialiseScalar(append([]byte{}, claimedValueBytes[:]...))
+	if err != nil {
+		return GadgetInputs{}, err
+	}
+
+	// 2. Compute the points which are paired by the verifier
+	proof := kzg.OpeningProof{
+		QuotientComm: quotientComm,
+		InputPoint:   inputPoint,
+		ClaimedValue: claimedValue,
+	}
+	pairingInputs := kzg.ComputePairingInputs(&polyComm, &proof, c.openKey)
+
+	// 3. Split everything into limbs
+	var inputPointBigInt, claimedValueBigInt big.Int
+	inputPoint.ToBigIntRegular(&inputPointBigInt)
+	claimedValue.ToBigIntRegular(&claimedValueBigInt)
+
+	inputs := GadgetInputs{
+		Commitment:   g1ToLimbs(&polyComm, limbBits),
+		Proof:        g1ToLimbs(&quotientComm, limbBits),
+		InputPoint:   splitIntoLimbs(&inputPointBigInt, fr.Bits, limbBits),
+		ClaimedValue: splitIntoLimbs(&claimedValueBigInt, fr.Bits, limbBits),
+	}
+	for i := 0; i < 2; i++ {
+		inputs.PairingG1[i] = g1ToLimbs(&pairingInputs.G1[i], limbBits)
+		inputs.PairingG2[i] = g2ToLimbs(&pairingInputs.G2[i], limbBits)
+	}
+	return inputs, nil
+}
+
+func g1ToLimbs(point *curve.G1Affine, limbBits uint) G1Limbs {
+	return G1Limbs{
+		X: fpToLimbs(&point.X, limbBits),
+		Y: fpToLimbs(&point.Y, limbBits),
+	}
+}
+
+func g2ToLimbs(point *curve.G2Affine, limbBits uint) G2Limbs {
+	return G2Limbs{
+		X0: fpToLimbs(&point.X.A0, limbBits),
+		X1: fpToLimbs(&point.X.A1, limbBits),
+		Y0: fpToLimbs(&point.Y.A0, limbBits),
+		Y1: fpToLimbs(&point.Y.A1, limbBits),
+	}
+}
+
+func fpToLimbs(element *fp.Element, limbBits uint) []uint64 {
+	var elementBigInt big.Int
+	element.ToBigIntRegular(&elementBigInt)
+	return splitIntoLimbs(&elementBigInt, fp.Bits, limbBits)
+}
+
+// Splits a value of at most `bits` bits into limbs of `limbBits` bits, least significant first.
+// The number of limbs only depends on the sizes, so that it is the same for every value
+func splitIntoLimbs(value *big.Int, bits int, limbBits uint) []uint64 {
+	numLimbs := (bits + int(limbBits) - 1) / int(limbBits)
+	limbs := make([]uint64, numLimbs)
+
+	mask := new(big.Int).Lsh(big.NewInt(1), limbBits)
+	mask.Sub(mask, big.NewInt(1))
+
+	remaining := new(big.Int).Set(value)
+	var limb big.Int
+	for i := 0; i < numLimbs; i++ {
+		limb.And(remaining, mask)
+		limbs[i] = limb.Uint64()
+		remaining.Rsh(remaining, limbBits)
+	}
+	return limbs
+}
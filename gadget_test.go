@@ -0,0 +1,83 @@
+package context
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestExportGadgetInputs(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	serPoly := testSerialisedPoly(4, 2)
+	inputPoint := EncodeScalarLE(fr.NewElement(77))
+	proof, comm, claimedValue, err := ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, limbBits := range []uint{64, 51} {
+		inputs, err := ctx.ExportGadgetInputs(comm, proof, inputPoint, claimedValue, limbBits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pairingCheckFromLimbs(t, inputs, limbBits) {
+			t.Errorf("pairing inputs with %d bit limbs should satisfy the pairing check", limbBits)
+		}
+
+		var commPoint curve.G1Affine
+		commPoint.X = fpFromLimbs(inputs.Commitment.X, limbBits)
+		commPoint.Y = fpFromLimbs(inputs.Commitment.Y, limbBits)
+		serComm := commPoint.Bytes()
+		if string(serComm[:]) != string(comm) {
+			t.Error("commitment limbs do not match the commitment")
+		}
+	}
+
+	// A wrong claimed value is exported, but fails the pairing check
+	wrongValue := EncodeScalarLE(fr.NewElement(1))
+	inputs, err := ctx.ExportGadgetInputs(comm, proof, inputPoint, wrongValue, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairingCheckFromLimbs(t, inputs, 64) {
+		t.Error("pairing inputs for a wrong claimed value should not satisfy the pairing check")
+	}
+
+	if _, err := ctx.ExportGadgetInputs(comm, proof, inputPoint, claimedValue, 65); err != ErrInvalidLimbSize {
+		t.Error("expected an error for limbs larger than 64 bits")
+	}
+}
+
+func pairingCheckFromLimbs(t *testing.T, inputs GadgetInputs, limbBits uint) bool {
+	g1 := make([]curve.G1Affine, 2)
+	g2 := make([]curve.G2Affine, 2)
+	for i := 0; i < 2; i++ {
+		g1[i].X = fpFromLimbs(inputs.PairingG1[i].X, limbBits)
+		g1[i].Y = fpFromLimbs(inputs.PairingG1[i].Y, limbBits)
+		g2[i].X.A0 = fpFromLimbs(inputs.PairingG2[i].X0, limbBits)
+		g2[i].X.A1 = fpFromLimbs(inputs.PairingG2[i].X1, limbBits)
+		g2[i].Y.A0 = fpFromLimbs(inputs.PairingG2[i].Y0, limbBits)
+		g2[i].Y.A1 = fpFromLimbs(inputs.PairingG2[i].Y1, limbBits)
+	}
+	ok, err := curve.PairingCheck(g1, g2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ok
+}
+
+func fpFromLimbs(limbs []uint64, limbBits uint) fp.Element {
+	var value, limb big.Int
+	for i := len(limbs) - 1; i >= 0; i-- {
+		value.Lsh(&value, limbBits)
+		limb.SetUint64(limbs[i])
+		value.Or(&value, &limb)
+	}
+	var element fp.Element
+	element.SetBigInt(&value)
+	return element
+}
@@ -0,0 +1,48 @@
+package gethadapter
+
+import "errors"
+
+var (
+	ErrInvalidBlobSize   = errors.New("buffer is not BlobSize bytes")
+	ErrInvalidBlobOffset = errors.New("buffer does not contain BlobSize bytes at the offset")
+)
+
+// A go-ethereum sized blob.
+//
+// kzg4844.Blob has the same underlying type, so pointers convert between the two
+// without copying: (*gethadapter.Blob)(&blob).
+// The casts below return a *Blob which shares memory with its input, so networking
+// code can view a received buffer as a blob without copying it, and without unsafe.
+type Blob [BlobSize]byte
+
+// Views `b` as a blob. `b` must be exactly BlobSize bytes, and the blob shares its memory
+func BlobFromBytes(b []byte) (*Blob, error) {
+	if len(b) != BlobSize {
+		return nil, ErrInvalidBlobSize
+	}
+	return (*Blob)(b), nil
+}
+
+// Views the BlobSize bytes of `buf` starting at `offset` as a blob, for example one blob
+// out of a buffer holding many. The blob shares memory with `buf`
+func BlobFromBuffer(buf []byte, offset int) (*Blob, error) {
+	if offset < 0 || offset > len(buf) || len(buf)-offset < BlobSize {
+		return nil, ErrInvalidBlobOffset
+	}
+	return (*Blob)(buf[offset : offset+BlobSize]), nil
+}
+
+// Views an array as a blob, sharing its memory
+func BlobFromArray(array *[BlobSize]byte) *Blob {
+	return (*Blob)(array)
+}
+
+// Returns the array underlying the blob, this can be passed to BlobToPoly
+func (b *Blob) Array() *[BlobSize]byte {
+	return (*[BlobSize]byte)(b)
+}
+
+// Returns the blob as a slice, which shares its memory
+func (b *Blob) Bytes() []byte {
+	return b[:]
+}
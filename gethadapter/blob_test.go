@@ -0,0 +1,60 @@
+package gethadapter
+
+import "testing"
+
+func TestBlobCasts(t *testing.T) {
+	buf := make([]byte, 2*BlobSize+10)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+
+	blob, err := BlobFromBytes(buf[:BlobSize])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The blob shares memory with the buffer
+	buf[3] = 0xff
+	if blob[3] != 0xff {
+		t.Error("blob should share memory with the buffer")
+	}
+	if &blob.Bytes()[0] != &buf[0] {
+		t.Error("blob bytes should share memory with the buffer")
+	}
+
+	second, err := BlobFromBuffer(buf, BlobSize+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &second[0] != &buf[BlobSize+10] {
+		t.Error("blob should start at the offset")
+	}
+
+	array := second.Array()
+	if BlobFromArray(array) != second {
+		t.Error("array casts should round trip")
+	}
+	serPoly := BlobToPoly(blob.Array())
+	if len(serPoly) != FieldElementsPerBlob {
+		t.Error("blob should convert to a polynomial")
+	}
+}
+
+func TestBlobCastsInvalid(t *testing.T) {
+	buf := make([]byte, BlobSize+10)
+
+	if _, err := BlobFromBytes(buf); err != ErrInvalidBlobSize {
+		t.Error("expected an error for a buffer which is too large")
+	}
+	if _, err := BlobFromBytes(buf[:BlobSize-1]); err != ErrInvalidBlobSize {
+		t.Error("expected an error for a buffer which is too small")
+	}
+
+	for _, offset := range []int{-1, 11, len(buf), len(buf) + 1} {
+		if _, err := BlobFromBuffer(buf, offset); err != ErrInvalidBlobOffset {
+			t.Errorf("expected an error for offset %d", offset)
+		}
+	}
+	if _, err := BlobFromBuffer(buf, 10); err != nil {
+		t.Error(err)
+	}
+}
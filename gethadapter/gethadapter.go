@@ -0,0 +1,109 @@
+// Package gethadapter converts between the types used by go-ethereum's kzg4844 package
+// and the types used by this library.
+//
+// go-ethereum is not imported, so that this library does not depend on it. Instead the
+// functions here take arrays with the same underlying types, which go-ethereum's types
+// convert to and from without unsafe casts:
+//
+//	kzg4844.Blob       -> (*[gethadapter.BlobSize]byte)(&blob)
+//	kzg4844.Commitment -> [48]byte(commitment)
+//	kzg4844.Proof      -> [48]byte(proof)
+//	kzg4844.Point      -> [32]byte(point)
+//	common.Hash        -> common.Hash(versionedHash)
+//
+// Note: go-ethereum encodes scalars in big-endian, as in the consensus specs,
+// whereas this library encodes them in little-endian. The conversions take care of this.
+package gethadapter
+
+import (
+	"errors"
+
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+const (
+	// Number of scalars in a go-ethereum blob
+	FieldElementsPerBlob = 4096
+	// Number of bytes in a go-ethereum blob
+	BlobSize = FieldElementsPerBlob * scalarSize
+
+	scalarSize = 32
+
+	// Version byte for versioned hashes of KZG commitments
+	VersionedHashVersionKZG = context.VersionedHashVersionKZG
+)
+
+var (
+	ErrInvalidCommitmentSize = errors.New("serialised commitment is not 48 bytes")
+	ErrInvalidPolySize       = errors.New("polynomial does not contain FieldElementsPerBlob scalars")
+	ErrInvalidScalarSize     = errors.New("serialised scalar is not 32 bytes")
+)
+
+// Converts a go-ethereum blob into a SerialisedPoly.
+// The blob is not modified, and the scalars are not checked to be canonical
+func BlobToPoly(blob *[BlobSize]byte) context.SerialisedPoly {
+	serPoly := make(context.SerialisedPoly, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		serScalar := make([]byte, scalarSize)
+		copy(serScalar, blob[i*scalarSize:(i+1)*scalarSize])
+		utils.ReverseSlice(serScalar)
+		serPoly[i] = serScalar
+	}
+	return serPoly
+}
+
+// Converts a SerialisedPoly with FieldElementsPerBlob scalars into a go-ethereum blob
+func PolyToBlob(serPoly context.SerialisedPoly) (*[BlobSize]byte, error) {
+	if len(serPoly) != FieldElementsPerBlob {
+		return nil, ErrInvalidPolySize
+	}
+	var blob [BlobSize]byte
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if len(serPoly[i]) != scalarSize {
+			return nil, ErrInvalidScalarSize
+		}
+		chunk := blob[i*scalarSize : (i+1)*scalarSize]
+		copy(chunk, serPoly[i])
+		utils.ReverseSlice(chunk)
+	}
+	return &blob, nil
+}
+
+// Converts a go-ethereum point or claimed value, which are big-endian, into this library's encoding
+func PointToBytes(point [32]byte) [32]byte {
+	return [32]byte(context.ScalarBytesBE(point).ToLE())
+}
+
+// Converts a point or claimed value from this library's encoding into go-ethereum's
+func PointFromBytes(serScalar [32]byte) [32]byte {
+	return [32]byte(context.ScalarBytesLE(serScalar).ToBE())
+}
+
+// Commitments and proofs use the same encoding, so these only change the type
+
+func CommitmentToBytes(commitment [48]byte) context.KZGCommitment {
+	return append([]byte{}, commitment[:]...)
+}
+
+func CommitmentFromBytes(serComm context.KZGCommitment) ([48]byte, error) {
+	var commitment [48]byte
+	if len(serComm) != len(commitment) {
+		return commitment, ErrInvalidCommitmentSize
+	}
+	copy(commitment[:], serComm)
+	return commitment, nil
+}
+
+func ProofToBytes(proof [48]byte) context.KZGProof {
+	return CommitmentToBytes(proof)
+}
+
+func ProofFromBytes(serProof context.KZGProof) ([48]byte, error) {
+	return CommitmentFromBytes(serProof)
+}
+
+// Computes the versioned hash of a commitment, which go-ethereum stores as a common.Hash
+func CommitmentToVersionedHash(commitment [48]byte) [32]byte {
+	return context.KZGToVersionedHash(commitment[:])
+}
@@ -0,0 +1,70 @@
+package gethadapter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+func TestBlobRoundTrip(t *testing.T) {
+	var blob [BlobSize]byte
+	// Set the least significant byte of each big-endian scalar
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		blob[i*scalarSize+scalarSize-1] = byte(i)
+	}
+
+	serPoly := BlobToPoly(&blob)
+	if serPoly[5][0] != 5 {
+		t.Error("scalars should be converted to little-endian")
+	}
+
+	got, err := PolyToBlob(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != blob {
+		t.Error("blob did not round trip")
+	}
+
+	if _, err := PolyToBlob(serPoly[1:]); err != ErrInvalidPolySize {
+		t.Error("expected an error for a polynomial of the wrong size")
+	}
+}
+
+func TestPointConversion(t *testing.T) {
+	var point [32]byte
+	point[31] = 7
+
+	serScalar := PointToBytes(point)
+	scalar, err := context.ScalarFromBytes(serScalar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scalar.Equal(context.ScalarFromUint64(7)) {
+		t.Error("point was not converted to little-endian")
+	}
+	if PointFromBytes(serScalar) != point {
+		t.Error("point did not round trip")
+	}
+}
+
+func TestCommitmentConversion(t *testing.T) {
+	var commitment [48]byte
+	commitment[0] = 0xc0
+
+	got, err := CommitmentFromBytes(CommitmentToBytes(commitment))
+	if err != nil || got != commitment {
+		t.Error("commitment did not round trip")
+	}
+	if _, err := ProofFromBytes(make([]byte, 47)); err != ErrInvalidCommitmentSize {
+		t.Error("expected an error for a short proof")
+	}
+
+	versionedHash := CommitmentToVersionedHash(commitment)
+	expected := sha256.Sum256(commitment[:])
+	if versionedHash[0] != VersionedHashVersionKZG || !bytes.Equal(versionedHash[1:], expected[1:]) {
+		t.Error("incorrect versioned hash")
+	}
+}
@@ -2,7 +2,11 @@ module github.com/crate-crypto/go-proto-danksharding-crypto
 
 go 1.17
 
-require github.com/consensys/gnark-crypto v0.8.0
+require (
+	github.com/consensys/gnark-crypto v0.8.0
+	github.com/ethereum/c-kzg-4844 v1.0.3
+	github.com/supranational/blst v0.3.14
+)
 
 require (
 	github.com/consensys/bavard v0.1.13 // indirect
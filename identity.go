@@ -0,0 +1,46 @@
+package context
+
+import (
+	"bytes"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// The identity of G1, also known as the point at infinity, appears in a few edge cases:
+//
+//   - The commitment to the zero polynomial, which is the commitment to a blob of zeroes,
+//     is the identity.
+//   - The proof for a constant polynomial, including the zero polynomial, is the identity,
+//     since the quotient polynomial is zero. This holds for opening proofs and blob proofs.
+//
+// The identity is a valid commitment and a valid proof, so it is accepted by every
+// verification method. It is serialised in compressed form as 0xc0 followed by 47 zero bytes,
+// setting the compression and infinity flags.
+
+// Returns the compressed serialisation of the identity of G1
+func IdentityG1Point() SerialisedG1Point {
+	var identity curve.G1Affine
+	serIdentity := identity.Bytes()
+	return serIdentity[:]
+}
+
+// Returns true if the point is the compressed serialisation of the identity of G1.
+// The encoding must be canonical, so that the padding after the flags is all zero
+func IsIdentityG1Point(serPoint SerialisedG1Point) bool {
+	return bytes.Equal(serPoint, IdentityG1Point())
+}
+
+// Returns a blob of zeroes, which represents the zero polynomial
+func (c *Context) ZeroBlob() SerialisedPoly {
+	serPoly := make(SerialisedPoly, c.domain.Cardinality)
+	for i := 0; i < len(serPoly); i++ {
+		serPoly[i] = make([]byte, serialisedScalarSize)
+	}
+	return serPoly
+}
+
+// Returns the commitment to the zero polynomial, this is the commitment to ZeroBlob.
+// It is the identity, so it can be returned without computing an MSM
+func (c *Context) ZeroPolynomialCommitment() KZGCommitment {
+	return IdentityG1Point()
+}
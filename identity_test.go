@@ -0,0 +1,89 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestIdentityG1Point(t *testing.T) {
+	identity := IdentityG1Point()
+	if len(identity) != serialisedG1Size || identity[0] != 0xc0 {
+		t.Fatalf("unexpected serialisation of the identity %x", identity)
+	}
+	for _, b := range identity[1:] {
+		if b != 0 {
+			t.Fatalf("unexpected serialisation of the identity %x", identity)
+		}
+	}
+	if !IsIdentityG1Point(identity) {
+		t.Error("identity should be recognised")
+	}
+
+	point, err := deserialisePoint(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !point.IsInfinity() {
+		t.Error("identity should deserialise to the point at infinity")
+	}
+}
+
+func TestZeroPolynomialCommitmentAndProofs(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	comms, err := ctx.PolyToCommitments([]SerialisedPoly{ctx.ZeroBlob()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(comms[0], ctx.ZeroPolynomialCommitment()) {
+		t.Error("commitment to the zero blob should be the identity")
+	}
+
+	inputPoint := EncodeScalarLE(fr.NewElement(42))
+	proof, comm, claimedValue, err := ctx.ComputeKzgProof(ctx.ZeroBlob(), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsIdentityG1Point(proof) || !IsIdentityG1Point(comm) || claimedValue != [32]byte{} {
+		t.Error("proof for the zero polynomial should be the identity with a claimed value of zero")
+	}
+	if err := ctx.VerifyKZGProof(comm, proof, inputPoint, claimedValue); err != nil {
+		t.Error(err)
+	}
+
+	blobProof, err := ctx.ComputeBlobKZGProof(ctx.ZeroBlob(), ctx.ZeroPolynomialCommitment())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsIdentityG1Point(blobProof) {
+		t.Error("blob proof for the zero polynomial should be the identity")
+	}
+	if err := ctx.VerifyBlobKZGProof(ctx.ZeroBlob(), ctx.ZeroPolynomialCommitment(), blobProof); err != nil {
+		t.Error(err)
+	}
+
+	// The proof for a constant polynomial is also the identity
+	constantPoly := make(SerialisedPoly, 4)
+	for i := range constantPoly {
+		constantPoly[i] = serialiseScalar(fr.NewElement(7))
+	}
+	_, constantProof, err := ctx.ComputeCommitmentAndBlobProof(constantPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsIdentityG1Point(constantProof) {
+		t.Error("blob proof for a constant polynomial should be the identity")
+	}
+
+	// The identity is not a valid proof for a non-constant polynomial
+	serPoly := testSerialisedPoly(4, 1)
+	serComm, _, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyBlobKZGProof(copyPoly(serPoly), serComm, IdentityG1Point()); err == nil {
+		t.Error("identity should not be a valid proof for a non-constant polynomial")
+	}
+}
@@ -0,0 +1,92 @@
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+)
+
+var ErrBatchVerifyLengthMismatch = errors.New("number of commitments, points, values and proofs must be the same")
+
+// Verifies many KZG opening proofs at once, where each polynomial may be opened at a different point.
+//
+// For each i, the proof `proofs[i]` attests that the polynomial committed to by `commitments[i]`
+// evaluates to `values[i]` at `points[i]`. Each check is e(C_i - [y_i]G₁ + z_i * π_i, G₂) = e(π_i, [α]G₂),
+// these are combined using random scalars r_i so that only two pairings are needed:
+//
+//	e(\sum r_i(C_i - [y_i]G₁ + z_i * π_i), G₂) * e(-\sum r_i * π_i, [α]G₂) == 1
+//
+// The random scalars are sampled from the operating system, so a prover cannot
+// choose proofs which cancel each other out.
+func BatchVerifyOpeningProofs(commitments []Commitment, points []fr.Element, values []fr.Element, proofs []curve.G1Affine, openKey *OpeningKey) error {
+	n := len(commitments)
+	if len(points) != n || len(values) != n || len(proofs) != n {
+		return ErrBatchVerifyLengthMismatch
+	}
+	if n == 0 {
+		return nil
+	}
+	if n == 1 {
+		proof := OpeningProof{
+			QuotientComm: proofs[0],
+			InputPoint:   points[0],
+			ClaimedValue: values[0],
+		}
+		return Verify(&commitments[0], &proof, openKey)
+	}
+
+	// 1. Sample the random scalars
+	randomScalars := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		_, err := randomScalars[i].SetRandom()
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. Compute \sum r_i * C_i + \sum (r_i * z_i) * π_i - [\sum r_i * y_i]G₁
+	msmPoints := make([]curve.G1Affine, 0, 2*n+1)
+	msmScalars := make([]fr.Element, 0, 2*n+1)
+
+	msmPoints = append(msmPoints, commitments...)
+	msmScalars = append(msmScalars, randomScalars...)
+
+	var sumRandomValues fr.Element
+	for i := 0; i < n; i++ {
+		var rz, ry fr.Element
+		rz.Mul(&randomScalars[i], &points[i])
+		msmPoints = append(msmPoints, proofs[i])
+		msmScalars = append(msmScalars, rz)
+
+		ry.Mul(&randomScalars[i], &values[i])
+		sumRandomValues.Add(&sumRandomValues, &ry)
+	}
+	sumRandomValues.Neg(&sumRandomValues)
+	msmPoints = append(msmPoints, openKey.GenG1)
+	msmScalars = append(msmScalars, sumRandomValues)
+
+	foldedLHS, err := multiexp.MultiExp(msmScalars, msmPoints)
+	if err != nil {
+		return err
+	}
+
+	// 3. Compute -\sum r_i * π_i
+	foldedProofs, err := multiexp.MultiExp(randomScalars, proofs)
+	if err != nil {
+		return err
+	}
+	var negFoldedProofs curve.G1Affine
+	negFoldedProofs.Neg(foldedProofs)
+
+	// 4. e(LHS, G₂) * e(-\sum r_i * π_i, [α]G₂) ==? 1
+	check, err := openKey.pairingCheck([]curve.G1Affine{*foldedLHS, negFoldedProofs})
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestBatchVerifyOpeningProofs(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	numProofs := 5
+	comms := make([]Commitment, numProofs)
+	points := make([]fr.Element, numProofs)
+	values := make([]fr.Element, numProofs)
+	proofs := make([]curve.G1Affine, numProofs)
+	for i := 0; i < numProofs; i++ {
+		poly := []fr.Element{fr.NewElement(uint64(i)), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+		comm, err := Commit(poly, &srs.CommitKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		point := fr.NewElement(uint64(100 + i))
+		proof, err := Open(domain, poly, point, &srs.CommitKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		comms[i] = *comm
+		points[i] = proof.InputPoint
+		values[i] = proof.ClaimedValue
+		proofs[i] = proof.QuotientComm
+	}
+
+	err := BatchVerifyOpeningProofs(comms, points, values, proofs, &srs.OpeningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A single proof takes the non-batched path
+	err = BatchVerifyOpeningProofs(comms[:1], points[:1], values[:1], proofs[:1], &srs.OpeningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing one of the claimed values should make the batch fail
+	one := fr.One()
+	values[2].Add(&values[2], &one)
+	err = BatchVerifyOpeningProofs(comms, points, values, proofs, &srs.OpeningKey)
+	if err != ErrVerifyOpeningProof {
+		t.Error("expected the batch to fail with an incorrect value")
+	}
+
+	err = BatchVerifyOpeningProofs(comms, points[1:], values, proofs, &srs.OpeningKey)
+	if err != ErrBatchVerifyLengthMismatch {
+		t.Error("expected an error for mismatched lengths")
+	}
+}
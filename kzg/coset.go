@@ -0,0 +1,257 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Multi-point openings over a coset h * <ω> of the roots of unity of size k.
+//
+// The prover shows that a polynomial f evaluates to the claimed values over the coset,
+// using a single G1 point. The claimed values define the polynomial I(x) of degree < k which
+// interpolates them, and f - I is divisible by the vanishing polynomial of the coset x^k - h^k.
+// The proof is [q(α)]G₁ where q = (f - I) / (x^k - h^k) and is checked with:
+//
+//	e([f(α)]G₁ - [I(α)]G₁, G₂) = e([q(α)]G₁, [α^k - h^k]G₂)
+//
+// The evaluations over the coset are in natural order: index i is the evaluation at h * ω^i,
+// where ω is the generator of `cosetDomain`.
+
+var (
+	ErrCosetSRSTooSmall = errors.New("monomial srs does not contain enough points for the coset size")
+	ErrVerifyCosetProof = errors.New("can't verify coset opening proof")
+)
+
+// Creates a proof for the evaluations of a polynomial in coefficient form over the coset
+// shifted by `cosetShift`. The evaluations are also returned.
+func OpenCoset(cosetDomain *Domain, coeffs []fr.Element, cosetShift fr.Element, srs *MonomialSRS) (curve.G1Affine, []fr.Element, error) {
+	k := int(cosetDomain.Cardinality)
+	if len(coeffs) > len(srs.G1) {
+		return curve.G1Affine{}, nil, ErrInvalidPolynomialSize
+	}
+
+	// 1. Divide by x^k - h^k, the remainder is I(x)
+	var shiftPowK fr.Element
+	shiftPowK.Exp(cosetShift, big.NewInt(int64(k)))
+	quotient, remainder := divideByCosetVanishing(coeffs, k, shiftPowK)
+
+	// 2. Evaluate I(x) over the coset, I(h * ω^i) is the FFT of I(h * x)
+	shiftPowers := make([]fr.Element, k)
+	shiftPowers[0].SetOne()
+	for i := 1; i < k; i++ {
+		shiftPowers[i].Mul(&shiftPowers[i-1], &cosetShift)
+	}
+	for i := 0; i < k; i++ {
+		remainder[i].Mul(&remainder[i], &shiftPowers[i])
+	}
+	evaluations := cosetDomain.FFT(remainder)
+
+	// 3. Commit to the quotient
+	var proof curve.G1Affine
+	if len(quotient) > 0 {
+		quotientComm, err := multiexp.MultiExp(quotient, srs.G1[:len(quotient)])
+		if err != nil {
+			return curve.G1Affine{}, nil, err
+		}
+		proof = *quotientComm
+	}
+
+	return proof, evaluations, nil
+}
+
+// Verifies a proof that the polynomial committed to by `comm` evaluates to `evaluations`
+// over the coset shifted by `cosetShift`
+func VerifyCoset(cosetDomain *Domain, comm *Commitment, proof *curve.G1Affine, cosetShift fr.Element, evaluations []fr.Element, srs *MonomialSRS) error {
+	k := int(cosetDomain.Cardinality)
+	if len(evaluations) != k {
+		return ErrInvalidPolynomialSize
+	}
+	if len(srs.G1) < k || len(srs.G2) < k+1 {
+		return ErrCosetSRSTooSmall
+	}
+
+	// 1. Interpolate I(x), the coefficients of I(h * x) are the inverse FFT of the evaluations
+	interpolationCoeffs := cosetDomain.IFFT(evaluations)
+	var shiftInv fr.Element
+	shiftInv.Inverse(&cosetShift)
+	shiftInvPow := fr.One()
+	for i := 0; i < k; i++ {
+		interpolationCoeffs[i].Mul(&interpolationCoeffs[i], &shiftInvPow)
+		shiftInvPow.Mul(&shiftInvPow, &shiftInv)
+	}
+
+	// 2. [f(α)]G₁ - [I(α)]G₁
+	interpolationComm, err := multiexp.MultiExp(interpolationCoeffs, srs.G1[:k])
+	if err != nil {
+		return err
+	}
+	var lhsJac curve.G1Jac
+	lhsJac.FromAffine(comm)
+	var interpolationJac curve.G1Jac
+	interpolationJac.FromAffine(interpolationComm)
+	lhsJac.SubAssign(&interpolationJac)
+	var lhs curve.G1Affine
+	lhs.FromJacobian(&lhsJac)
+
+	// 3. [α^k - h^k]G₂
+	var shiftPowK fr.Element
+	shiftPowK.Exp(cosetShift, big.NewInt(int64(k)))
+	var shiftPowKBigInt big.Int
+	shiftPowK.ToBigIntRegular(&shiftPowKBigInt)
+	var vanishingJac, shiftG2Jac curve.G2Jac
+	vanishingJac.FromAffine(&srs.G2[k])
+	shiftG2Jac.FromAffine(&srs.G2[0])
+	shiftG2Jac.ScalarMultiplication(&shiftG2Jac, &shiftPowKBigInt)
+	vanishingJac.SubAssign(&shiftG2Jac)
+	var vanishingG2 curve.G2Affine
+	vanishingG2.FromJacobian(&vanishingJac)
+
+	// 4. e([f(α) - I(α)]G₁, G₂) * e(-[q(α)]G₁, [α^k - h^k]G₂) ==? 1
+	var negProof curve.G1Affine
+	negProof.Neg(proof)
+	check, err := pairingCheck(
+		[]curve.G1Affine{lhs, negProof},
+		[]curve.G2Affine{srs.G2[0], vanishingG2},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyCosetProof
+	}
+	return nil
+}
+
+// Verifies many coset opening proofs at once, possibly over different polynomials and cosets.
+//
+// For each i, `proofs[i]` attests that the polynomial committed to by `comms[i]` evaluates to
+// `evaluations[i]` over the coset shifted by `cosetShifts[i]`. Each check from VerifyCoset is rearranged to
+// e(C_i - [I_i(α)]G₁ + h_i^k * π_i, G₂) = e(π_i, [α^k]G₂), and these are combined using
+// random scalars r_i so that only two pairings are needed:
+//
+//	e(\sum r_i(C_i + h_i^k * π_i) - [\sum r_i * I_i(α)]G₁, G₂) * e(-\sum r_i * π_i, [α^k]G₂) == 1
+//
+// Since every I_i has k coefficients, \sum r_i * I_i is computed before committing to it.
+// The random scalars are sampled from the operating system, so a prover cannot
+// choose proofs which cancel each other out.
+func BatchVerifyCosets(cosetDomain *Domain, comms []Commitment, proofs []curve.G1Affine, cosetShifts []fr.Element, evaluations [][]fr.Element, srs *MonomialSRS) error {
+	n := len(comms)
+	if len(proofs) != n || len(cosetShifts) != n || len(evaluations) != n {
+		return ErrBatchVerifyLengthMismatch
+	}
+	if n == 0 {
+		return nil
+	}
+	k := int(cosetDomain.Cardinality)
+	for i := 0; i < n; i++ {
+		if len(evaluations[i]) != k {
+			return ErrInvalidPolynomialSize
+		}
+	}
+	if len(srs.G1) < k || len(srs.G2) < k+1 {
+		return ErrCosetSRSTooSmall
+	}
+
+	// 1. Sample the random scalars
+	randomScalars := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		_, err := randomScalars[i].SetRandom()
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. Compute \sum r_i * I_i, where the coefficients of I_i(h_i * x) are the inverse FFT of the evaluations
+	sumInterpolationCoeffs := make([]fr.Element, k)
+	for i := 0; i < n; i++ {
+		interpolationCoeffs := cosetDomain.IFFT(evaluations[i])
+		var shiftInv fr.Element
+		shiftInv.Inverse(&cosetShifts[i])
+		// r_i * h_i^{-j}
+		factor := randomScalars[i]
+		for j := 0; j < k; j++ {
+			var tmp fr.Element
+			tmp.Mul(&interpolationCoeffs[j], &factor)
+			sumInterpolationCoeffs[j].Add(&sumInterpolationCoeffs[j], &tmp)
+			factor.Mul(&factor, &shiftInv)
+		}
+	}
+
+	// 3. Compute \sum r_i * C_i + \sum (r_i * h_i^k) * π_i - [\sum r_i * I_i(α)]G₁
+	msmPoints := make([]curve.G1Affine, 0, 2*n+k)
+	msmScalars := make([]fr.Element, 0, 2*n+k)
+
+	msmPoints = append(msmPoints, comms...)
+	msmScalars = append(msmScalars, randomScalars...)
+
+	for i := 0; i < n; i++ {
+		shiftPowK := utils.ExpUint64(cosetShifts[i], uint64(k))
+		shiftPowK.Mul(&shiftPowK, &randomScalars[i])
+		msmPoints = append(msmPoints, proofs[i])
+		msmScalars = append(msmScalars, shiftPowK)
+	}
+
+	for j := 0; j < k; j++ {
+		sumInterpolationCoeffs[j].Neg(&sumInterpolationCoeffs[j])
+	}
+	msmPoints = append(msmPoints, srs.G1[:k]...)
+	msmScalars = append(msmScalars, sumInterpolationCoeffs...)
+
+	foldedLHS, err := multiexp.MultiExp(msmScalars, msmPoints)
+	if err != nil {
+		return err
+	}
+
+	// 4. Compute -\sum r_i * π_i
+	foldedProofs, err := multiexp.MultiExp(randomScalars, proofs)
+	if err != nil {
+		return err
+	}
+	var negFoldedProofs curve.G1Affine
+	negFoldedProofs.Neg(foldedProofs)
+
+	// 5. e(LHS, G₂) * e(-\sum r_i * π_i, [α^k]G₂) ==? 1
+	check, err := pairingCheck(
+		[]curve.G1Affine{*foldedLHS, negFoldedProofs},
+		[]curve.G2Affine{srs.G2[0], srs.G2[k]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyCosetProof
+	}
+	return nil
+}
+
+// Divides a polynomial in coefficient form by x^k - c, returning
+// the quotient and the remainder. The remainder always has `k` coefficients
+func divideByCosetVanishing(coeffs []fr.Element, k int, c fr.Element) ([]fr.Element, []fr.Element) {
+	remainder := make([]fr.Element, len(coeffs))
+	copy(remainder, coeffs)
+
+	var quotient []fr.Element
+	if len(coeffs) > k {
+		quotient = make([]fr.Element, len(coeffs)-k)
+	}
+
+	// x^i = x^{i-k} * (x^k - c) + c * x^{i-k}
+	var tmp fr.Element
+	for i := len(remainder) - 1; i >= k; i-- {
+		quotient[i-k] = remainder[i]
+		tmp.Mul(&remainder[i], &c)
+		remainder[i-k].Add(&remainder[i-k], &tmp)
+	}
+
+	if len(remainder) < k {
+		padded := make([]fr.Element, k)
+		copy(padded, remainder)
+		return quotient, padded
+	}
+	return quotient, remainder[:k]
+}
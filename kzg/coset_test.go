@@ -0,0 +1,123 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestOpenVerifyCoset(t *testing.T) {
+	srs, err := NewMonomialSRSInsecure(16, big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosetDomain := NewDomain(4)
+
+	coeffs := make([]fr.Element, 16)
+	for i := 0; i < len(coeffs); i++ {
+		coeffs[i].SetUint64(uint64(i*i + 3))
+	}
+	comm, err := CommitCoefficients(coeffs, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cosetShift := fr.NewElement(5)
+	proof, evaluations, err := OpenCoset(cosetDomain, coeffs, cosetShift, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The evaluations should be over h * ω^i in natural order
+	for i := 0; i < len(evaluations); i++ {
+		var point fr.Element
+		point.Mul(&cosetShift, &cosetDomain.Roots[i])
+		expected := evalCoeffs(coeffs, point)
+		if !evaluations[i].Equal(&expected) {
+			t.Fatalf("incorrect evaluation at index %d", i)
+		}
+	}
+
+	err = VerifyCoset(cosetDomain, comm, &proof, cosetShift, evaluations, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	one := fr.One()
+	evaluations[1].Add(&evaluations[1], &one)
+	err = VerifyCoset(cosetDomain, comm, &proof, cosetShift, evaluations, srs)
+	if err != ErrVerifyCosetProof {
+		t.Error("expected the proof to fail with an incorrect evaluation")
+	}
+}
+
+func TestBatchVerifyCosets(t *testing.T) {
+	srs, err := NewMonomialSRSInsecure(16, big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosetDomain := NewDomain(4)
+
+	// Two polynomials, each opened over two cosets
+	numOpenings := 4
+	comms := make([]Commitment, numOpenings)
+	proofs := make([]curve.G1Affine, numOpenings)
+	shifts := make([]fr.Element, numOpenings)
+	evaluations := make([][]fr.Element, numOpenings)
+	for i := 0; i < numOpenings; i++ {
+		coeffs := make([]fr.Element, 16)
+		for j := 0; j < len(coeffs); j++ {
+			coeffs[j].SetUint64(uint64(j*j + i/2))
+		}
+		comm, err := CommitCoefficients(coeffs, srs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		comms[i] = *comm
+		shifts[i] = fr.NewElement(uint64(5 + i))
+		proofs[i], evaluations[i], err = OpenCoset(cosetDomain, coeffs, shifts[i], srs)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := BatchVerifyCosets(cosetDomain, comms, proofs, shifts, evaluations, srs); err != nil {
+		t.Fatal(err)
+	}
+	if err := BatchVerifyCosets(cosetDomain, nil, nil, nil, nil, srs); err != nil {
+		t.Error("an empty batch should verify")
+	}
+
+	// Swapping two proofs should fail
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	if err := BatchVerifyCosets(cosetDomain, comms, proofs, shifts, evaluations, srs); err != ErrVerifyCosetProof {
+		t.Error("expected the batch to fail with swapped proofs")
+	}
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+
+	one := fr.One()
+	evaluations[3][2].Add(&evaluations[3][2], &one)
+	if err := BatchVerifyCosets(cosetDomain, comms, proofs, shifts, evaluations, srs); err != ErrVerifyCosetProof {
+		t.Error("expected the batch to fail with an incorrect evaluation")
+	}
+
+	if err := BatchVerifyCosets(cosetDomain, comms[:3], proofs, shifts, evaluations, srs); err != ErrBatchVerifyLengthMismatch {
+		t.Error("expected a length mismatch")
+	}
+}
+
+func TestDivideByCosetVanishing(t *testing.T) {
+	// (x^3 + 2x^2 + 3x + 4) / (x^2 - 5) = x + 2, remainder 8x + 14
+	coeffs := []fr.Element{fr.NewElement(4), fr.NewElement(3), fr.NewElement(2), fr.NewElement(1)}
+	quotient, remainder := divideByCosetVanishing(coeffs, 2, fr.NewElement(5))
+
+	expectedQuotient := []fr.Element{fr.NewElement(2), fr.NewElement(1)}
+	expectedRemainder := []fr.Element{fr.NewElement(14), fr.NewElement(8)}
+	for i := 0; i < 2; i++ {
+		if !quotient[i].Equal(&expectedQuotient[i]) || !remainder[i].Equal(&expectedRemainder[i]) {
+			t.Fatal("incorrect division")
+		}
+	}
+}
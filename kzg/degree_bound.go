@@ -0,0 +1,96 @@
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+)
+
+var (
+	ErrInvalidDegreeBound  = errors.New("degree bound must be non-zero, at most the size of the SRS, and have its shift in G2")
+	ErrDegreeBoundExceeded = errors.New("polynomial degree is not below the claimed bound")
+	ErrVerifyDegreeBound   = errors.New("can't verify degree bound proof")
+)
+
+// Proof to the claim that a committed polynomial f(x) has degree
+// strictly less than a bound `k`
+//
+// This uses the shifted commitment technique; the prover commits to
+// x^{n-k} * f(x), which is only possible if deg(f) < k, since the SRS
+// only has `n` powers of alpha in G1. The verifier needs [α^{n-k}]G₂,
+// so the SRS may have fewer G2 powers, but only for large enough bounds
+type DegreeBoundProof struct {
+	// [α^{n-k} * f(α)]G₁
+	ShiftedComm curve.G1Affine
+}
+
+// Create a proof that the polynomial `p` in lagrange form has degree less than `bound`
+func ProveDegreeBound(domain *Domain, p Polynomial, bound uint64, srs *MonomialSRS) (*DegreeBoundProof, error) {
+	n := uint64(len(srs.G1))
+	shift, err := degreeBoundShift(bound, srs)
+	if err != nil {
+		return nil, err
+	}
+
+	coeffs, err := domain.LagrangeToCoefficients(p)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(coeffs)) > n {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	for i := bound; i < uint64(len(coeffs)); i++ {
+		if !coeffs[i].IsZero() {
+			return nil, ErrDegreeBoundExceeded
+		}
+	}
+
+	// Multiplying by x^{n-k} shifts the coefficients up by n-k
+	shiftedComm, err := multiexp.MultiExp(coeffs[:bound], srs.G1[shift:n])
+	if err != nil {
+		return nil, err
+	}
+
+	return &DegreeBoundProof{ShiftedComm: *shiftedComm}, nil
+}
+
+// Verify a proof that the polynomial behind `commitment` has degree less than `bound`
+func VerifyDegreeBound(commitment *Commitment, proof *DegreeBoundProof, bound uint64, srs *MonomialSRS) error {
+	shift, err := degreeBoundShift(bound, srs)
+	if err != nil {
+		return err
+	}
+
+	// e([f(α)]G₁, [α^{n-k}]G₂).e(-[α^{n-k} * f(α)]G₁, G₂) ==? 1
+	var negShiftedComm curve.G1Affine
+	negShiftedComm.Neg(&proof.ShiftedComm)
+
+	check, err := pairingCheck(
+		[]curve.G1Affine{*commitment, negShiftedComm},
+		[]curve.G2Affine{srs.G2[shift], srs.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyDegreeBound
+	}
+	return nil
+}
+
+// Returns n-k, where n is the number of G1 powers in the SRS.
+// This must be the same for the prover and the verifier, since a shift by fewer than n-k
+// would allow the prover to commit to x^{n-k} * f(x) for a polynomial of degree at least k
+func degreeBoundShift(bound uint64, srs *MonomialSRS) (uint64, error) {
+	n := uint64(len(srs.G1))
+	if bound == 0 || bound > n {
+		return 0, ErrInvalidDegreeBound
+	}
+	shift := n - bound
+	if shift >= uint64(len(srs.G2)) {
+		return 0, ErrInvalidDegreeBound
+	}
+	return shift, nil
+}
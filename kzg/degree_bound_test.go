@@ -0,0 +1,97 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestDegreeBoundProofSmoke(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := NewDomain(8)
+	srs, _ := NewSRSInsecure(*domain, secret)
+	monomialSRS, _ := NewMonomialSRSInsecure(domain.Cardinality, secret)
+
+	// Test that the domain ordering is accounted for
+	domain.ReverseRoots()
+	srs.CommitKey.ReversePoints()
+
+	// f(x) = 1 + 2x + 3x^2 has degree 2
+	poly := make(Polynomial, domain.Cardinality)
+	for i := 0; i < len(poly); i++ {
+		x := domain.Roots[i]
+		var tmp fr.Element
+		poly[i].SetUint64(1)
+		tmp.SetUint64(2).Mul(&tmp, &x)
+		poly[i].Add(&poly[i], &tmp)
+		tmp.SetUint64(3).Mul(&tmp, &x).Mul(&tmp, &x)
+		poly[i].Add(&poly[i], &tmp)
+	}
+	comm, _ := Commit(poly, &srs.CommitKey)
+
+	for bound := uint64(3); bound <= domain.Cardinality; bound++ {
+		proof, err := ProveDegreeBound(domain, poly, bound, monomialSRS)
+		if err != nil {
+			t.Fatalf("could not prove degree bound %d: %s", bound, err)
+		}
+		if err := VerifyDegreeBound(comm, proof, bound, monomialSRS); err != nil {
+			t.Errorf("degree bound %d failed to verify", bound)
+		}
+	}
+
+	_, err := ProveDegreeBound(domain, poly, 2, monomialSRS)
+	if err != ErrDegreeBoundExceeded {
+		t.Error("expected the degree bound to be exceeded")
+	}
+
+	// A valid proof for one bound must not verify for a smaller bound
+	proof, _ := ProveDegreeBound(domain, poly, 4, monomialSRS)
+	if err := VerifyDegreeBound(comm, proof, 3, monomialSRS); err == nil {
+		t.Error("proof for bound 4 should not verify for bound 3")
+	}
+}
+
+func TestDegreeBoundFewerG2Powers(t *testing.T) {
+	// 16 powers in G1 and 4 in G2, so only bounds of at least 13 can be checked
+	secret := big.NewInt(1234)
+	domain := NewDomain(16)
+	monomialSRS, _ := NewMonomialSRSInsecure(domain.Cardinality, secret)
+	monomialSRS.G2 = monomialSRS.G2[:4]
+
+	// f(x) = 1 + x + ... + x^10 has degree 10
+	coeffs := make([]fr.Element, 11)
+	for i := range coeffs {
+		coeffs[i].SetOne()
+	}
+	poly := make(Polynomial, domain.Cardinality)
+	for i := range poly {
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			poly[i].Mul(&poly[i], &domain.Roots[i]).Add(&poly[i], &coeffs[j])
+		}
+	}
+	comm, _ := CommitCoefficients(coeffs, monomialSRS)
+
+	for bound := uint64(13); bound <= domain.Cardinality; bound++ {
+		proof, err := ProveDegreeBound(domain, poly, bound, monomialSRS)
+		if err != nil {
+			t.Fatalf("could not prove degree bound %d: %s", bound, err)
+		}
+		if err := VerifyDegreeBound(comm, proof, bound, monomialSRS); err != nil {
+			t.Errorf("degree bound %d failed to verify", bound)
+		}
+	}
+
+	if _, err := ProveDegreeBound(domain, poly, 12, monomialSRS); err != ErrInvalidDegreeBound {
+		t.Error("expected a bound whose shift is not in G2 to be rejected")
+	}
+
+	// Shifting by the number of G2 powers instead of G1 powers would let x^2 * f(x) pass for bound 2
+	shifted := make([]fr.Element, len(coeffs)+2)
+	copy(shifted[2:], coeffs)
+	forgedComm, _ := CommitCoefficients(shifted, monomialSRS)
+	forged := &DegreeBoundProof{ShiftedComm: *forgedComm}
+	if err := VerifyDegreeBound(comm, forged, 2, monomialSRS); err != ErrInvalidDegreeBound {
+		t.Error("expected a bound whose shift is not in G2 to be rejected")
+	}
+}
@@ -1,15 +1,18 @@
 package kzg
 
 import (
+	"errors"
 	"fmt"
-	"math/big"
 	"math/bits"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
 )
 
+var ErrInvalidSubDomainSize = errors.New("size of the sub domain must be a power of two which is at most the size of the domain")
+
 type Domain struct {
 	Cardinality    uint64
 	CardinalityInv fr.Element
@@ -20,6 +23,21 @@ type Domain struct {
 
 	// Roots of unity for the multiplicative subgroup
 	Roots []fr.Element
+
+	// Indicates whether the roots have been bit-reversed
+	rootsBitReversed bool
+
+	// Cached bit-reversal permutation for slices the size of the domain:
+	// index i is swapped with bitReversedIndices[i].
+	// This is nil for domains whose size is not a power of two
+	bitReversedIndices []uint64
+
+	// Maps each root to its index in Roots
+	rootIndices map[fr.Element]int
+
+	// Indicates whether Roots is shared with another domain, so it
+	// must be copied before it is reordered
+	rootsShared bool
 }
 
 // Copied and modified from fft.NewDomain
@@ -42,7 +60,7 @@ func NewDomain(m uint64) *Domain {
 
 	// Generator = FinerGenerator^2 has order x
 	expo := uint64(1 << (maxOrderRoot - logx))
-	domain.Generator.Exp(rootOfUnity, big.NewInt(int64(expo))) // order x
+	domain.Generator = utils.ExpPow2(rootOfUnity, expo) // order x
 	domain.GeneratorInv.Inverse(&domain.Generator)
 	domain.CardinalityInv.SetUint64(uint64(x)).Inverse(&domain.CardinalityInv)
 
@@ -54,30 +72,138 @@ func NewDomain(m uint64) *Domain {
 		current.Mul(&current, &domain.Generator)
 	}
 
+	domain.bitReversedIndices = utils.BitReversedIndices(x)
+	domain.indexRoots()
+
 	return domain
 }
 
 func (d *Domain) ReverseRoots() {
-	utils.BitReverseRoots(d.Roots)
+	if d.rootsShared {
+		roots := make([]fr.Element, len(d.Roots))
+		copy(roots, d.Roots)
+		d.Roots = roots
+		d.rootsShared = false
+	}
+	d.BitReverse(d.Roots)
+	d.rootsBitReversed = !d.rootsBitReversed
+	d.indexRoots()
+}
+
+// Returns the domain of `size` roots of unity, derived from this domain instead of
+// computing the roots again. The size must be a power of two which divides the size of this domain.
+//
+// The roots are in the same order as the roots of this domain. If they are bit-reversed,
+// the roots of the smaller domain are a prefix of the roots of this domain, so the
+// two domains share the same memory. Otherwise, every (n/size)'th root is copied.
+func (d *Domain) SubDomain(size uint64) (*Domain, error) {
+	if size == 0 || !utils.IsPowerOfTwo(size) || d.bitReversedIndices == nil || size > d.Cardinality {
+		return nil, ErrInvalidSubDomainSize
+	}
+	stride := d.Cardinality / size
+
+	subDomain := &Domain{
+		Cardinality:      size,
+		rootsBitReversed: d.rootsBitReversed,
+	}
+	subDomain.Generator = utils.ExpPow2(d.Generator, stride)
+	subDomain.GeneratorInv.Inverse(&subDomain.Generator)
+	subDomain.CardinalityInv.SetUint64(size).Inverse(&subDomain.CardinalityInv)
+
+	if d.rootsBitReversed {
+		// The i'th bit-reversed root of the larger domain is ω^{rev(i) * n/size} for i < size,
+		// which is the i'th bit-reversed root of the smaller domain.
+		// The capacity is limited so that appending to the roots does not overwrite the larger domain
+		subDomain.Roots = d.Roots[:size:size]
+		subDomain.rootsShared = true
+		d.rootsShared = true
+	} else {
+		subDomain.Roots = make([]fr.Element, size)
+		for i := uint64(0); i < size; i++ {
+			subDomain.Roots[i] = d.Roots[i*stride]
+		}
+	}
+
+	subDomain.bitReversedIndices = utils.BitReversedIndices(size)
+	subDomain.indexRoots()
+
+	return subDomain, nil
+}
+
+// Builds the map used by FindRootIndex, this must be called whenever the roots are reordered
+func (d *Domain) indexRoots() {
+	d.rootIndices = make(map[fr.Element]int, len(d.Roots))
+	for i, root := range d.Roots {
+		d.rootIndices[root] = i
+	}
+}
+
+// Applies the bit-reversal permutation to `values` using the table cached on the domain.
+// Slices which are not the size of the domain fall back to computing the indices
+func (d *Domain) BitReverse(values []fr.Element) {
+	if uint64(len(values)) != d.Cardinality || d.bitReversedIndices == nil {
+		utils.BitReverseRoots(values)
+		return
+	}
+	for i, irev := range d.bitReversedIndices {
+		if irev > uint64(i) {
+			values[i], values[irev] = values[irev], values[i]
+		}
+	}
+}
+
+// Same as BitReverse, except over G1 points, such as the lagrange points of an SRS
+func (d *Domain) BitReverseG1(points []curve.G1Affine) {
+	if uint64(len(points)) != d.Cardinality || d.bitReversedIndices == nil {
+		utils.BitReversePoints(points)
+		return
+	}
+	for i, irev := range d.bitReversedIndices {
+		if irev > uint64(i) {
+			points[i], points[irev] = points[irev], points[i]
+		}
+	}
+}
+
+// Same as BitReverse, except over group elements
+func (d *Domain) bitReverseG1Jac(points []curve.G1Jac) {
+	if uint64(len(points)) != d.Cardinality || d.bitReversedIndices == nil {
+		panic("size of slice must be the size of a power of two domain")
+	}
+	for i, irev := range d.bitReversedIndices {
+		if irev > uint64(i) {
+			points[i], points[irev] = points[irev], points[i]
+		}
+	}
 }
 
 // Checks if a point is in the domain.
-// TODO: this is on a hot path, so we should benchmark for faster
-// TODO alternatives
 func (d Domain) isInDomain(point fr.Element) bool {
+	_, ok := d.FindRootIndex(point)
+	return ok
+}
+
+// Returns the index of the point in the roots of the domain, if it is in the domain.
+//
+// This is a map lookup for domains created with a constructor, and falls back to
+// a linear scan otherwise
+func (d Domain) FindRootIndex(point fr.Element) (int, bool) {
+	if d.rootIndices != nil {
+		index, ok := d.rootIndices[point]
+		return index, ok
+	}
 	for i := 0; i < int(d.Cardinality); i++ {
 		if point.Equal(&d.Roots[i]) {
-			return true
+			return i, true
 		}
 	}
-	return false
+	return 0, false
 }
 
 func evaluateAllLagrangeCoefficients(domain Domain, tau fr.Element) []fr.Element {
 	size := domain.Cardinality
 
-	var t_size fr.Element
-	t_size.Exp(tau, big.NewInt(int64(size)))
+	t_size := utils.ExpUint64(tau, size)
 
 	one := fr.One()
 
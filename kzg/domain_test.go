@@ -5,7 +5,9 @@ import (
 	"math/big"
 	"testing"
 
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
 )
 
 func powInt(x, y int) int {
@@ -44,3 +46,137 @@ func TestRootsSmoke(t *testing.T) {
 		t.Error("root does not have an order of 2")
 	}
 }
+
+func TestBitReverseCachedTable(t *testing.T) {
+	domain := NewDomain(16)
+
+	values := make([]fr.Element, domain.Cardinality)
+	for i := 0; i < len(values); i++ {
+		values[i].SetUint64(uint64(i))
+	}
+	expected := make([]fr.Element, len(values))
+	copy(expected, values)
+	utils.BitReverseRoots(expected)
+
+	domain.BitReverse(values)
+	for i := 0; i < len(values); i++ {
+		if !values[i].Equal(&expected[i]) {
+			t.Fatalf("bit-reversal using the cached table differs at index %d", i)
+		}
+	}
+
+	// Slices of a different size should still be permuted correctly
+	small := []fr.Element{fr.NewElement(0), fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+	domain.BitReverse(small)
+	two := fr.NewElement(2)
+	if !small[1].Equal(&two) {
+		t.Error("slice not the size of the domain was not bit-reversed")
+	}
+}
+
+func TestFindRootIndex(t *testing.T) {
+	domain := NewDomain(16)
+
+	for _, reversed := range []bool{false, true} {
+		if reversed {
+			domain.ReverseRoots()
+		}
+		for i := 0; i < len(domain.Roots); i++ {
+			index, ok := domain.FindRootIndex(domain.Roots[i])
+			if !ok || index != i {
+				t.Errorf("root %d was not found at the correct index, bit-reversed: %v", i, reversed)
+			}
+		}
+	}
+
+	if _, ok := domain.FindRootIndex(fr.NewElement(2)); ok {
+		t.Error("two is not a root of unity of order 16")
+	}
+
+	// Domains which were not created with a constructor fall back to a linear scan
+	literal := Domain{Cardinality: 2, Roots: []fr.Element{fr.One(), fr.NewElement(5)}}
+	if index, ok := literal.FindRootIndex(fr.NewElement(5)); !ok || index != 1 {
+		t.Error("root was not found in a domain without a map")
+	}
+}
+
+func TestSubDomain(t *testing.T) {
+	for _, reversed := range []bool{false, true} {
+		domain := NewDomain(16)
+		expected := NewDomain(4)
+		if reversed {
+			domain.ReverseRoots()
+			expected.ReverseRoots()
+		}
+
+		subDomain, err := domain.SubDomain(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subDomain.Cardinality != 4 || subDomain.Generator != expected.Generator ||
+			subDomain.GeneratorInv != expected.GeneratorInv || subDomain.CardinalityInv != expected.CardinalityInv {
+			t.Errorf("sub domain parameters do not match, reversed=%v", reversed)
+		}
+		for i := 0; i < 4; i++ {
+			if subDomain.Roots[i] != expected.Roots[i] {
+				t.Errorf("root %d does not match, reversed=%v", i, reversed)
+			}
+			if index, ok := subDomain.FindRootIndex(expected.Roots[i]); !ok || index != i {
+				t.Errorf("root %d was not indexed, reversed=%v", i, reversed)
+			}
+		}
+	}
+}
+
+func TestSubDomainSharesRoots(t *testing.T) {
+	domain := NewDomain(16)
+	domain.ReverseRoots()
+	subDomain, _ := domain.SubDomain(4)
+
+	if &subDomain.Roots[0] != &domain.Roots[0] {
+		t.Fatal("bit-reversed sub domain should share the roots")
+	}
+
+	// Reordering either domain must not change the other
+	expectedRoots := append([]fr.Element{}, domain.Roots...)
+	subDomain.ReverseRoots()
+	for i := range expectedRoots {
+		if domain.Roots[i] != expectedRoots[i] {
+			t.Fatal("reordering the sub domain changed the domain")
+		}
+	}
+	if subDomain.Roots[1] != NewDomain(4).Roots[1] {
+		t.Error("sub domain was not reordered")
+	}
+
+	subDomain, _ = domain.SubDomain(4)
+	domain.ReverseRoots()
+	if subDomain.Roots[1] != expectedRoots[1] {
+		t.Error("reordering the domain changed the sub domain")
+	}
+}
+
+func TestSubDomainInvalidSize(t *testing.T) {
+	domain := NewDomain(8)
+	for _, size := range []uint64{0, 3, 16} {
+		if _, err := domain.SubDomain(size); err != ErrInvalidSubDomainSize {
+			t.Errorf("expected an error for size %d", size)
+		}
+	}
+}
+
+func TestBitReverseG1(t *testing.T) {
+	domain := NewDomain(8)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	points := append([]curve.G1Affine{}, srs.CommitKey.G1...)
+	domain.BitReverseG1(points)
+
+	expected := append([]curve.G1Affine{}, srs.CommitKey.G1...)
+	utils.BitReversePoints(expected)
+	for i := range points {
+		if !points[i].Equal(&expected[i]) {
+			t.Errorf("point %d does not match utils.BitReversePoints", i)
+		}
+	}
+}
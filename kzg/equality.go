@@ -0,0 +1,107 @@
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+)
+
+// Proves that two commitments made with different setups, for example from different
+// ceremonies or of different sizes, commit to the same polynomial.
+//
+// Both commitments are opened at a challenge z derived from the two commitments.
+// If the polynomials differ, they agree at z with probability at most d / r,
+// where d is the larger degree, so equal openings show that the polynomials are equal.
+// The polynomial must fit in both setups.
+
+const commitmentEqualityProtocolName = "commitment_equality"
+
+var ErrVerifyCommitmentEquality = errors.New("can't verify commitment equality proof")
+
+// Proof that two commitments over different setups commit to the same polynomial
+type CommitmentEqualityProof struct {
+	// Opening proof for the commitment made with the first setup
+	QuotientCommA curve.G1Affine
+	// Opening proof for the commitment made with the second setup
+	QuotientCommB curve.G1Affine
+	// Evaluation of the polynomial at the challenge point
+	ClaimedValue fr.Element
+}
+
+// Create a proof that `commA` and `commB`, the commitments to the polynomial `coeffs`
+// in coefficient form with `srsA` and `srsB` respectively, commit to the same polynomial
+func ProveCommitmentEquality(coeffs []fr.Element, commA, commB *Commitment, srsA, srsB *MonomialSRS) (*CommitmentEqualityProof, error) {
+	if len(coeffs) == 0 || len(coeffs) > len(srsA.G1) || len(coeffs) > len(srsB.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	// 1. Compute the challenge point
+	z := commitmentEqualityChallenge(commA, commB)
+
+	// 2. Compute the quotient (f(x) - f(z)) / (x - z), the remainder is f(z)
+	quotient, remainder := divideByCosetVanishing(coeffs, 1, z)
+
+	// 3. Commit to the quotient with both setups.
+	// A constant polynomial has a zero quotient, so the proofs are the identity
+	proof := CommitmentEqualityProof{ClaimedValue: remainder[0]}
+	if len(quotient) == 0 {
+		return &proof, nil
+	}
+	quotientCommA, err := CommitCoefficients(quotient, srsA)
+	if err != nil {
+		return nil, err
+	}
+	quotientCommB, err := CommitCoefficients(quotient, srsB)
+	if err != nil {
+		return nil, err
+	}
+	proof.QuotientCommA = *quotientCommA
+	proof.QuotientCommB = *quotientCommB
+
+	return &proof, nil
+}
+
+// Verify a proof that `commA` made with `srsA` and `commB` made with `srsB` commit to the same polynomial
+func VerifyCommitmentEquality(commA, commB *Commitment, proof *CommitmentEqualityProof, srsA, srsB *MonomialSRS) error {
+	if len(srsA.G1) == 0 || len(srsA.G2) < 2 || len(srsB.G1) == 0 || len(srsB.G2) < 2 {
+		return ErrMinSRSSize
+	}
+
+	z := commitmentEqualityChallenge(commA, commB)
+
+	// Both commitments must open to the same value at the challenge point
+	openingA := OpeningProof{
+		QuotientComm: proof.QuotientCommA,
+		InputPoint:   z,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	if err := Verify(commA, &openingA, srsA.openingKey()); err != nil {
+		return ErrVerifyCommitmentEquality
+	}
+	openingB := OpeningProof{
+		QuotientComm: proof.QuotientCommB,
+		InputPoint:   z,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	if err := Verify(commB, &openingB, srsB.openingKey()); err != nil {
+		return ErrVerifyCommitmentEquality
+	}
+	return nil
+}
+
+func commitmentEqualityChallenge(commA, commB *Commitment) fr.Element {
+	transcript := fiatshamir.NewTranscript(commitmentEqualityProtocolName)
+	transcript.AppendPoints([]curve.G1Affine{*commA, *commB})
+	return transcript.ChallengeScalars(1)[0]
+}
+
+// The opening key for the monomial SRS
+func (srs *MonomialSRS) openingKey() *OpeningKey {
+	return &OpeningKey{
+		GenG1:   srs.G1[0],
+		GenG2:   srs.G2[0],
+		AlphaG2: srs.G2[1],
+	}
+}
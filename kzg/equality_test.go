@@ -0,0 +1,64 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestCommitmentEqualityProof(t *testing.T) {
+	srsA, _ := NewMonomialSRSInsecure(8, big.NewInt(1234))
+	srsB, _ := NewMonomialSRSInsecure(16, big.NewInt(5678))
+
+	coeffs := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	commA, _ := CommitCoefficients(coeffs, srsA)
+	commB, _ := CommitCoefficients(coeffs, srsB)
+
+	proof, err := ProveCommitmentEquality(coeffs, commA, commB, srsA, srsB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCommitmentEquality(commA, commB, proof, srsA, srsB); err != nil {
+		t.Fatal(err)
+	}
+
+	// A commitment to a different polynomial must not verify
+	otherCoeffs := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(6)}
+	otherCommB, _ := CommitCoefficients(otherCoeffs, srsB)
+	if err := VerifyCommitmentEquality(commA, otherCommB, proof, srsA, srsB); err != ErrVerifyCommitmentEquality {
+		t.Error("proof should not verify for a different commitment")
+	}
+	otherProof, _ := ProveCommitmentEquality(coeffs, commA, otherCommB, srsA, srsB)
+	if err := VerifyCommitmentEquality(commA, otherCommB, otherProof, srsA, srsB); err != ErrVerifyCommitmentEquality {
+		t.Error("commitments to different polynomials should not be proven equal")
+	}
+}
+
+func TestCommitmentEqualityConstantPolynomial(t *testing.T) {
+	srsA, _ := NewMonomialSRSInsecure(4, big.NewInt(1234))
+	srsB, _ := NewMonomialSRSInsecure(4, big.NewInt(5678))
+
+	coeffs := []fr.Element{fr.NewElement(7)}
+	commA, _ := CommitCoefficients(coeffs, srsA)
+	commB, _ := CommitCoefficients(coeffs, srsB)
+
+	proof, err := ProveCommitmentEquality(coeffs, commA, commB, srsA, srsB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCommitmentEquality(commA, commB, proof, srsA, srsB); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommitmentEqualityPolynomialTooLarge(t *testing.T) {
+	srsA, _ := NewMonomialSRSInsecure(8, big.NewInt(1234))
+	srsB, _ := NewMonomialSRSInsecure(4, big.NewInt(5678))
+
+	coeffs := make([]fr.Element, 8)
+	commA, _ := CommitCoefficients(coeffs, srsA)
+	if _, err := ProveCommitmentEquality(coeffs, commA, commA, srsA, srsB); err != ErrInvalidPolynomialSize {
+		t.Error("expected an error when the polynomial does not fit in both setups")
+	}
+}
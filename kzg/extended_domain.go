@@ -0,0 +1,61 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var ErrInvalidExtensionFactor = errors.New("extension factor must be a power of two")
+
+// A domain together with the domain it is extended to for Reed-Solomon encoding.
+//
+// The roots of both domains are bit-reversed. With this ordering the roots of the original
+// domain are the first roots of the extended domain, so the original domain shares the roots
+// of the extended domain, and the extension of a polynomial starts with its original evaluations.
+type ExtendedDomain struct {
+	Original *Domain
+	Extended *Domain
+	// Ratio between the sizes of the extended and original domains
+	ExtensionFactor uint64
+}
+
+// Creates a domain of `size` roots of unity and its extension by `extensionFactor`.
+// Both must be powers of two
+func NewDomainExtended(size uint64, extensionFactor uint64) (*ExtendedDomain, error) {
+	if !utils.IsPowerOfTwo(size) {
+		return nil, ErrInvalidSubDomainSize
+	}
+	if !utils.IsPowerOfTwo(extensionFactor) {
+		return nil, ErrInvalidExtensionFactor
+	}
+
+	extended := NewDomain(size * extensionFactor)
+	extended.ReverseRoots()
+
+	original, err := extended.SubDomain(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendedDomain{
+		Original:        original,
+		Extended:        extended,
+		ExtensionFactor: extensionFactor,
+	}, nil
+}
+
+// Computes the evaluations over the extended domain of a polynomial in lagrange form
+// over the original domain. Both are in bit-reversed order, so the first
+// evaluations returned are the evaluations of `p`
+func (e *ExtendedDomain) Extend(p Polynomial) ([]fr.Element, error) {
+	coeffs, err := e.Original.LagrangeToCoefficients(p)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := e.Extended.FFT(coeffs)
+	e.Extended.BitReverse(evaluations)
+	return evaluations, nil
+}
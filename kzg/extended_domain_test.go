@@ -0,0 +1,58 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestNewDomainExtended(t *testing.T) {
+	domains, err := NewDomainExtended(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domains.Original.Cardinality != 4 || domains.Extended.Cardinality != 16 {
+		t.Fatal("domains have the wrong sizes")
+	}
+
+	// The original domain should be the bit-reversed domain of size 4
+	expected := NewDomain(4)
+	expected.ReverseRoots()
+	for i := 0; i < 4; i++ {
+		if domains.Original.Roots[i] != expected.Roots[i] || domains.Extended.Roots[i] != expected.Roots[i] {
+			t.Errorf("root %d does not match", i)
+		}
+	}
+
+	if _, err := NewDomainExtended(3, 2); err != ErrInvalidSubDomainSize {
+		t.Error("expected an error for a size which is not a power of two")
+	}
+	if _, err := NewDomainExtended(4, 3); err != ErrInvalidExtensionFactor {
+		t.Error("expected an error for an extension factor which is not a power of two")
+	}
+}
+
+func TestExtend(t *testing.T) {
+	domains, _ := NewDomainExtended(4, 2)
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	extension, err := domains.Extend(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every extended evaluation should be the polynomial evaluated at the corresponding root
+	for i, root := range domains.Extended.Roots {
+		expected, err := EvaluateLagrangePolynomial(domains.Original, poly, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if extension[i] != *expected {
+			t.Errorf("extended evaluation %d is incorrect", i)
+		}
+	}
+
+	if _, err := domains.Extend(poly[:3]); err != ErrInvalidPolynomialSize {
+		t.Error("expected an error for a polynomial of the wrong size")
+	}
+}
@@ -0,0 +1,188 @@
+package kzg
+
+import (
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Computes the evaluations of a polynomial in coefficient form
+// over the roots of unity in natural order.
+//
+// Note: the output is in natural order even if the roots of the domain have been bit-reversed
+// The polynomial is padded with zero coefficients up to the size of the domain.
+func (d *Domain) FFT(coeffs []fr.Element) []fr.Element {
+	if uint64(len(coeffs)) > d.Cardinality {
+		panic("number of coefficients exceeds the size of the domain")
+	}
+	values := make([]fr.Element, d.Cardinality)
+	copy(values, coeffs)
+	if !utils.IsPowerOfTwo(d.Cardinality) {
+		return mixedRadixFFT(values, d.Generator)
+	}
+	d.fftInPlace(values, d.Generator)
+	return values
+}
+
+// Computes the coefficients of a polynomial from its evaluations
+// over the roots of unity in natural order.
+//
+// Note: the input is expected in natural order even if the roots of the domain have been bit-reversed.
+// Use LagrangeToCoefficients for polynomials whose evaluations follow the order of the roots
+func (d *Domain) IFFT(evaluations []fr.Element) []fr.Element {
+	if uint64(len(evaluations)) != d.Cardinality {
+		panic("number of evaluations does not equal the size of the domain")
+	}
+	var coeffs []fr.Element
+	if utils.IsPowerOfTwo(d.Cardinality) {
+		coeffs = make([]fr.Element, len(evaluations))
+		copy(coeffs, evaluations)
+		d.fftInPlace(coeffs, d.GeneratorInv)
+	} else {
+		coeffs = mixedRadixFFT(evaluations, d.GeneratorInv)
+	}
+
+	for i := 0; i < len(coeffs); i++ {
+		coeffs[i].Mul(&coeffs[i], &d.CardinalityInv)
+	}
+	return coeffs
+}
+
+// Computes the coefficients of a polynomial in lagrange form.
+//
+// The evaluations are expected to be in the same order as the
+// roots of the domain, so this accounts for the domain being bit-reversed
+func (d *Domain) LagrangeToCoefficients(p Polynomial) ([]fr.Element, error) {
+	if d.Cardinality != uint64(len(p)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	evaluations := p
+	if d.rootsBitReversed {
+		evaluations = make([]fr.Element, len(p))
+		copy(evaluations, p)
+		d.BitReverse(evaluations)
+	}
+	return d.IFFT(evaluations), nil
+}
+
+// Iterative radix-2 Cooley-Tukey FFT.
+// The input is expected in natural order and so is the output.
+// len(values) must be the size of the domain and `generator` must have
+// order len(values)
+func (d *Domain) fftInPlace(values []fr.Element, generator fr.Element) {
+	n := len(values)
+	if n <= 1 {
+		return
+	}
+	d.BitReverse(values)
+
+	// Precompute the twiddle factors for the largest layer.
+	// Smaller layers use a strided subset of these
+	twiddles := utils.ComputePowers(generator, uint(n/2))
+
+	var tmp fr.Element
+	for size := 2; size <= n; size *= 2 {
+		half := size / 2
+		stride := n / size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				tmp.Mul(&values[start+j+half], &twiddles[j*stride])
+				values[start+j+half].Sub(&values[start+j], &tmp)
+				values[start+j].Add(&values[start+j], &tmp)
+			}
+		}
+	}
+}
+
+// Converts points in lagrange basis [L_i(α)]G₁ into points in monomial basis [α^i]G₁
+//
+// Since α^j = \sum_i ω_i^j * L_i(α), this is an FFT over the group elements.
+// The lagrange points are expected in the same order as the roots of the domain
+func (d *Domain) LagrangeToMonomialG1(lagrangePoints []curve.G1Affine) ([]curve.G1Affine, error) {
+	if d.Cardinality != uint64(len(lagrangePoints)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	points := make([]curve.G1Jac, len(lagrangePoints))
+	for i := 0; i < len(points); i++ {
+		points[i].FromAffine(&lagrangePoints[i])
+	}
+	if d.rootsBitReversed {
+		d.bitReverseG1Jac(points)
+	}
+
+	d.fftG1InPlace(points, d.Generator)
+
+	return curve.BatchJacobianToAffineG1(points), nil
+}
+
+// Converts points in monomial basis [α^i]G₁ into points in lagrange basis [L_i(α)]G₁
+//
+// The lagrange points are returned in the same order as the roots of the domain
+func (d *Domain) MonomialToLagrangeG1(monomialPoints []curve.G1Affine) ([]curve.G1Affine, error) {
+	if d.Cardinality != uint64(len(monomialPoints)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	points := make([]curve.G1Jac, len(monomialPoints))
+	for i := 0; i < len(points); i++ {
+		points[i].FromAffine(&monomialPoints[i])
+	}
+
+	d.fftG1InPlace(points, d.GeneratorInv)
+
+	var cardinalityInv big.Int
+	d.CardinalityInv.ToBigIntRegular(&cardinalityInv)
+	utils.ParallelFor(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			points[i].ScalarMultiplication(&points[i], &cardinalityInv)
+		}
+	})
+
+	if d.rootsBitReversed {
+		d.bitReverseG1Jac(points)
+	}
+
+	return curve.BatchJacobianToAffineG1(points), nil
+}
+
+// Same as fftInPlace, except over group elements.
+// The butterflies in each layer are independent, so each layer is split between one goroutine per CPU
+func (d *Domain) fftG1InPlace(values []curve.G1Jac, generator fr.Element) {
+	n := len(values)
+	if n <= 1 {
+		return
+	}
+	d.bitReverseG1Jac(values)
+
+	twiddles := utils.ComputePowers(generator, uint(n/2))
+	twiddlesBigInt := make([]big.Int, len(twiddles))
+	for i := 0; i < len(twiddles); i++ {
+		twiddles[i].ToBigIntRegular(&twiddlesBigInt[i])
+	}
+
+	for size := 2; size <= n; size *= 2 {
+		half := size / 2
+		stride := n / size
+		// Butterfly k is at position k % half in the block starting at (k / half) * size
+		utils.ParallelFor(n/2, func(first, last int) {
+			var tmp curve.G1Jac
+			for k := first; k < last; k++ {
+				start := (k / half) * size
+				j := k % half
+
+				tmp.Set(&values[start+j+half])
+				// The first twiddle factor is always one
+				if j != 0 {
+					tmp.ScalarMultiplication(&tmp, &twiddlesBigInt[j*stride])
+				}
+				values[start+j+half].Set(&values[start+j])
+				values[start+j+half].SubAssign(&tmp)
+				values[start+j].AddAssign(&tmp)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestFFTRoundTrip(t *testing.T) {
+	domain := NewDomain(16)
+
+	coeffs := make([]fr.Element, domain.Cardinality)
+	for i := 0; i < len(coeffs); i++ {
+		coeffs[i].SetUint64(randUint64())
+	}
+
+	evaluations := domain.FFT(coeffs)
+
+	// Check against direct evaluation of the polynomial
+	for i := 0; i < len(evaluations); i++ {
+		expected := evalCoeffs(coeffs, domain.Roots[i])
+		if !expected.Equal(&evaluations[i]) {
+			t.Fatal("fft does not match direct evaluation")
+		}
+	}
+
+	got := domain.IFFT(evaluations)
+	for i := 0; i < len(coeffs); i++ {
+		if !got[i].Equal(&coeffs[i]) {
+			t.Fatal("inverse fft did not return the original coefficients")
+		}
+	}
+}
+
+func TestLagrangeToCoefficientsBitReversed(t *testing.T) {
+	domain := NewDomain(8)
+	domain.ReverseRoots()
+
+	// f(x) = 5 + 3x
+	poly := make(Polynomial, domain.Cardinality)
+	for i := 0; i < len(poly); i++ {
+		poly[i].SetUint64(3).Mul(&poly[i], &domain.Roots[i])
+		five := fr.NewElement(5)
+		poly[i].Add(&poly[i], &five)
+	}
+
+	coeffs, err := domain.LagrangeToCoefficients(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []fr.Element{fr.NewElement(5), fr.NewElement(3)}
+	for i := 0; i < len(coeffs); i++ {
+		var want fr.Element
+		if i < len(expected) {
+			want = expected[i]
+		}
+		if !coeffs[i].Equal(&want) {
+			t.Fatal("incorrect coefficients for bit-reversed domain")
+		}
+	}
+}
+
+func evalCoeffs(coeffs []fr.Element, point fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, &point).Add(&result, &coeffs[i])
+	}
+	return result
+}
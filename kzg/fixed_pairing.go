@@ -0,0 +1,234 @@
+package kzg
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// Pairings against fixed G2 points.
+//
+// The Miller loop computes e(P, Q) by stepping a multiple of Q through the bits of the curve
+// parameter x₀, and at each step multiplying the result by a line through the multiple of Q,
+// evaluated at P. The lines only depend on Q, so for the G2 points of the opening key they are
+// computed once and only evaluated at P during verification.
+//
+// The loop, doubling and addition steps track MillerLoop, g2Proj.DoubleStep and g2Proj.AddMixedStep in
+// ecc/bls12-381/pairing.go of gnark-crypto v0.8.0, and must be updated with them when gnark-crypto is.
+// Its line type is not exported, so the coefficients of each line are kept in the X, Y and Z
+// coordinates of a G2Jac, and temporaries are copied from zeroE2.
+//
+// The lines are only used with the gnark-crypto pairing. Builds which replace pairingCheck, such as
+// the `blst` build, set useFixedLines to false so that every pairing goes through the replacement.
+
+// |x₀| for BLS12-381, the Miller loop runs over its bits from the second most significant one down
+const millerLoopCounter uint64 = 0xd201000000010000
+
+// A zero element of the quadratic extension field, which G2 coordinates are in
+var zeroE2 = curve.G2Affine{}.X
+
+// The lines of the Miller loop for a fixed G2 point, in the order that they are used.
+// A line r0 + r1 * x + r2 * y is stored as X = r0, Y = r1, Z = r2
+type fixedG2Lines struct {
+	lines []curve.G2Jac
+}
+
+// The Miller loop lines for the two G2 points of an opening key, along with the points
+// that they were computed from
+type openingKeyLines struct {
+	genG2Point   curve.G2Affine
+	alphaG2Point curve.G2Affine
+	genG2        fixedG2Lines
+	alphaG2      fixedG2Lines
+}
+
+// Precomputes the Miller loop lines for the G2 points of the opening key, so that
+// Verify does not need to recompute them for every proof.
+//
+// If GenG2 or AlphaG2 are changed afterwards, the lines are no longer used until this is called again.
+// This does nothing if the pairing is not computed by gnark-crypto.
+func (ok *OpeningKey) Precompute() {
+	if !useFixedLines {
+		return
+	}
+	ok.lines = &openingKeyLines{
+		genG2Point:   ok.GenG2,
+		alphaG2Point: ok.AlphaG2,
+		genG2:        precomputeLines(&ok.GenG2),
+		alphaG2:      precomputeLines(&ok.AlphaG2),
+	}
+}
+
+// Returns the precomputed lines, or nil if there are none or if they were computed for other G2 points
+func (ok *OpeningKey) precomputedLines() *openingKeyLines {
+	if !useFixedLines || ok.lines == nil {
+		return nil
+	}
+	if !ok.lines.genG2Point.Equal(&ok.GenG2) || !ok.lines.alphaG2Point.Equal(&ok.AlphaG2) {
+		return nil
+	}
+	return ok.lines
+}
+
+func precomputeLines(q *curve.G2Affine) fixedG2Lines {
+	// 1. Q in homogeneous projective coordinates
+	var qProj curve.G2Jac
+	qProj.X.Set(&q.X)
+	qProj.Y.Set(&q.Y)
+	qProj.Z.SetOne()
+
+	// 2. Record the line from each step, there is always an addition after the first doubling
+	var lines []curve.G2Jac
+	var line curve.G2Jac
+	doubleStep(&qProj, &line)
+	lines = append(lines, line)
+	addMixedStep(&qProj, &line, q)
+	lines = append(lines, line)
+	for i := 61; i >= 0; i-- {
+		doubleStep(&qProj, &line)
+		lines = append(lines, line)
+		if (millerLoopCounter>>uint(i))&1 == 1 {
+			addMixedStep(&qProj, &line, q)
+			lines = append(lines, line)
+		}
+	}
+	return fixedG2Lines{lines: lines}
+}
+
+// Checks that \prod e(P_i, Q_i) == 1, where the Miller loop lines for each Q_i have been precomputed
+func fixedPairingCheck(P []curve.G1Affine, lines []*fixedG2Lines) bool {
+	var one curve.GT
+	one.SetOne()
+	result := fixedMillerLoop(P, lines)
+	result = curve.FinalExponentiation(&result)
+	return result.Equal(&one)
+}
+
+// Same as curve.MillerLoop, except that the lines are read from `lines` instead of being computed from Q
+func fixedMillerLoop(P []curve.G1Affine, lines []*fixedG2Lines) curve.GT {
+	var result, product curve.GT
+	result.SetOne()
+
+	// The pairing with the point at infinity is one
+	var p []curve.G1Affine
+	var q []*fixedG2Lines
+	for k := range P {
+		if !P[k].IsInfinity() {
+			p = append(p, P[k])
+			q = append(q, lines[k])
+		}
+	}
+
+	var l1, l2 curve.G2Jac
+	next := 0
+	for k := range p {
+		evaluateLine(&l1, &q[k].lines[next], &p[k])
+		evaluateLine(&l2, &q[k].lines[next+1], &p[k])
+		product.Mul014By014(&l1.X, &l1.Y, &l1.Z, &l2.X, &l2.Y, &l2.Z)
+		result.Mul(&result, &product)
+	}
+	next += 2
+
+	for i := 61; i >= 0; i-- {
+		result.Square(&result)
+
+		isAddition := (millerLoopCounter>>uint(i))&1 == 1
+		for k := range p {
+			evaluateLine(&l1, &q[k].lines[next], &p[k])
+			if !isAddition {
+				result.MulBy014(&l1.X, &l1.Y, &l1.Z)
+				continue
+			}
+			evaluateLine(&l2, &q[k].lines[next+1], &p[k])
+			product.Mul014By014(&l1.X, &l1.Y, &l1.Z, &l2.X, &l2.Y, &l2.Z)
+			result.Mul(&result, &product)
+		}
+		next++
+		if isAddition {
+			next++
+		}
+	}
+
+	// x₀ is negative
+	result.Conjugate(&result)
+	return result
+}
+
+// Evaluates the line at P, leaving the constant coefficient as it is
+func evaluateLine(res, line *curve.G2Jac, p *curve.G1Affine) {
+	res.X.Set(&line.X)
+	res.Y.MulByElement(&line.Y, &p.X)
+	res.Z.MulByElement(&line.Z, &p.Y)
+}
+
+// Doubles p, which is in homogeneous projective coordinates, and returns the tangent line.
+// See https://eprint.iacr.org/2013/722.pdf (Section 4.3)
+func doubleStep(p *curve.G2Jac, l *curve.G2Jac) {
+	t1, A, B, C, D, E, EE, F, G, H, I, J, K := zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2
+	A.Mul(&p.X, &p.Y)
+	A.Halve()
+	B.Square(&p.Y)
+	C.Square(&p.Z)
+	D.Double(&C).
+		Add(&D, &C)
+	E.MulBybTwistCurveCoeff(&D)
+	F.Double(&E).
+		Add(&F, &E)
+	G.Add(&B, &F)
+	G.Halve()
+	H.Add(&p.Y, &p.Z).
+		Square(&H)
+	t1.Add(&B, &C)
+	H.Sub(&H, &t1)
+	I.Sub(&E, &B)
+	J.Square(&p.X)
+	EE.Square(&E)
+	K.Double(&EE).
+		Add(&K, &EE)
+
+	// X, Y, Z
+	p.X.Sub(&B, &F).
+		Mul(&p.X, &A)
+	p.Y.Square(&G).
+		Sub(&p.Y, &K)
+	p.Z.Mul(&B, &H)
+
+	// Line
+	l.X.Set(&I)
+	l.Y.Double(&J).
+		Add(&l.Y, &J)
+	l.Z.Neg(&H)
+}
+
+// Adds the affine point a to p, which is in homogeneous projective coordinates, and returns the line through them.
+// See https://eprint.iacr.org/2013/722.pdf (Section 4.3)
+func addMixedStep(p *curve.G2Jac, l *curve.G2Jac, a *curve.G2Affine) {
+	Y2Z1, X2Z1, O, L, C, D, E, F, G, H, t0, t1, t2, J := zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2, zeroE2
+	Y2Z1.Mul(&a.Y, &p.Z)
+	O.Sub(&p.Y, &Y2Z1)
+	X2Z1.Mul(&a.X, &p.Z)
+	L.Sub(&p.X, &X2Z1)
+	C.Square(&O)
+	D.Square(&L)
+	E.Mul(&L, &D)
+	F.Mul(&p.Z, &C)
+	G.Mul(&p.X, &D)
+	t0.Double(&G)
+	H.Add(&E, &F).
+		Sub(&H, &t0)
+	t1.Mul(&p.Y, &E)
+
+	// X, Y, Z
+	p.X.Mul(&L, &H)
+	p.Y.Sub(&G, &H).
+		Mul(&p.Y, &O).
+		Sub(&p.Y, &t1)
+	p.Z.Mul(&E, &p.Z)
+
+	t2.Mul(&L, &a.Y)
+	J.Mul(&a.X, &O).
+		Sub(&J, &t2)
+
+	// Line
+	l.X.Set(&J)
+	l.Y.Neg(&O)
+	l.Z.Set(&L)
+}
@@ -0,0 +1,130 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestFixedMillerLoopMatchesGnark(t *testing.T) {
+	_, _, genG1, genG2 := curve.Generators()
+
+	var p1, p2 curve.G1Affine
+	p1.ScalarMultiplication(&genG1, big.NewInt(12345))
+	p2.ScalarMultiplication(&genG1, big.NewInt(678910))
+	var q curve.G2Affine
+	q.ScalarMultiplication(&genG2, big.NewInt(1112))
+
+	qLines := precomputeLines(&q)
+	genLines := precomputeLines(&genG2)
+
+	P := []curve.G1Affine{p1, p2}
+	expected, err := curve.MillerLoop(P, []curve.G2Affine{q, genG2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := fixedMillerLoop(P, []*fixedG2Lines{&qLines, &genLines})
+	if !got.Equal(&expected) {
+		t.Fatal("miller loop with precomputed lines does not match gnark-crypto")
+	}
+
+	// The point at infinity is skipped, like in gnark-crypto
+	var infinity curve.G1Affine
+	P = []curve.G1Affine{infinity, p2}
+	expected, err = curve.MillerLoop(P, []curve.G2Affine{q, genG2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = fixedMillerLoop(P, []*fixedG2Lines{&qLines, &genLines})
+	if !got.Equal(&expected) {
+		t.Fatal("miller loop with the point at infinity does not match gnark-crypto")
+	}
+}
+
+func TestFixedPairingCheck(t *testing.T) {
+	_, _, genG1, genG2 := curve.Generators()
+
+	// e([a]G₁, [b]G₂) * e([-ab]G₁, G₂) == 1
+	var q curve.G2Affine
+	q.ScalarMultiplication(&genG2, big.NewInt(7))
+	var p1, p2 curve.G1Affine
+	p1.ScalarMultiplication(&genG1, big.NewInt(5))
+	p2.ScalarMultiplication(&genG1, big.NewInt(35))
+	p2.Neg(&p2)
+
+	qLines := precomputeLines(&q)
+	genLines := precomputeLines(&genG2)
+	lines := []*fixedG2Lines{&qLines, &genLines}
+
+	if !fixedPairingCheck([]curve.G1Affine{p1, p2}, lines) {
+		t.Error("pairing check should pass")
+	}
+	if fixedPairingCheck([]curve.G1Affine{p1, p1}, lines) {
+		t.Error("pairing check should fail")
+	}
+}
+
+func TestVerifyWithoutPrecompute(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	comm, _ := Commit(poly, &srs.CommitKey)
+	point := samplePointOutsideDomain(*domain)
+	proof, _ := Open(domain, poly, *point, &srs.CommitKey)
+
+	// An opening key which is created directly does not have precomputed lines
+	openKey := OpeningKey{GenG1: srs.OpeningKey.GenG1, GenG2: srs.OpeningKey.GenG2, AlphaG2: srs.OpeningKey.AlphaG2}
+	if err := Verify(comm, &proof, &openKey); err != nil {
+		t.Error(err)
+	}
+	if err := Verify(comm, &proof, &srs.OpeningKey); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyWithStaleLines(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+	otherSRS, _ := NewSRSInsecure(*domain, big.NewInt(5678))
+
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	comm, _ := Commit(poly, &srs.CommitKey)
+	point := samplePointOutsideDomain(*domain)
+	proof, _ := Open(domain, poly, *point, &srs.CommitKey)
+
+	// The lines were computed for the original AlphaG2, so they must not be used for the new one
+	openKey := srs.OpeningKey
+	openKey.AlphaG2 = otherSRS.OpeningKey.AlphaG2
+	if err := Verify(comm, &proof, &openKey); err != ErrVerifyOpeningProof {
+		t.Error("proof should not verify against a different opening key")
+	}
+
+	openKey.Precompute()
+	if err := Verify(comm, &proof, &openKey); err != ErrVerifyOpeningProof {
+		t.Error("proof should not verify against a different opening key")
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	comm, _ := Commit(poly, &srs.CommitKey)
+	point := samplePointOutsideDomain(*domain)
+	proof, _ := Open(domain, poly, *point, &srs.CommitKey)
+
+	b.Run("precomputed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Verify(comm, &proof, &srs.OpeningKey)
+		}
+	})
+	b.Run("not precomputed", func(b *testing.B) {
+		openKey := OpeningKey{GenG1: srs.OpeningKey.GenG1, GenG2: srs.OpeningKey.GenG2, AlphaG2: srs.OpeningKey.AlphaG2}
+		for i := 0; i < b.N; i++ {
+			_ = Verify(comm, &proof, &openKey)
+		}
+	})
+}
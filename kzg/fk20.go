@@ -0,0 +1,170 @@
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// FK20 computes the coset opening proofs for every cell of a polynomial's extension at once.
+//
+// The proof for the coset z * <ω> of size ℓ is [q(α)]G₁ where q = (f - I) / (x^ℓ - z^ℓ). Expanding the
+// division gives [q(α)]G₁ = \sum_{t=1}^{m-1} (z^ℓ)^{t-1} * h_t, where m = n / ℓ and
+//
+//	h_t = \sum_{i >= tℓ} f_i * [α^{i - tℓ}]G₁
+//
+// So every proof is an evaluation of H(y) = \sum_j h_{j+1} * y^j. For the cells of the extension, the
+// values z^ℓ are exactly the roots of unity of the same order as the number of cells, so all of the
+// proofs are computed with one FFT of H. The h_t are computed by grouping the coefficients by their
+// index modulo ℓ: each group gives a Toeplitz matrix of size m, whose product with a vector of SRS points
+// is computed with the circulant embedding in ToeplitzMatrix. The FFTs of the SRS vectors only depend
+// on the SRS, so they are precomputed in an FK20Table.
+//
+// See: https://eprint.iacr.org/2023/033
+
+var (
+	ErrInvalidFK20Shape = errors.New("polynomial size, cell size and number of cells must be powers of two, with the polynomial containing at most NumCells cells")
+	ErrFK20SRSTooSmall  = errors.New("monomial srs does not contain enough points for the polynomial size")
+	ErrFK20PolyTooLarge = errors.New("polynomial has more coefficients than the FK20 table supports")
+)
+
+// The SRS preprocessing for FK20, for polynomials of a fixed size and cells of a fixed size
+type FK20Table struct {
+	// Number of coefficients of the polynomials
+	PolySize uint64
+	// Number of evaluations in each cell, ℓ
+	CellSize uint64
+	// Number of cells that proofs are computed for
+	NumCells uint64
+
+	// Domain of size 2m, over which the Toeplitz products are computed
+	toeplitzDomain *Domain
+	// Domain of size NumCells, over which H is evaluated
+	cellsDomain *Domain
+	// pointsFFT[k][r] is the k'th evaluation of the FFT of the SRS vector for the coefficients
+	// with index r modulo ℓ. It is stored this way round so that each k is a single MSM
+	pointsFFT [][]curve.G1Affine
+}
+
+// Precomputes the SRS points needed to compute `numCells` coset proofs of size `cellSize`,
+// for polynomials with `polySize` coefficients
+func NewFK20Table(srs *MonomialSRS, polySize, cellSize, numCells uint64) (*FK20Table, error) {
+	if !utils.IsPowerOfTwo(polySize) || !utils.IsPowerOfTwo(cellSize) || !utils.IsPowerOfTwo(numCells) {
+		return nil, ErrInvalidFK20Shape
+	}
+	if polySize < cellSize || numCells < polySize/cellSize {
+		return nil, ErrInvalidFK20Shape
+	}
+	m := polySize / cellSize
+	if uint64(len(srs.G1)) < polySize {
+		return nil, ErrFK20SRSTooSmall
+	}
+
+	table := &FK20Table{
+		PolySize:       polySize,
+		CellSize:       cellSize,
+		NumCells:       numCells,
+		toeplitzDomain: NewDomain(2 * m),
+		cellsDomain:    NewDomain(numCells),
+	}
+
+	// 1. For each r, the vector is [α^{(m-1-j)ℓ + r}]G₁ for j in [0, m)
+	pointsFFT := make([][]curve.G1Affine, 2*m)
+	for k := range pointsFFT {
+		pointsFFT[k] = make([]curve.G1Affine, cellSize)
+	}
+	for r := uint64(0); r < cellSize; r++ {
+		vector := make([]curve.G1Affine, m)
+		for j := uint64(0); j < m; j++ {
+			vector[j] = srs.G1[(m-1-j)*cellSize+r]
+		}
+
+		// 2. Take the FFT of the zero padded vector
+		vectorFFT := curve.BatchJacobianToAffineG1(G1VectorFFT(table.toeplitzDomain, vector))
+		for k := range vectorFFT {
+			pointsFFT[k][r] = vectorFFT[k]
+		}
+	}
+	table.pointsFFT = pointsFFT
+
+	return table, nil
+}
+
+// Computes the proof for every cell of the polynomial's extension.
+//
+// The polynomial is in coefficient form, and the proofs are returned in the order of the cells,
+// which is bit-reversed like the extended evaluations. The i'th proof is the same as the proof from
+// OpenCoset for the coset of size CellSize shifted by the (i * CellSize)'th bit-reversed root of
+// unity of order NumCells * CellSize.
+func (t *FK20Table) ComputeCellProofs(coeffs []fr.Element) ([]curve.G1Affine, error) {
+	if uint64(len(coeffs)) > t.PolySize {
+		return nil, ErrFK20PolyTooLarge
+	}
+	m := t.PolySize / t.CellSize
+	cellSize := t.CellSize
+
+	padded := make([]fr.Element, t.PolySize)
+	copy(padded, coeffs)
+
+	// 1. For each r, the Toeplitz matrix has Row[k] = f_{(m-1-k)ℓ + r}, and a column which is zero apart
+	// from the shared diagonal. The FFTs of the circulant embeddings are stored as scalars[k][r]
+	scalars := make([][]fr.Element, 2*m)
+	for k := range scalars {
+		scalars[k] = make([]fr.Element, cellSize)
+	}
+	for r := uint64(0); r < cellSize; r++ {
+		matrix := ToeplitzMatrix{
+			Column: make([]fr.Element, m),
+			Row:    make([]fr.Element, m),
+		}
+		for k := uint64(0); k < m; k++ {
+			matrix.Row[k] = padded[(m-1-k)*cellSize+r]
+		}
+		matrix.Column[0] = matrix.Row[0]
+
+		circulantFFT, err := matrix.CirculantFFT(t.toeplitzDomain)
+		if err != nil {
+			return nil, err
+		}
+		// The inverse FFT below is scaled by 1/2m, which is folded in here
+		for k := range circulantFFT {
+			scalars[k][r].Mul(&circulantFFT[k], &t.toeplitzDomain.CardinalityInv)
+		}
+	}
+
+	// 2. Sum the pointwise products over r, which is an MSM of size ℓ for each k
+	sums := make([]curve.G1Jac, 2*m)
+	errs := make([]error, 2*m)
+	utils.ParallelFor(int(2*m), func(start, end int) {
+		for k := start; k < end; k++ {
+			sum, err := multiexp.MultiExpWithTasks(scalars[k], t.pointsFFT[k], 1)
+			if err != nil {
+				errs[k] = err
+				return
+			}
+			sums[k].FromAffine(sum)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. The inverse FFT gives the Toeplitz products, the first m of which are h_0, ..., h_{m-1}
+	t.toeplitzDomain.fftG1InPlace(sums, t.toeplitzDomain.GeneratorInv)
+
+	// 4. Evaluate H(y) = \sum_j h_{j+1} * y^j over the roots of unity of order NumCells
+	hCoeffs := make([]curve.G1Jac, t.NumCells)
+	copy(hCoeffs, sums[1:m])
+	t.cellsDomain.fftG1InPlace(hCoeffs, t.cellsDomain.Generator)
+
+	// 5. The i'th cell's coset shift to the power of ℓ is the i'th bit-reversed root, so
+	// the proofs are put into bit-reversed order
+	proofs := curve.BatchJacobianToAffineG1(hCoeffs)
+	t.cellsDomain.BitReverseG1(proofs)
+	return proofs, nil
+}
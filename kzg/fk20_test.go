@@ -0,0 +1,77 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestFK20ComputeCellProofs(t *testing.T) {
+	polySize, cellSize, numCells := uint64(16), uint64(4), uint64(8)
+
+	srs, err := NewMonomialSRSInsecure(polySize, big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := NewFK20Table(srs, polySize, cellSize, numCells)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coeffs := make([]fr.Element, polySize)
+	for i := range coeffs {
+		coeffs[i].SetUint64(uint64(i*i + 3))
+	}
+	proofs, err := table.ComputeCellProofs(coeffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(proofs)) != numCells {
+		t.Fatalf("expected %d proofs, got %d", numCells, len(proofs))
+	}
+
+	// Each proof should match the proof for the cell's coset
+	extendedDomain := NewDomain(numCells * cellSize)
+	extendedDomain.ReverseRoots()
+	cosetDomain := NewDomain(cellSize)
+	for i := uint64(0); i < numCells; i++ {
+		expected, _, err := OpenCoset(cosetDomain, coeffs, extendedDomain.Roots[i*cellSize], srs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proofs[i].Equal(&expected) {
+			t.Fatalf("proof for cell %d does not match the coset opening", i)
+		}
+	}
+
+	// A polynomial of lower degree gives the same proofs as its zero padding
+	lowDegree, err := table.ComputeCellProofs(coeffs[:5])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _, _ := OpenCoset(cosetDomain, coeffs[:5], extendedDomain.Roots[cellSize], srs)
+	if !lowDegree[1].Equal(&expected) {
+		t.Error("proof for a polynomial of lower degree is incorrect")
+	}
+
+	if _, err := table.ComputeCellProofs(make([]fr.Element, polySize+1)); err != ErrFK20PolyTooLarge {
+		t.Error("expected an error for a polynomial which is too large")
+	}
+}
+
+func TestNewFK20TableInvalid(t *testing.T) {
+	srs, err := NewMonomialSRSInsecure(16, big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFK20Table(srs, 16, 3, 8); err != ErrInvalidFK20Shape {
+		t.Error("expected an error for a cell size which is not a power of two")
+	}
+	if _, err := NewFK20Table(srs, 16, 4, 2); err != ErrInvalidFK20Shape {
+		t.Error("expected an error for fewer cells than the polynomial contains")
+	}
+	if _, err := NewFK20Table(srs, 32, 4, 16); err != ErrFK20SRSTooSmall {
+		t.Error("expected an error for an srs which is too small")
+	}
+}
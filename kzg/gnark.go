@@ -0,0 +1,124 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gnarkkzg "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+)
+
+// Conversions between the keys in this package and gnark-crypto's KZG SRS.
+//
+// gnark-crypto's SRS is in monomial basis, whereas the CommitKey is in lagrange basis,
+// so converting between the two requires an FFT over the group elements.
+
+// Converts the keys into a gnark-crypto SRS. The commit key must be for the given domain.
+func ToGnarkSRS(domain *Domain, ck *CommitKey, ok *OpeningKey) (*gnarkkzg.SRS, error) {
+	monomialPoints, err := domain.LagrangeToMonomialG1(ck.G1)
+	if err != nil {
+		return nil, err
+	}
+
+	var srs gnarkkzg.SRS
+	srs.G1 = monomialPoints
+	srs.G2[0] = ok.GenG2
+	srs.G2[1] = ok.AlphaG2
+
+	return &srs, nil
+}
+
+// Converts a gnark-crypto SRS into the keys in this package.
+// The commit key will be in lagrange form, following the order of the roots in the domain.
+func FromGnarkSRS(domain *Domain, srs *gnarkkzg.SRS) (*SRS, error) {
+	if uint64(len(srs.G1)) < domain.Cardinality {
+		return nil, errors.New("gnark srs is smaller than the domain")
+	}
+
+	lagrangePoints, err := domain.MonomialToLagrangeG1(srs.G1[:domain.Cardinality])
+	if err != nil {
+		return nil, err
+	}
+
+	return &SRS{
+		CommitKey: CommitKey{
+			G1: lagrangePoints,
+		},
+		OpeningKey: *OpeningKeyFromGnarkSRS(srs),
+	}, nil
+}
+
+// Converts an opening key into a gnark-crypto SRS which can only be used for verification.
+func (ok *OpeningKey) ToGnarkSRS() *gnarkkzg.SRS {
+	var srs gnarkkzg.SRS
+	srs.G1 = []Commitment{ok.GenG1}
+	srs.G2[0] = ok.GenG2
+	srs.G2[1] = ok.AlphaG2
+	return &srs
+}
+
+// Extracts the opening key from a gnark-crypto SRS
+func OpeningKeyFromGnarkSRS(srs *gnarkkzg.SRS) *OpeningKey {
+	return &OpeningKey{
+		GenG1:   srs.G1[0],
+		GenG2:   srs.G2[0],
+		AlphaG2: srs.G2[1],
+	}
+}
+
+// Converts a monomial SRS into a gnark-crypto SRS
+func (srs *MonomialSRS) ToGnarkSRS() (*gnarkkzg.SRS, error) {
+	if len(srs.G1) == 0 || len(srs.G2) < 2 {
+		return nil, ErrMinSRSSize
+	}
+
+	var res gnarkkzg.SRS
+	res.G1 = make([]Commitment, len(srs.G1))
+	copy(res.G1, srs.G1)
+	res.G2[0] = srs.G2[0]
+	res.G2[1] = srs.G2[1]
+	return &res, nil
+}
+
+// Conversions between the proofs and polynomials in this package and gnark-crypto's.
+//
+// A gnark-crypto Digest is the same type as a Commitment, so it does not need converting.
+// gnark-crypto's polynomials are in coefficient form, whereas a Polynomial is in lagrange
+// form over the domain. The version of gnark-crypto used here represents them as []fr.Element,
+// later versions use fr.Vector which converts to and from []fr.Element with a type conversion.
+
+// Converts a gnark-crypto opening proof for the polynomial at `point`
+func FromGnarkOpeningProof(proof *gnarkkzg.OpeningProof, point fr.Element) OpeningProof {
+	return OpeningProof{
+		QuotientComm: proof.H,
+		InputPoint:   point,
+		ClaimedValue: proof.ClaimedValue,
+	}
+}
+
+// Converts an opening proof into a gnark-crypto opening proof.
+// gnark-crypto does not store the input point in the proof, so it must be passed to gnark's Verify separately
+func (proof *OpeningProof) ToGnarkOpeningProof() gnarkkzg.OpeningProof {
+	return gnarkkzg.OpeningProof{
+		H:            proof.QuotientComm,
+		ClaimedValue: proof.ClaimedValue,
+	}
+}
+
+// Converts a gnark-crypto polynomial in coefficient form into a polynomial
+// in lagrange form over the domain, following the order of the roots
+func (d *Domain) PolynomialFromGnark(coeffs []fr.Element) (Polynomial, error) {
+	if uint64(len(coeffs)) > d.Cardinality {
+		return nil, ErrInvalidPolynomialSize
+	}
+	evaluations := d.FFT(coeffs)
+	if d.rootsBitReversed {
+		d.BitReverse(evaluations)
+	}
+	return evaluations, nil
+}
+
+// Converts a polynomial in lagrange form over the domain into a gnark-crypto
+// polynomial in coefficient form
+func (d *Domain) PolynomialToGnark(p Polynomial) ([]fr.Element, error) {
+	return d.LagrangeToCoefficients(p)
+}
@@ -0,0 +1,115 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gnarkkzg "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+)
+
+func TestGnarkSRSRoundTrip(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := NewDomain(8)
+	srs, _ := NewSRSInsecure(*domain, secret)
+	domain.ReverseRoots()
+	srs.CommitKey.ReversePoints()
+
+	gnarkSRS, err := ToGnarkSRS(domain, &srs.CommitKey, &srs.OpeningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := gnarkkzg.NewSRS(domain.Cardinality, secret)
+	for i := 0; i < len(expected.G1); i++ {
+		if !expected.G1[i].Equal(&gnarkSRS.G1[i]) {
+			t.Fatal("monomial points do not match gnark")
+		}
+	}
+
+	got, err := FromGnarkSRS(domain, gnarkSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(srs.CommitKey.G1); i++ {
+		if !srs.CommitKey.G1[i].Equal(&got.CommitKey.G1[i]) {
+			t.Fatal("lagrange points did not round trip")
+		}
+	}
+}
+
+func TestVerifyWithGnark(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+	comm, _ := Commit(poly, &srs.CommitKey)
+	point := samplePointOutsideDomain(*domain)
+	proof, _ := Open(domain, poly, *point, &srs.CommitKey)
+
+	gnarkProof := gnarkkzg.OpeningProof{
+		H:            proof.QuotientComm,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	err := gnarkkzg.Verify(comm, &gnarkProof, *point, srs.OpeningKey.ToGnarkSRS())
+	if err != nil {
+		t.Error("gnark could not verify proof")
+	}
+}
+
+func TestGnarkOpeningProofInterop(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := NewDomain(8)
+	srs, _ := NewSRSInsecure(*domain, secret)
+	domain.ReverseRoots()
+	srs.CommitKey.ReversePoints()
+	gnarkSRS, _ := gnarkkzg.NewSRS(domain.Cardinality, secret)
+
+	coeffs := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3), fr.NewElement(4)}
+	point := fr.NewElement(987)
+
+	// A gnark-crypto commitment and opening should verify with this package's batch verifier
+	digest, err := gnarkkzg.Commit(coeffs, gnarkSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gnarkProof, err := gnarkkzg.Open(coeffs, point, gnarkSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof := FromGnarkOpeningProof(&gnarkProof, point)
+	err = BatchVerifyOpeningProofs([]Commitment{digest}, []fr.Element{proof.InputPoint}, []fr.Element{proof.ClaimedValue}, []Commitment{proof.QuotientComm}, &srs.OpeningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same polynomial in lagrange form should have the same commitment and opening
+	poly, err := domain.PolynomialFromGnark(coeffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comm, err := Commit(poly, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !comm.Equal(&digest) {
+		t.Error("commitment does not match the gnark-crypto digest")
+	}
+	ourProof, err := Open(domain, poly, point, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted := ourProof.ToGnarkOpeningProof()
+	if err := gnarkkzg.Verify(comm, &converted, point, gnarkSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	gotCoeffs, err := domain.PolynomialToGnark(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range coeffs {
+		if !gotCoeffs[i].Equal(&coeffs[i]) {
+			t.Fatal("coefficients do not round trip")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package kzg
+
+import (
+	"encoding/binary"
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+var ErrInvalidHashToCurveDST = errors.New("domain separation tag must be between 1 and 255 bytes")
+
+// Hashes `msg` to a point in G₁, using the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite from RFC 9380.
+//
+// This is used to derive auxiliary generators, such as the base γG₁ used to blind
+// commitments, deterministically from a domain separation tag. Nobody knows the discrete
+// log of the resulting point relative to G₁, or to any other hashed point.
+//
+// `dst` should be unique to the protocol and to the purpose of the point.
+func HashToG1(msg, dst []byte) (curve.G1Affine, error) {
+	if len(dst) == 0 || len(dst) > 255 {
+		return curve.G1Affine{}, ErrInvalidHashToCurveDST
+	}
+	return curve.HashToG1(msg, dst)
+}
+
+// Derives `n` independent generators of G₁ from a domain separation tag.
+// The i'th generator is the hash of i, as an 8 byte little-endian integer
+func DeriveGenerators(dst []byte, n int) ([]curve.G1Affine, error) {
+	generators := make([]curve.G1Affine, n)
+	for i := 0; i < n; i++ {
+		generator, err := HashToG1(u64ToBytesLE(uint64(i)), dst)
+		if err != nil {
+			return nil, err
+		}
+		generators[i] = generator
+	}
+	return generators, nil
+}
+
+func u64ToBytesLE(number uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bytes, number)
+	return bytes
+}
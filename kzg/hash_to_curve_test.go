@@ -0,0 +1,48 @@
+package kzg
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+func TestHashToG1Vector(t *testing.T) {
+	// Test vector from RFC 9380, appendix J.9.1
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	var expected curve.G1Affine
+	expected.X.SetString("0x052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1")
+	expected.Y.SetString("0x08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265")
+
+	point, err := HashToG1([]byte{}, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !point.Equal(&expected) {
+		t.Fatal("hash to curve does not match RFC 9380")
+	}
+}
+
+func TestDeriveGenerators(t *testing.T) {
+	dst := []byte("TEST-V01-BLINDING-BASE")
+	generators, err := DeriveGenerators(dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, generator := range generators {
+		if !generator.IsInSubGroup() || generator.IsInfinity() {
+			t.Errorf("generator %d is not a valid point", i)
+		}
+	}
+	if generators[0].Equal(&generators[1]) || generators[1].Equal(&generators[2]) {
+		t.Error("generators should be distinct")
+	}
+
+	again, _ := DeriveGenerators(dst, 1)
+	if !again[0].Equal(&generators[0]) {
+		t.Error("generators should be deterministic")
+	}
+
+	if _, err := HashToG1([]byte("abc"), nil); err != ErrInvalidHashToCurveDST {
+		t.Error("expected an error for an empty domain separation tag")
+	}
+}
@@ -0,0 +1,148 @@
+package kzg
+
+import (
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Hiding commitments follow the construction in Marlin, where a commitment
+// to f(x) is blinded with a random polynomial r(x) = r_0 + r_1 * x:
+//
+// C = [f(α)]G₁ + [r(α)]γG₁
+//
+// Since r(x) has degree 1, the commitment stays hiding after a single opening.
+// This requires an extra generator γG₁ whose discrete log relative to G₁ is unknown.
+
+// Key used to blind commitments
+type BlindingKey struct {
+	// [γ]G₁
+	GammaG1 curve.G1Affine
+	// [γα]G₁
+	GammaAlphaG1 curve.G1Affine
+}
+
+// The blinding polynomial r(x) = R0 + R1 * x
+// This must be kept secret by the committer and is needed to make opening proofs
+type BlindingFactor struct {
+	R0 fr.Element
+	R1 fr.Element
+}
+
+// Proof to the claim that a hiding commitment to f(x) was evaluated at a point `a`
+// and resulted in `f(a)`
+type HidingOpeningProof struct {
+	// [q(α)]G₁ + [r_1]γG₁ where q(x) = (f(x) - f(a))/(x-a)
+	QuotientComm curve.G1Affine
+
+	// Point that we are evaluating the polynomial at : `a`
+	InputPoint fr.Element
+
+	// ClaimedValue purported value : `f(a)`
+	ClaimedValue fr.Element
+
+	// Evaluation of the blinding polynomial at the input point : `r(a)`
+	BlindingEval fr.Element
+}
+
+// Creates a blinding key for an existing SRS.
+// DO NOT USE THIS METHOD IN PRODUCTION
+func NewBlindingKeyInsecure(bAlpha *big.Int, bGamma *big.Int) *BlindingKey {
+	_, _, gen1Aff, _ := curve.Generators()
+
+	var blindingKey BlindingKey
+	blindingKey.GammaG1.ScalarMultiplication(&gen1Aff, bGamma)
+	blindingKey.GammaAlphaG1.ScalarMultiplication(&blindingKey.GammaG1, bAlpha)
+
+	return &blindingKey
+}
+
+// Samples a random blinding polynomial
+func NewBlindingFactor() (*BlindingFactor, error) {
+	var blinder BlindingFactor
+	if _, err := blinder.R0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := blinder.R1.SetRandom(); err != nil {
+		return nil, err
+	}
+	return &blinder, nil
+}
+
+// Commits to a polynomial in lagrange form, blinding it with the blinding polynomial
+func HidingCommit(p Polynomial, blinder *BlindingFactor, ck *CommitKey, bk *BlindingKey) (*Commitment, error) {
+	comm, err := Commit(p, ck)
+	if err != nil {
+		return nil, err
+	}
+
+	// [r_0]γG₁ + [r_1]γαG₁
+	var r0, r1 big.Int
+	blinder.R0.ToBigIntRegular(&r0)
+	blinder.R1.ToBigIntRegular(&r1)
+
+	var blindingTerm, tmp curve.G1Jac
+	blindingTerm.ScalarMultiplicationAffine(&bk.GammaG1, &r0)
+	tmp.ScalarMultiplicationAffine(&bk.GammaAlphaG1, &r1)
+	blindingTerm.AddAssign(&tmp)
+	blindingTerm.AddMixed(comm)
+
+	var res Commitment
+	res.FromJacobian(&blindingTerm)
+	return &res, nil
+}
+
+// Create a KZG proof that the polynomial f(x) behind a hiding commitment, when
+// evaluated at a point `a` is equal to `f(a)`
+func HidingOpen(domain *Domain, p Polynomial, blinder *BlindingFactor, point fr.Element, ck *CommitKey, bk *BlindingKey) (HidingOpeningProof, error) {
+	proof, err := Open(domain, p, point, ck)
+	if err != nil {
+		return HidingOpeningProof{}, err
+	}
+
+	// (r(x) - r(a)) / (x - a) = r_1, so the blinded quotient
+	// commitment only needs [r_1]γG₁ added to it
+	var r1 big.Int
+	blinder.R1.ToBigIntRegular(&r1)
+
+	var quotientComm curve.G1Jac
+	quotientComm.ScalarMultiplicationAffine(&bk.GammaG1, &r1)
+	quotientComm.AddMixed(&proof.QuotientComm)
+
+	res := HidingOpeningProof{
+		InputPoint:   point,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	res.QuotientComm.FromJacobian(&quotientComm)
+
+	// r(a) = r_0 + r_1 * a
+	res.BlindingEval.Mul(&blinder.R1, &point)
+	res.BlindingEval.Add(&res.BlindingEval, &blinder.R0)
+
+	return res, nil
+}
+
+// Verify a KZG proof for a hiding commitment
+func HidingVerify(commitment *Commitment, proof *HidingOpeningProof, open_key *OpeningKey, bk *BlindingKey) error {
+	// Remove the evaluated blinding term from the commitment
+	// C - [r(a)]γG₁
+	// The remaining check is then identical to the non-hiding case
+	var blindingEval big.Int
+	proof.BlindingEval.ToBigIntRegular(&blindingEval)
+
+	var unblindedComm curve.G1Jac
+	unblindedComm.ScalarMultiplicationAffine(&bk.GammaG1, &blindingEval)
+	unblindedComm.Neg(&unblindedComm)
+	unblindedComm.AddMixed(commitment)
+
+	var unblindedCommAff Commitment
+	unblindedCommAff.FromJacobian(&unblindedComm)
+
+	openingProof := OpeningProof{
+		QuotientComm: proof.QuotientComm,
+		InputPoint:   proof.InputPoint,
+		ClaimedValue: proof.ClaimedValue,
+	}
+	return Verify(&unblindedCommAff, &openingProof, open_key)
+}
@@ -0,0 +1,50 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestHidingProofVerifySmoke(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+	bk := NewBlindingKeyInsecure(big.NewInt(1234), big.NewInt(5678))
+
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	blinder, err := NewBlindingFactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	comm, err := HidingCommit(poly, blinder, &srs.CommitKey, bk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hiding commitment should differ from the regular commitment
+	plainComm, _ := Commit(poly, &srs.CommitKey)
+	if comm.Equal(plainComm) {
+		t.Error("hiding commitment should not equal the plain commitment")
+	}
+
+	point := samplePointOutsideDomain(*domain)
+	proof, err := HidingOpen(domain, poly, blinder, *point, &srs.CommitKey, bk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = HidingVerify(comm, &proof, &srs.OpeningKey, bk)
+	if err != nil {
+		t.Error("hiding proof down bad")
+	}
+
+	// Tampering with the blinding evaluation should fail verification
+	one := fr.One()
+	proof.BlindingEval.Add(&proof.BlindingEval, &one)
+	err = HidingVerify(comm, &proof, &srs.OpeningKey, bk)
+	if err == nil {
+		t.Error("expected tampered hiding proof to fail")
+	}
+}
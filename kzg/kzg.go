@@ -45,47 +45,31 @@ type OpeningProofOpt struct {
 //
 // Copied from gnark-crypto with minor modifications
 func Verify(commitment *Commitment, proof *OpeningProof, open_key *OpeningKey) error {
-
-	// [f(a)]G₁
-	var claimedValueG1Aff curve.G1Jac
-	var claimedValueBigInt big.Int
+	var claimedValueBigInt, pointBigInt big.Int
 	proof.ClaimedValue.ToBigIntRegular(&claimedValueBigInt)
-	claimedValueG1Aff.ScalarMultiplicationAffine(&open_key.GenG1, &claimedValueBigInt)
-
-	// [f(α) - f(a)]G₁
-	var fminusfaG1Jac curve.G1Jac
-	fminusfaG1Jac.FromAffine(commitment)
-	fminusfaG1Jac.SubAssign(&claimedValueG1Aff)
-
-	// [-H(α)]G₁
-	var negH curve.G1Affine
-	negH.Neg(&proof.QuotientComm)
-
-	// [α-a]G₂
-	var alphaMinusaG2Jac, genG2Jac, alphaG2Jac curve.G2Jac
-	var pointBigInt big.Int
 	// TODO(perf) instead of converting values from bytes to Fields, then to big.Int
 	// TODO(perf) can simply convert the value from bytes to big.Int (avoid ToBigIntRegular)
 	proof.InputPoint.ToBigIntRegular(&pointBigInt)
-	genG2Jac.FromAffine(&open_key.GenG2)
-	alphaG2Jac.FromAffine(&open_key.AlphaG2)
-	alphaMinusaG2Jac.ScalarMultiplication(&genG2Jac, &pointBigInt).
-		Neg(&alphaMinusaG2Jac).
-		AddAssign(&alphaG2Jac)
-
-	// [α-a]G₂
-	var xminusaG2Aff curve.G2Affine
-	xminusaG2Aff.FromJacobian(&alphaMinusaG2Jac)
-
-	// [f(α) - f(a)]G₁
-	var fminusfaG1Aff curve.G1Affine
-	fminusfaG1Aff.FromJacobian(&fminusfaG1Jac)
-
-	// e([f(α) - f(a)]G₁, G₂).e([-H(α)]G₁, [α-a]G₂) ==? 1
-	check, err := curve.PairingCheck(
-		[]curve.G1Affine{fminusfaG1Aff, negH},
-		[]curve.G2Affine{open_key.GenG2, xminusaG2Aff},
-	)
+
+	return verify(commitment, &proof.QuotientComm, &pointBigInt, &claimedValueBigInt, open_key)
+}
+
+func VerifyOpt(commitment *Commitment, proof *OpeningProofOpt, open_key *OpeningKey) error {
+	return verify(commitment, &proof.QuotientComm, proof.InputPointBigInt, proof.ClaimedValueBigInt, open_key)
+}
+
+// The usual check e([f(α) - f(a)]G₁, G₂) = e([H(α)]G₁, [α - a]G₂) is rearranged into
+//
+//	e([f(α) - f(a) + a * H(α)]G₁, G₂) = e([H(α)]G₁, [α]G₂)
+//
+// so that the G2 points are the same for every proof. This replaces a scalar multiplication
+// in G2 with a cheaper one in G1, and if the opening key has been precomputed, the Miller loop
+// lines for its G2 points are not recomputed.
+func verify(commitment *Commitment, quotientComm *curve.G1Affine, point, claimedValue *big.Int, open_key *OpeningKey) error {
+	inputs := pairingInputs(commitment, quotientComm, point, claimedValue, open_key)
+
+	// e([f(α) - f(a) + a * H(α)]G₁, G₂).e([-H(α)]G₁, [α]G₂) ==? 1
+	check, err := open_key.pairingCheck(inputs.G1[:])
 	if err != nil {
 		return err
 	}
@@ -95,50 +79,57 @@ func Verify(commitment *Commitment, proof *OpeningProof, open_key *OpeningKey) e
 	return nil
 }
 
-func VerifyOpt(commitment *Commitment, proof *OpeningProofOpt, open_key *OpeningKey) error {
+// Checks that e(P[0], G₂).e(P[1], [α]G₂) == 1, using the precomputed lines if they match the opening key
+func (ok *OpeningKey) pairingCheck(P []curve.G1Affine) (bool, error) {
+	lines := ok.precomputedLines()
+	if lines == nil {
+		return pairingCheck(P, []curve.G2Affine{ok.GenG2, ok.AlphaG2})
+	}
+	return fixedPairingCheck(P, []*fixedG2Lines{&lines.genG2, &lines.alphaG2}), nil
+}
 
+// The points whose pairings are multiplied together in the verification equation.
+// The proof is valid if and only if e(G1[0], G2[0]) * e(G1[1], G2[1]) = 1
+type PairingInputs struct {
+	G1 [2]curve.G1Affine
+	G2 [2]curve.G2Affine
+}
+
+// Returns the pairing inputs which Verify checks for the opening proof.
+// This is intended for verifying the proof elsewhere, for example inside of a circuit
+func ComputePairingInputs(commitment *Commitment, proof *OpeningProof, open_key *OpeningKey) PairingInputs {
+	var claimedValueBigInt, pointBigInt big.Int
+	proof.ClaimedValue.ToBigIntRegular(&claimedValueBigInt)
+	proof.InputPoint.ToBigIntRegular(&pointBigInt)
+
+	return pairingInputs(commitment, &proof.QuotientComm, &pointBigInt, &claimedValueBigInt, open_key)
+}
+
+func pairingInputs(commitment *Commitment, quotientComm *curve.G1Affine, point, claimedValue *big.Int, open_key *OpeningKey) PairingInputs {
 	// [f(a)]G₁
-	var claimedValueG1Aff curve.G1Jac
+	var claimedValueG1Jac curve.G1Jac
+	claimedValueG1Jac.ScalarMultiplicationAffine(&open_key.GenG1, claimedValue)
 
-	claimedValueG1Aff.ScalarMultiplicationAffine(&open_key.GenG1, proof.ClaimedValueBigInt)
+	// [a * H(α)]G₁
+	var pointTimesQuotientJac curve.G1Jac
+	pointTimesQuotientJac.ScalarMultiplicationAffine(quotientComm, point)
 
-	// [f(α) - f(a)]G₁
-	var fminusfaG1Jac curve.G1Jac
-	fminusfaG1Jac.FromAffine(commitment)
-	fminusfaG1Jac.SubAssign(&claimedValueG1Aff)
+	// [f(α) - f(a) + a * H(α)]G₁
+	var lhsJac curve.G1Jac
+	lhsJac.FromAffine(commitment)
+	lhsJac.SubAssign(&claimedValueG1Jac)
+	lhsJac.AddAssign(&pointTimesQuotientJac)
+	var lhs curve.G1Affine
+	lhs.FromJacobian(&lhsJac)
 
 	// [-H(α)]G₁
 	var negH curve.G1Affine
-	negH.Neg(&proof.QuotientComm)
-
-	// [α-a]G₂
-	var alphaMinusaG2Jac, genG2Jac, alphaG2Jac curve.G2Jac
-	genG2Jac.FromAffine(&open_key.GenG2)
-	alphaG2Jac.FromAffine(&open_key.AlphaG2)
-	alphaMinusaG2Jac.ScalarMultiplication(&genG2Jac, proof.InputPointBigInt).
-		Neg(&alphaMinusaG2Jac).
-		AddAssign(&alphaG2Jac)
-
-	// [α-a]G₂
-	var xminusaG2Aff curve.G2Affine
-	xminusaG2Aff.FromJacobian(&alphaMinusaG2Jac)
-
-	// [f(α) - f(a)]G₁
-	var fminusfaG1Aff curve.G1Affine
-	fminusfaG1Aff.FromJacobian(&fminusfaG1Jac)
-
-	// e([f(α) - f(a)]G₁, G₂).e([-H(α)]G₁, [α-a]G₂) ==? 1
-	check, err := curve.PairingCheck(
-		[]curve.G1Affine{fminusfaG1Aff, negH},
-		[]curve.G2Affine{open_key.GenG2, xminusaG2Aff},
-	)
-	if err != nil {
-		return err
-	}
-	if !check {
-		return ErrVerifyOpeningProof
+	negH.Neg(quotientComm)
+
+	return PairingInputs{
+		G1: [2]curve.G1Affine{lhs, negH},
+		G2: [2]curve.G2Affine{open_key.GenG2, open_key.AlphaG2},
 	}
-	return nil
 }
 
 // Create a KZG proof that a polynomial f(x) when evaluated at a point `a` is equal to `f(a)`
@@ -157,7 +148,7 @@ func Open(domain *Domain, p Polynomial, point fr.Element, ck *CommitKey) (Openin
 	}
 
 	// compute the quotient polynomial
-	quotient_poly, err := DividePolyByXminusA(*domain, p, res.ClaimedValue, point)
+	quotient_poly, err := ComputeQuotientPoly(domain, p, point, res.ClaimedValue)
 	if err != nil {
 		return OpeningProof{}, err
 	}
@@ -172,6 +163,52 @@ func Open(domain *Domain, p Polynomial, point fr.Element, ck *CommitKey) (Openin
 	return res, nil
 }
 
+// Computes the quotient q(x) = (f(x) - y)/(x - z) in lagrange form, where `y` is f(z).
+// `z` may be a point in the domain, in which case the quotient is evaluated at `z` using
+// the derivative of f, as in compute_quotient_eval_within_domain from the consensus specs.
+//
+// Note: polynomial is in lagrange basis
+func ComputeQuotientPoly(domain *Domain, f Polynomial, z, y fr.Element) (Polynomial, error) {
+	index, ok := domain.FindRootIndex(z)
+	if !ok {
+		return DividePolyByXminusA(*domain, f, y, z)
+	}
+
+	if domain.Cardinality != uint64(len(f)) {
+		return nil, errors.New("polynomial size does not match domain size")
+	}
+
+	// q_i = (f_i - y) / (ω_i - z) for i != m, where z = ω_m
+	numer := make([]fr.Element, len(f))
+	denom := make([]fr.Element, len(f))
+	for i := 0; i < len(f); i++ {
+		if i == index {
+			continue
+		}
+		numer[i].Sub(&f[i], &y)
+		denom[i].Sub(&domain.Roots[i], &z)
+	}
+	// The inverse of zero is zero, so the entry for z is left as zero
+	denom = fr.BatchInvert(denom)
+
+	quotient := make(Polynomial, len(f))
+	for i := 0; i < len(f); i++ {
+		quotient[i].Mul(&numer[i], &denom[i])
+	}
+
+	// q_m = \sum_{i != m} (f_i - y) * ω_i / (z * (z - ω_i))
+	//     = \sum_{i != m} -q_i * ω_i / z
+	var sum, tmp, zInv fr.Element
+	for i := 0; i < len(f); i++ {
+		tmp.Mul(&quotient[i], &domain.Roots[i])
+		sum.Sub(&sum, &tmp)
+	}
+	zInv.Inverse(&z)
+	quotient[index].Mul(&sum, &zInv)
+
+	return quotient, nil
+}
+
 // DividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis, in regular form
 // Note: polynomial is in lagrange basis
 func DividePolyByXminusA(domain Domain, f Polynomial, fa, a fr.Element) ([]fr.Element, error) {
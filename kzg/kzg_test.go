@@ -23,3 +23,77 @@ func TestProofVerifySmoke(t *testing.T) {
 		t.Error("proof down bad")
 	}
 }
+
+func TestVerifyIncorrectProof(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	comm, _ := Commit(poly, &srs.CommitKey)
+	point := samplePointOutsideDomain(*domain)
+	proof, _ := Open(domain, poly, *point, &srs.CommitKey)
+
+	one := fr.One()
+	proof.ClaimedValue.Add(&proof.ClaimedValue, &one)
+	if Verify(comm, &proof, &srs.OpeningKey) != ErrVerifyOpeningProof {
+		t.Error("proof with an incorrect claimed value should not verify")
+	}
+
+	var pointBigInt, claimedValueBigInt big.Int
+	proof.InputPoint.ToBigIntRegular(&pointBigInt)
+	proof.ClaimedValue.ToBigIntRegular(&claimedValueBigInt)
+	optProof := OpeningProofOpt{
+		QuotientComm:       proof.QuotientComm,
+		InputPointBigInt:   &pointBigInt,
+		ClaimedValueBigInt: &claimedValueBigInt,
+	}
+	if VerifyOpt(comm, &optProof, &srs.OpeningKey) != ErrVerifyOpeningProof {
+		t.Error("proof with an incorrect claimed value should not verify")
+	}
+}
+
+func TestComputeQuotientPoly(t *testing.T) {
+	domain := NewDomain(4)
+	domain.ReverseRoots()
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	for _, z := range []fr.Element{*samplePointOutsideDomain(*domain), domain.Roots[2]} {
+		y, _ := EvaluateLagrangePolynomial(domain, poly, z)
+		quotient, err := ComputeQuotientPoly(domain, poly, z, *y)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// q(x) * (x - z) = f(x) - y, checked at a point outside of the domain
+		x := samplePointOutsideDomain(*domain)
+		qx, _ := EvaluateLagrangePolynomial(domain, quotient, *x)
+		fx, _ := EvaluateLagrangePolynomial(domain, poly, *x)
+
+		var lhs, rhs fr.Element
+		lhs.Sub(x, &z)
+		lhs.Mul(&lhs, qx)
+		rhs.Sub(fx, y)
+		if lhs != rhs {
+			t.Errorf("quotient is incorrect for z = %s", z.String())
+		}
+	}
+}
+
+func TestProofVerifyInDomain(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	comm, _ := Commit(poly, &srs.CommitKey)
+	proof, err := Open(domain, poly, domain.Roots[1], &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.ClaimedValue != poly[1] {
+		t.Error("claimed value should be the evaluation in the polynomial")
+	}
+	if err := Verify(comm, &proof, &srs.OpeningKey); err != nil {
+		t.Error("proof for a point in the domain should verify")
+	}
+}
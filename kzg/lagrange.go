@@ -12,8 +12,12 @@ func EvaluateLagrangePolynomial(domain *Domain, poly Polynomial, eval_point fr.E
 		return nil, errors.New("domain size does not equal the number of evaluations in the polynomial")
 	}
 
-	// TODO: should we check here instead that eval_point is not in the domain
-	// TODO We should do it in a using channels, so that it doesn't block the rest of the code
+	// The formula below divides by zero for points in the domain, however
+	// the evaluation at those points is simply the corresponding evaluation
+	if index, ok := domain.FindRootIndex(eval_point); ok {
+		result := poly[index]
+		return &result, nil
+	}
 
 	denom := make([]fr.Element, domain.Cardinality)
 	for i := range denom {
@@ -33,11 +37,12 @@ func EvaluateLagrangePolynomial(domain *Domain, poly Polynomial, eval_point fr.E
 	}
 
 	// result * (x^width - 1) * 1/width
-	tmp := utils.Pow2(eval_point, domain.Cardinality)
+	// This also handles mixed radix domains, whose size is not a power of two
+	tmp := utils.ExpUint64(eval_point, domain.Cardinality)
 	one := fr.One()
-	tmp.Sub(tmp, &one)
-	tmp.Mul(tmp, &domain.CardinalityInv)
-	result.Mul(tmp, &result)
+	tmp.Sub(&tmp, &one)
+	tmp.Mul(&tmp, &domain.CardinalityInv)
+	result.Mul(&tmp, &result)
 
 	return &result, nil
 }
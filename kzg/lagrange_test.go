@@ -47,6 +47,21 @@ func TestEvalPolynomialSmoke(t *testing.T) {
 
 }
 
+func TestEvalPolynomialInDomain(t *testing.T) {
+	domain := NewDomain(4)
+	poly := []fr.Element{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)}
+
+	for i := 0; i < len(poly); i++ {
+		got, err := EvaluateLagrangePolynomial(domain, poly, domain.Roots[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&poly[i]) {
+			t.Errorf("evaluation at root %d should be the %d'th evaluation", i, i)
+		}
+	}
+}
+
 func samplePointOutsideDomain(domain Domain) *fr.Element {
 	var rand_element fr.Element
 
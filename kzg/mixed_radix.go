@@ -0,0 +1,125 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Mixed radix domains have a size of the form 2^a * 3^b.
+//
+// The multiplicative group of the scalar field has order r - 1 = 2^32 * 3 * ...
+// so a is at most 32 and b is at most 1.
+//
+// These are intended for experimental protocols which do not fit the power of two
+// constraint. The bit-reversal permutation is not defined for these domains, so
+// ReverseRoots must not be called on them.
+
+const (
+	maxTwoAdicity   = 32
+	maxThreeAdicity = 1
+)
+
+var ErrMixedRadixSizeTooLarge = errors.New("no mixed radix domain of the form 2^a * 3^b is large enough")
+
+// A generator of the multiplicative group of the scalar field
+const multiplicativeGenerator = 7
+
+// Creates a domain whose size is the smallest number of the form 2^a * 3^b
+// which is at least `m`
+func NewDomainMixedRadix(m uint64) (*Domain, error) {
+	size, ok := nextMixedRadixSize(m)
+	if !ok {
+		return nil, ErrMixedRadixSizeTooLarge
+	}
+
+	domain := &Domain{}
+	domain.Cardinality = size
+
+	// Generator = g^((r-1)/size) has order `size`
+	var exponent big.Int
+	exponent.Sub(fr.Modulus(), big.NewInt(1))
+	exponent.Div(&exponent, new(big.Int).SetUint64(size))
+
+	domain.Generator.Exp(fr.NewElement(multiplicativeGenerator), &exponent)
+	domain.GeneratorInv.Inverse(&domain.Generator)
+	domain.CardinalityInv.SetUint64(size).Inverse(&domain.CardinalityInv)
+
+	domain.Roots = make([]fr.Element, size)
+	current := fr.One()
+	for i := uint64(0); i < size; i++ {
+		domain.Roots[i] = current
+		current.Mul(&current, &domain.Generator)
+	}
+	domain.indexRoots()
+
+	return domain, nil
+}
+
+func nextMixedRadixSize(m uint64) (uint64, bool) {
+	best := uint64(0)
+	threePow := uint64(1)
+	for b := 0; b <= maxThreeAdicity; b++ {
+		size := threePow
+		for a := 0; a <= maxTwoAdicity; a++ {
+			if size >= m {
+				if best == 0 || size < best {
+					best = size
+				}
+				break
+			}
+			size *= 2
+		}
+		threePow *= 3
+	}
+	return best, best != 0
+}
+
+// Recursive decimation-in-time FFT for sizes of the form 2^a * 3^b.
+// `generator` must have order len(values)
+func mixedRadixFFT(values []fr.Element, generator fr.Element) []fr.Element {
+	n := len(values)
+	if n == 1 {
+		return []fr.Element{values[0]}
+	}
+
+	radix := 2
+	if n%2 != 0 {
+		radix = 3
+	}
+	if n%radix != 0 {
+		panic("size of slice must be of the form 2^a * 3^b")
+	}
+	m := n / radix
+
+	var subGenerator fr.Element
+	subGenerator.Exp(generator, big.NewInt(int64(radix)))
+
+	// Compute the FFT of each of the `radix` interleaved subsequences
+	subFFTs := make([][]fr.Element, radix)
+	for s := 0; s < radix; s++ {
+		sub := make([]fr.Element, m)
+		for j := 0; j < m; j++ {
+			sub[j] = values[j*radix+s]
+		}
+		subFFTs[s] = mixedRadixFFT(sub, subGenerator)
+	}
+
+	// X[k] = \sum_s generator^{s*k} * subFFTs[s][k mod m]
+	result := make([]fr.Element, n)
+	var twiddle, tmp fr.Element
+	omegaK := fr.One()
+	for k := 0; k < n; k++ {
+		result[k] = subFFTs[0][k%m]
+		twiddle = omegaK
+		for s := 1; s < radix; s++ {
+			tmp.Mul(&subFFTs[s][k%m], &twiddle)
+			result[k].Add(&result[k], &tmp)
+			twiddle.Mul(&twiddle, &omegaK)
+		}
+		omegaK.Mul(&omegaK, &generator)
+	}
+
+	return result
+}
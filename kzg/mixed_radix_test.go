@@ -0,0 +1,74 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestNextMixedRadixSize(t *testing.T) {
+	testCases := map[uint64]uint64{
+		1: 1, 3: 3, 5: 6, 7: 8, 10: 12, 13: 16, 17: 24, 4097: 6144,
+	}
+	for m, expected := range testCases {
+		got, ok := nextMixedRadixSize(m)
+		if !ok || got != expected {
+			t.Errorf("expected next mixed radix size of %d to be %d, got %d", m, expected, got)
+		}
+	}
+}
+
+func TestMixedRadixFFT(t *testing.T) {
+	domain, err := NewDomainMixedRadix(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain.Cardinality != 12 {
+		t.Fatalf("unexpected domain size %d", domain.Cardinality)
+	}
+
+	var res fr.Element
+	res.Exp(domain.Generator, big.NewInt(12))
+	if !res.IsOne() {
+		t.Fatal("generator does not have order 12")
+	}
+	res.Exp(domain.Generator, big.NewInt(6))
+	if res.IsOne() {
+		t.Fatal("generator has order 6")
+	}
+	res.Exp(domain.Generator, big.NewInt(4))
+	if res.IsOne() {
+		t.Fatal("generator has order 4")
+	}
+
+	coeffs := make([]fr.Element, domain.Cardinality)
+	for i := 0; i < len(coeffs); i++ {
+		coeffs[i].SetUint64(randUint64())
+	}
+
+	evaluations := domain.FFT(coeffs)
+	for i := 0; i < len(evaluations); i++ {
+		expected := evalCoeffs(coeffs, domain.Roots[i])
+		if !expected.Equal(&evaluations[i]) {
+			t.Fatal("mixed radix fft does not match direct evaluation")
+		}
+	}
+
+	got := domain.IFFT(evaluations)
+	for i := 0; i < len(coeffs); i++ {
+		if !got[i].Equal(&coeffs[i]) {
+			t.Fatal("mixed radix inverse fft did not return the original coefficients")
+		}
+	}
+
+	point := samplePointOutsideDomain(*domain)
+	eval, err := EvaluateLagrangePolynomial(domain, evaluations, *point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := evalCoeffs(coeffs, *point)
+	if !expected.Equal(eval) {
+		t.Error("barycentric evaluation over mixed radix domain is incorrect")
+	}
+}
@@ -0,0 +1,54 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ErrPairingInputSize = errors.New("number of G1 points, G2 points and weights must be the same")
+
+// The implementation used to check that a product of pairings is equal to one.
+// This is gnark-crypto by default, builds with the `blst` tag replace it in an init function
+var pairingCheck = curve.PairingCheck
+
+// Whether pairings against an opening key use the Miller loop lines from OpeningKey.Precompute.
+// The lines are evaluated with gnark-crypto, so builds which replace pairingCheck set this to false
+var useFixedLines = true
+
+// Checks that \prod e([w_i]P_i, Q_i) == 1 using a single multi-pairing.
+//
+// If `weights` is nil, every weight is one. To combine several independent pairing equations
+// into one check, give every pair in the same equation the same random weight, see RandomWeights.
+// Without the weights, a prover could choose inputs to the equations which cancel each other out.
+//
+// The points are assumed to be in the correct subgroup.
+func MultiPairingCheck(g1Points []curve.G1Affine, g2Points []curve.G2Affine, weights []fr.Element) (bool, error) {
+	if len(g1Points) != len(g2Points) || (weights != nil && len(weights) != len(g1Points)) {
+		return false, ErrPairingInputSize
+	}
+	if weights == nil {
+		return pairingCheck(g1Points, g2Points)
+	}
+
+	weightedG1 := make([]curve.G1Affine, len(g1Points))
+	for i := 0; i < len(g1Points); i++ {
+		var weightBigInt big.Int
+		weights[i].ToBigIntRegular(&weightBigInt)
+		weightedG1[i].ScalarMultiplication(&g1Points[i], &weightBigInt)
+	}
+	return pairingCheck(weightedG1, g2Points)
+}
+
+// Samples `n` random weights, for combining pairing equations in MultiPairingCheck
+func RandomWeights(n int) ([]fr.Element, error) {
+	weights := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		if _, err := weights[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return weights, nil
+}
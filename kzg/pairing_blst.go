@@ -0,0 +1,44 @@
+//go:build blst
+
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Building with the `blst` tag routes pairings through supranational/blst.
+// This requires cgo.
+
+func init() {
+	pairingCheck = blstPairingCheck
+	useFixedLines = false
+}
+
+func blstPairingCheck(P []curve.G1Affine, Q []curve.G2Affine) (bool, error) {
+	if len(P) != len(Q) {
+		return false, errors.New("invalid inputs sizes")
+	}
+
+	ps := make([]blst.P1Affine, len(P))
+	qs := make([]blst.P2Affine, len(Q))
+	for i := 0; i < len(P); i++ {
+		// The points have already been checked to be in the correct subgroup
+		// when they were deserialised, so only the encoding is converted here
+		serP := P[i].RawBytes()
+		if ps[i].Deserialize(serP[:]) == nil {
+			return false, errors.New("could not convert G1 point to blst")
+		}
+		serQ := Q[i].RawBytes()
+		if qs[i].Deserialize(serQ[:]) == nil {
+			return false, errors.New("could not convert G2 point to blst")
+		}
+	}
+
+	gt := blst.Fp12MillerLoopN(qs, ps)
+	gt.FinalExp()
+	one := blst.Fp12One()
+	return gt.Equals(&one), nil
+}
@@ -0,0 +1,52 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+func TestMultiPairingCheck(t *testing.T) {
+	_, _, genG1, genG2 := curve.Generators()
+
+	// e([2]G₁, G₂) * e(-G₁, [2]G₂) == 1
+	var twoG1, negG1 curve.G1Affine
+	twoG1.ScalarMultiplication(&genG1, big.NewInt(2))
+	negG1.Neg(&genG1)
+	var twoG2 curve.G2Affine
+	twoG2.ScalarMultiplication(&genG2, big.NewInt(2))
+
+	g1Points := []curve.G1Affine{twoG1, negG1}
+	g2Points := []curve.G2Affine{genG2, twoG2}
+
+	ok, err := MultiPairingCheck(g1Points, g2Points, nil)
+	if err != nil || !ok {
+		t.Fatal("unweighted pairing check failed")
+	}
+
+	// The same weight on both pairs keeps the equation true
+	weights, err := RandomWeights(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err = MultiPairingCheck(g1Points, g2Points, append(weights, weights[0]))
+	if err != nil || !ok {
+		t.Fatal("weighted pairing check failed")
+	}
+
+	// Different weights on the pairs of a single equation should not
+	weights, err = RandomWeights(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err = MultiPairingCheck(g1Points, g2Points, weights)
+	if err != nil || ok {
+		t.Fatal("expected the pairing check to fail")
+	}
+
+	_, err = MultiPairingCheck(g1Points, g2Points[:1], nil)
+	if err != ErrPairingInputSize {
+		t.Error("expected an error for mismatched sizes")
+	}
+}
@@ -0,0 +1,75 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Helpers for polynomials in coefficient form.
+// The coefficient at index `i` is the coefficient of x^i
+
+// Computes the coefficients of the polynomial which vanishes on `points`
+// ie \prod (x - points_i)
+//
+// This is quadratic in the number of points
+func vanishingPolyCoeffs(points []fr.Element) []fr.Element {
+	coeffs := make([]fr.Element, len(points)+1)
+	coeffs[0].SetOne()
+
+	var tmp fr.Element
+	for i := 0; i < len(points); i++ {
+		// Multiply the current polynomial (of degree i) by (x - points_i)
+		coeffs[i+1] = coeffs[i]
+		for j := i; j > 0; j-- {
+			tmp.Mul(&coeffs[j], &points[i])
+			coeffs[j].Sub(&coeffs[j-1], &tmp)
+		}
+		coeffs[0].Mul(&coeffs[0], &points[i])
+		coeffs[0].Neg(&coeffs[0])
+	}
+
+	return coeffs
+}
+
+// Divides `a` by `b` using long division, returning the quotient and the remainder
+func dividePolys(a, b []fr.Element) ([]fr.Element, []fr.Element, error) {
+	degB := len(b) - 1
+	for degB >= 0 && b[degB].IsZero() {
+		degB--
+	}
+	if degB < 0 {
+		return nil, nil, errors.New("cannot divide by the zero polynomial")
+	}
+
+	remainder := make([]fr.Element, len(a))
+	copy(remainder, a)
+
+	if len(a) <= degB {
+		return []fr.Element{}, remainder, nil
+	}
+
+	var leadInv fr.Element
+	leadInv.Inverse(&b[degB])
+
+	quotient := make([]fr.Element, len(a)-degB)
+	var tmp fr.Element
+	for i := len(quotient) - 1; i >= 0; i-- {
+		quotient[i].Mul(&remainder[i+degB], &leadInv)
+		for j := 0; j <= degB; j++ {
+			tmp.Mul(&quotient[i], &b[j])
+			remainder[i+j].Sub(&remainder[i+j], &tmp)
+		}
+	}
+
+	return quotient, remainder[:degB], nil
+}
+
+func isZeroPoly(p []fr.Element) bool {
+	for i := 0; i < len(p); i++ {
+		if !p[i].IsZero() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Erasure decoding of a Reed-Solomon codeword, where the erasures are whole cosets.
+//
+// The codeword is the evaluations of a polynomial P over a domain of size N, some of which
+// are missing. The missing evaluations are the cosets h_i * <ω> with ω of order k, whose vanishing
+// polynomials are x^k - h_i^k. Let Z be the product of these, and E be the evaluations with zeros
+// in place of the missing ones. Then E * Z = P * Z over the whole domain, so if deg(P * Z) < N
+// the coefficients of P * Z are the inverse FFT of E * Z. P is then recovered by dividing by Z
+// over a coset of the domain, where Z has no roots.
+
+var (
+	ErrInvalidErasureSize = errors.New("coset size must be a power of two which divides the domain size")
+	ErrTooManyErasures    = errors.New("at least half of the evaluations are needed for recovery")
+)
+
+// Recovers the coefficients of the polynomial whose evaluations over `domain` are `evaluations`.
+//
+// The evaluations are in natural order, and the evaluations over the cosets of size `cosetSize`
+// shifted by `missingCosetShifts` are treated as missing, so their values are ignored.
+// The polynomial must have degree less than domain.Cardinality / 2, and at most half of
+// the evaluations can be missing.
+func RecoverPolynomialCoeffs(domain *Domain, evaluations []fr.Element, cosetSize uint64, missingCosetShifts []fr.Element) ([]fr.Element, error) {
+	n := domain.Cardinality
+	if uint64(len(evaluations)) != n {
+		return nil, ErrInvalidPolynomialSize
+	}
+	if !utils.IsPowerOfTwo(cosetSize) || cosetSize > n {
+		return nil, ErrInvalidErasureSize
+	}
+	if uint64(len(missingCosetShifts))*cosetSize > n/2 {
+		return nil, ErrTooManyErasures
+	}
+
+	// 1. Compute the coefficients of the vanishing polynomial Z of the missing cosets
+	vanishingCoeffs := vanishingPolyForCosets(n, cosetSize, missingCosetShifts)
+
+	// 2. Compute E * Z over the domain. At the missing evaluations Z is zero,
+	// so the missing evaluations do not need to be zeroed out
+	vanishingEvals := domain.FFT(vanishingCoeffs)
+	productEvals := make([]fr.Element, n)
+	for i := uint64(0); i < n; i++ {
+		productEvals[i].Mul(&evaluations[i], &vanishingEvals[i])
+	}
+
+	// 3. Interpolate P * Z
+	productCoeffs := domain.IFFT(productEvals)
+
+	// 4. Divide P * Z by Z over the coset g * <domain>, which Z does not vanish on
+	shiftPowers := utils.ComputePowers(fr.NewElement(multiplicativeGenerator), uint(n))
+	productCosetEvals := domain.FFT(scaleCoeffs(productCoeffs, shiftPowers))
+	vanishingCosetEvals := domain.FFT(scaleCoeffs(vanishingCoeffs, shiftPowers))
+
+	vanishingCosetEvals = fr.BatchInvert(vanishingCosetEvals)
+	for i := uint64(0); i < n; i++ {
+		productCosetEvals[i].Mul(&productCosetEvals[i], &vanishingCosetEvals[i])
+	}
+
+	// 5. Interpolate P over the coset, the inverse FFT gives the coefficients of P(g * x)
+	var shiftInv fr.Element
+	shiftInv.SetUint64(multiplicativeGenerator).Inverse(&shiftInv)
+	shiftInvPowers := utils.ComputePowers(shiftInv, uint(n))
+	coeffs := scaleCoeffs(domain.IFFT(productCosetEvals), shiftInvPowers)
+
+	return coeffs, nil
+}
+
+// Returns the coefficients of \prod (x^k - h_i^k), padded to `size` coefficients
+func vanishingPolyForCosets(size uint64, cosetSize uint64, cosetShifts []fr.Element) []fr.Element {
+	// The product is a polynomial in y = x^k, so it is computed in y first
+	shortCoeffs := make([]fr.Element, 1, len(cosetShifts)+1)
+	shortCoeffs[0].SetOne()
+	for _, shift := range cosetShifts {
+		// Multiply by (y - h^k)
+		shiftPowK := utils.ExpUint64(shift, cosetSize)
+		shortCoeffs = append(shortCoeffs, fr.Element{})
+		for j := len(shortCoeffs) - 1; j >= 0; j-- {
+			var tmp fr.Element
+			tmp.Mul(&shortCoeffs[j], &shiftPowK)
+			if j > 0 {
+				shortCoeffs[j].Sub(&shortCoeffs[j-1], &tmp)
+			} else {
+				shortCoeffs[j].Neg(&tmp)
+			}
+		}
+	}
+
+	coeffs := make([]fr.Element, size)
+	for j := range shortCoeffs {
+		coeffs[uint64(j)*cosetSize] = shortCoeffs[j]
+	}
+	return coeffs
+}
+
+// Returns the coefficients multiplied by the corresponding powers
+func scaleCoeffs(coeffs []fr.Element, powers []fr.Element) []fr.Element {
+	scaled := make([]fr.Element, len(coeffs))
+	for i := range coeffs {
+		scaled[i].Mul(&coeffs[i], &powers[i])
+	}
+	return scaled
+}
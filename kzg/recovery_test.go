@@ -0,0 +1,70 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestRecoverPolynomialCoeffs(t *testing.T) {
+	domain := NewDomain(32)
+	cosetSize := uint64(4)
+	// The stride between the points of a coset in natural order
+	stride := domain.Cardinality / cosetSize
+
+	coeffs := make([]fr.Element, domain.Cardinality)
+	for i := uint64(0); i < domain.Cardinality/2; i++ {
+		coeffs[i].SetUint64(i*i + 1)
+	}
+	evaluations := domain.FFT(coeffs)
+
+	// Erase the cosets ω^1 * <ω^stride>, ω^4 * <ω^stride>, ... up to half of the evaluations
+	missingRoots := []uint64{1, 4, 5, 7}
+	missingShifts := make([]fr.Element, len(missingRoots))
+	for i, root := range missingRoots {
+		missingShifts[i] = domain.Roots[root]
+		for j := uint64(0); j < cosetSize; j++ {
+			evaluations[root+j*stride].SetUint64(12345)
+		}
+	}
+
+	got, err := RecoverPolynomialCoeffs(domain, evaluations, cosetSize, missingShifts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range coeffs {
+		if !got[i].Equal(&coeffs[i]) {
+			t.Fatalf("coefficient %d was not recovered", i)
+		}
+	}
+
+	// Nothing missing
+	got, err = RecoverPolynomialCoeffs(domain, domain.FFT(coeffs), cosetSize, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range coeffs {
+		if !got[i].Equal(&coeffs[i]) {
+			t.Fatalf("coefficient %d was not recovered", i)
+		}
+	}
+}
+
+func TestRecoverPolynomialCoeffsInvalid(t *testing.T) {
+	domain := NewDomain(32)
+	evaluations := make([]fr.Element, 32)
+	shifts := make([]fr.Element, 5)
+	for i := range shifts {
+		shifts[i] = domain.Roots[i]
+	}
+
+	if _, err := RecoverPolynomialCoeffs(domain, evaluations, 4, shifts); err != ErrTooManyErasures {
+		t.Error("expected an error when more than half of the evaluations are missing")
+	}
+	if _, err := RecoverPolynomialCoeffs(domain, evaluations, 3, nil); err != ErrInvalidErasureSize {
+		t.Error("expected an error for a coset size which is not a power of two")
+	}
+	if _, err := RecoverPolynomialCoeffs(domain, evaluations[1:], 4, nil); err != ErrInvalidPolynomialSize {
+		t.Error("expected an error for the wrong number of evaluations")
+	}
+}
@@ -0,0 +1,89 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Create KZG proofs for many polynomials, all evaluated at the same point `a`
+//
+// This is equivalent to calling Open for each polynomial, however the
+// inverses 1/(roots - a) are only computed once and shared between
+// the evaluation and the quotient computation of every polynomial.
+// The per-polynomial work is done in parallel.
+func OpenSharedPoint(domain *Domain, polys []Polynomial, point fr.Element, ck *CommitKey) ([]OpeningProof, error) {
+	for _, p := range polys {
+		if len(p) == 0 || len(p) > len(ck.G1) {
+			return nil, ErrInvalidPolynomialSize
+		}
+		if domain.Cardinality != uint64(len(p)) {
+			return nil, errors.New("polynomial size does not match domain size")
+		}
+	}
+
+	if domain.isInDomain(point) {
+		return nil, errors.New("cannot divide by point in the domain")
+	}
+
+	// 1/(roots - a)
+	invDenom := make([]fr.Element, domain.Cardinality)
+	for i := 0; i < len(invDenom); i++ {
+		invDenom[i].Sub(&domain.Roots[i], &point)
+	}
+	invDenom = fr.BatchInvert(invDenom)
+
+	// (a^width - 1) * 1/width
+	// This is negated since the barycentric formula uses 1/(a - roots)
+	evalFactor := utils.ExpPow2(point, domain.Cardinality)
+	one := fr.One()
+	evalFactor.Sub(&evalFactor, &one)
+	evalFactor.Mul(&evalFactor, &domain.CardinalityInv)
+	evalFactor.Neg(&evalFactor)
+
+	proofs := make([]OpeningProof, len(polys))
+	errs := make([]error, len(polys))
+
+	utils.ParallelFor(len(polys), func(start, end int) {
+		for i := start; i < end; i++ {
+			proofs[i], errs[i] = openWithInverses(domain, polys[i], point, invDenom, &evalFactor, ck)
+		}
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+func openWithInverses(domain *Domain, p Polynomial, point fr.Element, invDenom []fr.Element, evalFactor *fr.Element, ck *CommitKey) (OpeningProof, error) {
+	// Evaluate the polynomial using the barycentric formula
+	var claimedValue, tmp fr.Element
+	for i := 0; i < len(p); i++ {
+		tmp.Mul(&p[i], &domain.Roots[i])
+		tmp.Mul(&tmp, &invDenom[i])
+		claimedValue.Add(&claimedValue, &tmp)
+	}
+	claimedValue.Mul(&claimedValue, evalFactor)
+
+	// Compute the quotient polynomial (f - f(a))/(x-a)
+	quotient := make([]fr.Element, len(p))
+	for i := 0; i < len(p); i++ {
+		quotient[i].Sub(&p[i], &claimedValue)
+		quotient[i].Mul(&quotient[i], &invDenom[i])
+	}
+
+	quotientComm, err := Commit(quotient, ck)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	return OpeningProof{
+		QuotientComm: *quotientComm,
+		InputPoint:   point,
+		ClaimedValue: claimedValue,
+	}, nil
+}
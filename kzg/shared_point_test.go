@@ -0,0 +1,43 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestOpenSharedPointMatchesOpen(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := NewSRSInsecure(*domain, big.NewInt(1234))
+
+	polys := []Polynomial{
+		{fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)},
+		{fr.NewElement(1), fr.NewElement(4), fr.NewElement(1), fr.NewElement(6)},
+	}
+	// Enough polynomials that some goroutines open more than one
+	for i := uint64(0); i < 64; i++ {
+		polys = append(polys, Polynomial{fr.NewElement(i), fr.NewElement(i + 1), fr.NewElement(2 * i), fr.NewElement(7)})
+	}
+	point := samplePointOutsideDomain(*domain)
+
+	proofs, err := OpenSharedPoint(domain, polys, *point, &srs.CommitKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, poly := range polys {
+		expected, _ := Open(domain, poly, *point, &srs.CommitKey)
+		if !expected.ClaimedValue.Equal(&proofs[i].ClaimedValue) {
+			t.Error("claimed value does not match Open")
+		}
+		if !expected.QuotientComm.Equal(&proofs[i].QuotientComm) {
+			t.Error("quotient commitment does not match Open")
+		}
+
+		comm, _ := Commit(poly, &srs.CommitKey)
+		if err := Verify(comm, &proofs[i], &srs.OpeningKey); err != nil {
+			t.Error("shared point proof down bad")
+		}
+	}
+}
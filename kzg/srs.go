@@ -18,11 +18,21 @@ type OpeningKey struct {
 	GenG1   curve.G1Affine
 	GenG2   curve.G2Affine
 	AlphaG2 curve.G2Affine
+
+	// Miller loop lines for GenG2 and AlphaG2, which are set by Precompute
+	lines *openingKeyLines
 }
 
+// Computes a multi-scalar multiplication over G1
+type MultiExpG1 func(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error)
+
 // Key used to make opening proofs
 type CommitKey struct {
 	G1 []curve.G1Affine
+
+	// Optional MSM implementation used when committing with this key.
+	// If nil, multiexp.MultiExp is used
+	MultiExp MultiExpG1
 }
 
 func (c CommitKey) ReversePoints() {
@@ -61,6 +71,7 @@ func NewSRSInsecure(domain Domain, bAlpha *big.Int) (*SRS, error) {
 	openKey.GenG1 = gen1Aff
 	openKey.GenG2 = gen2Aff
 	openKey.AlphaG2.ScalarMultiplication(&gen2Aff, bAlpha)
+	openKey.Precompute()
 
 	alphas := evaluateAllLagrangeCoefficients(domain, alpha)
 	for i := 0; i < len(alphas); i++ {
@@ -97,6 +108,7 @@ func newSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	openKey.GenG1 = gen1Aff
 	openKey.GenG2 = gen2Aff
 	openKey.AlphaG2.ScalarMultiplication(&gen2Aff, bAlpha)
+	openKey.Precompute()
 
 	alphas := make([]fr.Element, size-1)
 	alphas[0] = alpha
@@ -122,10 +134,57 @@ func Commit(p []fr.Element, ck *CommitKey) (*Commitment, error) {
 		return nil, ErrInvalidPolynomialSize
 	}
 
-	res, err := multiexp.MultiExp(p, ck.G1[:len(p)])
+	multiExp := multiexp.MultiExp
+	if ck.MultiExp != nil {
+		multiExp = ck.MultiExp
+	}
+	res, err := multiExp(p, ck.G1[:len(p)])
 	if err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
+
+// Structured reference string in monomial basis.
+//
+// This is needed for proofs which commit to polynomials
+// in coefficient form or need higher powers of alpha in G2
+type MonomialSRS struct {
+	// [α^i]G₁
+	G1 []curve.G1Affine
+	// [α^i]G₂
+	G2 []curve.G2Affine
+}
+
+// Creates an SRS in monomial form with `size` powers in both groups.
+// DO NOT USE THIS METHOD IN PRODUCTION
+func NewMonomialSRSInsecure(size uint64, bAlpha *big.Int) (*MonomialSRS, error) {
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	_, _, gen1Aff, gen2Aff := curve.Generators()
+
+	alphas := utils.ComputePowers(alpha, uint(size))
+	for i := 0; i < len(alphas); i++ {
+		alphas[i].FromMont()
+	}
+
+	return &MonomialSRS{
+		G1: curve.BatchScalarMultiplicationG1(&gen1Aff, alphas),
+		G2: curve.BatchScalarMultiplicationG2(&gen2Aff, alphas),
+	}, nil
+}
+
+// Commits to a polynomial in coefficient form, using the monomial SRS
+func CommitCoefficients(coeffs []fr.Element, srs *MonomialSRS) (*Commitment, error) {
+	if len(coeffs) == 0 || len(coeffs) > len(srs.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	return multiexp.MultiExp(coeffs, srs.G1[:len(coeffs)])
+}
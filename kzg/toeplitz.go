@@ -0,0 +1,166 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var (
+	ErrToeplitzShape      = errors.New("toeplitz matrix must have a first row and column of the same power of two size, which agree on the diagonal")
+	ErrToeplitzVectorSize = errors.New("vector size does not match the size of the toeplitz matrix")
+)
+
+// A square Toeplitz matrix, where each descending diagonal is constant.
+//
+// It is described by its first column and its first row, which share the
+// element at index 0. Entry (i, j) is Column[i-j] if i >= j and Row[j-i] otherwise.
+//
+// FK20 computes all of the KZG proofs for a polynomial with a Toeplitz matrix-vector product,
+// where the vector is made of SRS points. The product is computed in O(n log n) by embedding the
+// matrix into a circulant matrix of twice the size, which is diagonalised by the FFT:
+//
+//  1. Build the circulant embedding and take its FFT (this only depends on the matrix)
+//  2. Zero pad the vector to twice the size and take its FFT (this can be precomputed for SRS points)
+//  3. Multiply the two pointwise and take the inverse FFT
+//  4. The product is the first half of the result
+type ToeplitzMatrix struct {
+	Column []fr.Element
+	Row    []fr.Element
+}
+
+// Returns the first column of the circulant matrix of size 2n which contains the
+// Toeplitz matrix in its top left corner:
+//
+//	[Column_0, ..., Column_{n-1}, 0, Row_{n-1}, ..., Row_1]
+func (t *ToeplitzMatrix) CirculantEmbedding() ([]fr.Element, error) {
+	if err := t.checkShape(); err != nil {
+		return nil, err
+	}
+	n := len(t.Column)
+
+	circulant := make([]fr.Element, 2*n)
+	copy(circulant, t.Column)
+	for i := 1; i < n; i++ {
+		circulant[2*n-i] = t.Row[i]
+	}
+	return circulant, nil
+}
+
+// Returns the FFT of the circulant embedding, over the domain of size 2n.
+// These are the eigenvalues of the circulant matrix
+func (t *ToeplitzMatrix) CirculantFFT(domain *Domain) ([]fr.Element, error) {
+	circulant, err := t.CirculantEmbedding()
+	if err != nil {
+		return nil, err
+	}
+	if domain.Cardinality != uint64(len(circulant)) {
+		return nil, ErrToeplitzVectorSize
+	}
+	return domain.FFT(circulant), nil
+}
+
+// Computes the product of the matrix with a vector of scalars
+func (t *ToeplitzMatrix) MulVector(vector []fr.Element) ([]fr.Element, error) {
+	if err := t.checkShape(); err != nil {
+		return nil, err
+	}
+	n := len(t.Column)
+	if len(vector) != n {
+		return nil, ErrToeplitzVectorSize
+	}
+	domain := NewDomain(uint64(2 * n))
+
+	// 1. FFT of the circulant embedding
+	circulantFFT, err := t.CirculantFFT(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. FFT of the zero padded vector
+	vectorFFT := domain.FFT(vector)
+
+	// 3. Pointwise multiplication, then the inverse FFT
+	for i := 0; i < len(vectorFFT); i++ {
+		vectorFFT[i].Mul(&vectorFFT[i], &circulantFFT[i])
+	}
+	result := domain.IFFT(vectorFFT)
+
+	// 4. The product is the first half of the result
+	return result[:n], nil
+}
+
+// Computes the product of the matrix with a vector of G1 points
+func (t *ToeplitzMatrix) MulG1Vector(points []curve.G1Affine) ([]curve.G1Affine, error) {
+	if err := t.checkShape(); err != nil {
+		return nil, err
+	}
+	n := len(t.Column)
+	if len(points) != n {
+		return nil, ErrToeplitzVectorSize
+	}
+	domain := NewDomain(uint64(2 * n))
+
+	// 1. FFT of the circulant embedding
+	circulantFFT, err := t.CirculantFFT(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. FFT of the zero padded points
+	pointsFFT := G1VectorFFT(domain, points)
+
+	return HadamardIFFTG1(domain, circulantFFT, pointsFFT)[:n], nil
+}
+
+// Computes the FFT of a vector of G1 points over the domain, zero padding
+// it to the size of the domain. The input and output are in natural order.
+//
+// In FK20, the vector is made of SRS points, so this can be computed once and reused
+func G1VectorFFT(domain *Domain, points []curve.G1Affine) []curve.G1Jac {
+	if uint64(len(points)) > domain.Cardinality {
+		panic("number of points exceeds the size of the domain")
+	}
+	values := make([]curve.G1Jac, domain.Cardinality)
+	for i := 0; i < len(points); i++ {
+		values[i].FromAffine(&points[i])
+	}
+	domain.fftG1InPlace(values, domain.Generator)
+	return values
+}
+
+// Multiplies the FFT of a circulant matrix with the FFT of a vector of G1 points pointwise,
+// then takes the inverse FFT, giving the product of the circulant matrix with the vector.
+func HadamardIFFTG1(domain *Domain, circulantFFT []fr.Element, pointsFFT []curve.G1Jac) []curve.G1Affine {
+	if uint64(len(circulantFFT)) != domain.Cardinality || uint64(len(pointsFFT)) != domain.Cardinality {
+		panic("vector sizes must equal the size of the domain")
+	}
+
+	// The inverse FFT is scaled by 1/n, which is folded into the pointwise multiplication
+	values := make([]curve.G1Jac, domain.Cardinality)
+	var scalar fr.Element
+	var scalarBigInt big.Int
+	for i := 0; i < len(values); i++ {
+		scalar.Mul(&circulantFFT[i], &domain.CardinalityInv)
+		scalar.ToBigIntRegular(&scalarBigInt)
+		values[i].ScalarMultiplication(&pointsFFT[i], &scalarBigInt)
+	}
+
+	domain.fftG1InPlace(values, domain.GeneratorInv)
+
+	return curve.BatchJacobianToAffineG1(values)
+}
+
+func (t *ToeplitzMatrix) checkShape() error {
+	n := len(t.Column)
+	if n == 0 || len(t.Row) != n || !utils.IsPowerOfTwo(uint64(n)) {
+		return ErrToeplitzShape
+	}
+	if !t.Column[0].Equal(&t.Row[0]) {
+		return ErrToeplitzShape
+	}
+	return nil
+}
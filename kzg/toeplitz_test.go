@@ -0,0 +1,104 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestToeplitzMulVector(t *testing.T) {
+	n := 8
+	matrix := testToeplitzMatrix(n)
+
+	vector := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		vector[i].SetUint64(uint64(3*i + 1))
+	}
+
+	got, err := matrix.MulVector(vector)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := naiveToeplitzMul(matrix, vector)
+	for i := 0; i < n; i++ {
+		if !got[i].Equal(&expected[i]) {
+			t.Fatalf("incorrect product at index %d", i)
+		}
+	}
+}
+
+func TestToeplitzMulG1Vector(t *testing.T) {
+	n := 4
+	matrix := testToeplitzMatrix(n)
+	_, _, genG1, _ := curve.Generators()
+
+	vector := make([]fr.Element, n)
+	points := make([]curve.G1Affine, n)
+	for i := 0; i < n; i++ {
+		vector[i].SetUint64(uint64(i + 5))
+		points[i].ScalarMultiplication(&genG1, big.NewInt(int64(i+5)))
+	}
+
+	got, err := matrix.MulG1Vector(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := naiveToeplitzMul(matrix, vector)
+	for i := 0; i < n; i++ {
+		var expectedBigInt big.Int
+		expected[i].ToBigIntRegular(&expectedBigInt)
+		var expectedPoint curve.G1Affine
+		expectedPoint.ScalarMultiplication(&genG1, &expectedBigInt)
+		if !got[i].Equal(&expectedPoint) {
+			t.Fatalf("incorrect product at index %d", i)
+		}
+	}
+}
+
+func TestToeplitzInvalidShape(t *testing.T) {
+	matrix := testToeplitzMatrix(4)
+	matrix.Row[0].SetUint64(1000)
+	if _, err := matrix.CirculantEmbedding(); err != ErrToeplitzShape {
+		t.Error("expected an error when the row and column disagree on the diagonal")
+	}
+
+	matrix = ToeplitzMatrix{Column: make([]fr.Element, 3), Row: make([]fr.Element, 3)}
+	if _, err := matrix.CirculantEmbedding(); err != ErrToeplitzShape {
+		t.Error("expected an error for a size which is not a power of two")
+	}
+}
+
+func testToeplitzMatrix(n int) ToeplitzMatrix {
+	matrix := ToeplitzMatrix{
+		Column: make([]fr.Element, n),
+		Row:    make([]fr.Element, n),
+	}
+	for i := 0; i < n; i++ {
+		matrix.Column[i].SetUint64(uint64(i + 1))
+		matrix.Row[i].SetUint64(uint64(100 + i))
+	}
+	matrix.Row[0] = matrix.Column[0]
+	return matrix
+}
+
+func naiveToeplitzMul(matrix ToeplitzMatrix, vector []fr.Element) []fr.Element {
+	n := len(vector)
+	result := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var entry, tmp fr.Element
+			if i >= j {
+				entry = matrix.Column[i-j]
+			} else {
+				entry = matrix.Row[j-i]
+			}
+			tmp.Mul(&entry, &vector[j])
+			result[i].Add(&result[i], &tmp)
+		}
+	}
+	return result
+}
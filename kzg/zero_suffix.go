@@ -0,0 +1,102 @@
+package kzg
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+)
+
+var (
+	ErrInvalidSuffixStart = errors.New("suffix start must be non-zero and at most the size of the domain")
+	ErrSuffixNotZero      = errors.New("polynomial has a non-zero evaluation in the suffix")
+	ErrVerifyZeroSuffix   = errors.New("can't verify zero suffix proof")
+)
+
+// Proof to the claim that a committed polynomial f(x) in lagrange form
+// evaluates to zero at every root of the domain from index `k` onwards.
+//
+// Let T be the roots at indices >= k and Z_T(x) the polynomial vanishing on T.
+// f evaluates to zero on T if and only if Z_T(x) divides f(x), so the proof is a
+// commitment to the quotient q(x) = f(x) / Z_T(x)
+//
+// Note: index refers to the position of the evaluation in the polynomial, so if the
+// domain is bit-reversed, the suffix is over the bit-reversed roots
+type ZeroSuffixProof struct {
+	// [q(α)]G₁
+	QuotientComm curve.G1Affine
+}
+
+// Create a proof that the polynomial `p` in lagrange form is zero at every index >= suffixStart
+func ProveZeroSuffix(domain *Domain, p Polynomial, suffixStart uint64, srs *MonomialSRS) (*ZeroSuffixProof, error) {
+	if suffixStart == 0 || suffixStart > domain.Cardinality {
+		return nil, ErrInvalidSuffixStart
+	}
+	if domain.Cardinality != uint64(len(p)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	for i := suffixStart; i < domain.Cardinality; i++ {
+		if !p[i].IsZero() {
+			return nil, ErrSuffixNotZero
+		}
+	}
+
+	coeffs, err := domain.LagrangeToCoefficients(p)
+	if err != nil {
+		return nil, err
+	}
+
+	vanishingPoly := vanishingPolyCoeffs(domain.Roots[suffixStart:])
+	quotient, remainder, err := dividePolys(coeffs, vanishingPoly)
+	if err != nil {
+		return nil, err
+	}
+	// This should not happen since we checked the evaluations above
+	if !isZeroPoly(remainder) {
+		return nil, ErrSuffixNotZero
+	}
+	if len(quotient) > len(srs.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	quotientComm, err := multiexp.MultiExp(quotient, srs.G1[:len(quotient)])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZeroSuffixProof{QuotientComm: *quotientComm}, nil
+}
+
+// Verify a proof that the polynomial behind `commitment` is zero at every index >= suffixStart
+func VerifyZeroSuffix(domain *Domain, commitment *Commitment, proof *ZeroSuffixProof, suffixStart uint64, srs *MonomialSRS) error {
+	if suffixStart == 0 || suffixStart > domain.Cardinality {
+		return ErrInvalidSuffixStart
+	}
+
+	// [Z_T(α)]G₂
+	vanishingPoly := vanishingPolyCoeffs(domain.Roots[suffixStart:])
+	if len(vanishingPoly) > len(srs.G2) {
+		return ErrInvalidSuffixStart
+	}
+	vanishingComm, err := multiexp.MultiExpG2(vanishingPoly, srs.G2[:len(vanishingPoly)])
+	if err != nil {
+		return err
+	}
+
+	// e([f(α)]G₁, G₂).e(-[q(α)]G₁, [Z_T(α)]G₂) ==? 1
+	var negQuotientComm curve.G1Affine
+	negQuotientComm.Neg(&proof.QuotientComm)
+
+	check, err := pairingCheck(
+		[]curve.G1Affine{*commitment, negQuotientComm},
+		[]curve.G2Affine{srs.G2[0], *vanishingComm},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyZeroSuffix
+	}
+	return nil
+}
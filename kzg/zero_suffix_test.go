@@ -0,0 +1,78 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestZeroSuffixProofSmoke(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := NewDomain(8)
+	srs, _ := NewSRSInsecure(*domain, secret)
+	monomialSRS, _ := NewMonomialSRSInsecure(domain.Cardinality, secret)
+
+	domain.ReverseRoots()
+	srs.CommitKey.ReversePoints()
+
+	// Only the first three evaluations are non-zero
+	poly := make(Polynomial, domain.Cardinality)
+	poly[0] = fr.NewElement(7)
+	poly[1] = fr.NewElement(8)
+	poly[2] = fr.NewElement(9)
+	comm, _ := Commit(poly, &srs.CommitKey)
+
+	for suffixStart := uint64(3); suffixStart <= domain.Cardinality; suffixStart++ {
+		proof, err := ProveZeroSuffix(domain, poly, suffixStart, monomialSRS)
+		if err != nil {
+			t.Fatalf("could not prove zero suffix from %d: %s", suffixStart, err)
+		}
+		if err := VerifyZeroSuffix(domain, comm, proof, suffixStart, monomialSRS); err != nil {
+			t.Errorf("zero suffix from %d failed to verify", suffixStart)
+		}
+	}
+
+	_, err := ProveZeroSuffix(domain, poly, 2, monomialSRS)
+	if err != ErrSuffixNotZero {
+		t.Error("expected the suffix to contain a non-zero evaluation")
+	}
+
+	// A proof for one suffix must not verify for a longer suffix
+	proof, _ := ProveZeroSuffix(domain, poly, 4, monomialSRS)
+	if err := VerifyZeroSuffix(domain, comm, proof, 3, monomialSRS); err == nil {
+		t.Error("proof for suffix 4 should not verify for suffix 3")
+	}
+}
+
+func TestDividePolys(t *testing.T) {
+	// (x - 2)(x - 3) = x^2 - 5x + 6
+	points := []fr.Element{fr.NewElement(2), fr.NewElement(3)}
+	vanishing := vanishingPolyCoeffs(points)
+
+	var minusFive fr.Element
+	minusFive.SetInt64(-5)
+	expected := []fr.Element{fr.NewElement(6), minusFive, fr.One()}
+	for i := 0; i < len(expected); i++ {
+		if !vanishing[i].Equal(&expected[i]) {
+			t.Fatal("incorrect vanishing polynomial")
+		}
+	}
+
+	// (x^2 - 5x + 6)(x + 1) = x^3 - 4x^2 + x + 6
+	var minusFour fr.Element
+	minusFour.SetInt64(-4)
+	numerator := []fr.Element{fr.NewElement(6), fr.One(), minusFour, fr.One()}
+
+	quotient, remainder, err := dividePolys(numerator, vanishing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isZeroPoly(remainder) {
+		t.Error("remainder should be zero")
+	}
+	one := fr.One()
+	if len(quotient) != 2 || !quotient[0].Equal(&one) || !quotient[1].Equal(&one) {
+		t.Error("incorrect quotient")
+	}
+}
@@ -0,0 +1,65 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrLimitExceeded = errors.New("input size limit exceeded")
+
+// Bounds on the size of the inputs that the Context will accept.
+//
+// These allow node operators to bound the worst case CPU and memory usage
+// of a single call, before any expensive work is done.
+// A value of zero means that there is no limit.
+type Limits struct {
+	// Maximum number of polynomials that can be passed to a proving or committing method
+	MaxPolysPerCall int
+	// Maximum number of polynomials that can be passed to a batch verification method
+	MaxBatchSize int
+	// Maximum number of cells that can be passed to a single reconstruction
+	MaxCellsPerReconstruction int
+}
+
+// Returned when an input exceeds one of the configured Limits
+type LimitError struct {
+	// Name of the limit that was exceeded
+	Limit string
+	Max   int
+	Got   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s is %d, got %d", ErrLimitExceeded, e.Limit, e.Max, e.Got)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Sets the input size limits for the Context.
+// By default, there are no limits
+func WithLimits(limits Limits) ContextOption {
+	return func(c *Context) {
+		c.limits = limits
+	}
+}
+
+func checkLimit(name string, max int, got int) error {
+	if max > 0 && got > max {
+		return &LimitError{Limit: name, Max: max, Got: got}
+	}
+	return nil
+}
+
+func (c *Context) checkPolysPerCall(numPolys int) error {
+	return checkLimit("MaxPolysPerCall", c.limits.MaxPolysPerCall, numPolys)
+}
+
+func (c *Context) checkBatchSize(batchSize int) error {
+	return checkLimit("MaxBatchSize", c.limits.MaxBatchSize, batchSize)
+}
+
+func (c *Context) checkCellsPerReconstruction(numCells int) error {
+	return checkLimit("MaxCellsPerReconstruction", c.limits.MaxCellsPerReconstruction, numCells)
+}
@@ -0,0 +1,29 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithLimits(Limits{MaxPolysPerCall: 1, MaxBatchSize: 1}))
+
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 2)}
+
+	_, err := ctx.PolyToCommitments(polys)
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Error("expected the number of polynomials to exceed the limit")
+	}
+
+	err = ctx.VerifyAggregateKzgProof(polys, nil, nil)
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != "MaxBatchSize" {
+		t.Error("expected the batch size to exceed the limit")
+	}
+
+	// Within the limits, the call should succeed
+	_, err = ctx.PolyToCommitments(polys[:1])
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,70 @@
+package context
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Bounded LRU cache keyed by byte arrays, which is safe for concurrent use.
+// It backs the commitment cache and the verification memo
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// Most recently used entries are at the front
+	order *list.List
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Returns the value for the key and marks it as the most recently used
+func (lc *lruCache) get(key []byte) (interface{}, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	elem, ok := lc.entries[string(key)]
+	if !ok {
+		return nil, false
+	}
+	lc.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+// Adds the key, evicting the least recently used key if the cache is full.
+// If the key is already present, its value is not replaced
+func (lc *lruCache) add(key []byte, value interface{}) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if elem, ok := lc.entries[string(key)]; ok {
+		lc.order.MoveToFront(elem)
+		return
+	}
+
+	// Evict the least recently used entry
+	if lc.order.Len() >= lc.capacity {
+		oldest := lc.order.Back()
+		lc.order.Remove(oldest)
+		delete(lc.entries, oldest.Value.(*lruEntry).key)
+	}
+
+	entry := &lruEntry{key: string(key), value: value}
+	lc.entries[entry.key] = lc.order.PushFront(entry)
+}
+
+func (lc *lruCache) len() int {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.order.Len()
+}
@@ -0,0 +1,31 @@
+package context
+
+import "testing"
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.add([]byte{1}, 1)
+	cache.add([]byte{2}, 2)
+
+	// Use the first key, so that the second is the least recently used
+	if value, ok := cache.get([]byte{1}); !ok || value.(int) != 1 {
+		t.Fatal("expected the first key to be cached")
+	}
+	cache.add([]byte{3}, 3)
+
+	if cache.len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.len())
+	}
+	if _, ok := cache.get([]byte{2}); ok {
+		t.Error("least recently used key should have been evicted")
+	}
+	if _, ok := cache.get([]byte{1}); !ok {
+		t.Error("recently used key should not have been evicted")
+	}
+
+	// Adding a key which is present does not replace its value
+	cache.add([]byte{1}, 4)
+	if value, _ := cache.get([]byte{1}); value.(int) != 1 {
+		t.Error("existing value should not be replaced")
+	}
+}
@@ -0,0 +1,264 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Helpers for 2D layouts, where the blobs are the rows of a matrix.
+//
+// The commitment to row i is the commitment to the i'th blob, from PolyToCommitments.
+// Column j contains the j'th element of every blob, and is committed to as the polynomial
+// whose evaluations over the bit-reversed domain of size numRows are the elements of the column.
+// Commitments to either are combined using powers of a challenge, which gives the
+// commitment to the same combination of the rows or columns.
+// For PeerDAS, the extended matrix has a row for each blob and a column for each cell index,
+// and RecoverMatrix reconstructs it from some of its cells.
+
+var (
+	ErrInvalidNumRows      = errors.New("number of rows must be a power of two, which is at most the polynomial size")
+	ErrMatrixShapeMismatch = errors.New("every row of the matrix must have the same number of elements")
+	ErrEmptyCombination    = errors.New("cannot combine zero commitments or polynomials")
+	ErrInvalidRowIndex     = errors.New("row index is out of range")
+)
+
+// Computes the commitment to each column of the matrix whose rows are `serPolys`.
+// The number of rows must be a power of two which is at most the polynomial size.
+//
+// Note: the polynomials are modified in place during deserialisation.
+func (c *Context) ColumnCommitments(serPolys []SerialisedPoly) (_ SerialisedCommitments, err error) {
+	defer c.recoverPanic(&err)
+
+	numRows := uint64(len(serPolys))
+	if !utils.IsPowerOfTwo(numRows) || numRows > c.domain.Cardinality {
+		return nil, ErrInvalidNumRows
+	}
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return nil, err
+	}
+
+	// 1. Deserialise the rows
+	rows, err := deserialisePolys(serPolys)
+	if err != nil {
+		return nil, err
+	}
+	numColumns := len(rows[0])
+	for _, row := range rows {
+		if len(row) != numColumns {
+			return nil, ErrMatrixShapeMismatch
+		}
+	}
+
+	// 2. The columns are evaluated over a bit-reversed domain of size numRows, which is
+	// the first numRows roots of the polynomials' domain
+	columnDomain, err := c.domain.SubDomain(numRows)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Commit to each column in coefficient form
+	comms := make(SerialisedCommitments, numColumns)
+	column := make([]fr.Element, numRows)
+	c.withCPUBudget(func() {
+		for j := 0; j < numColumns; j++ {
+			for i := range rows {
+				column[i] = rows[i][j]
+			}
+			var coeffs []fr.Element
+			coeffs, err = columnDomain.LagrangeToCoefficients(column)
+			if err != nil {
+				return
+			}
+			var comm *kzg.Commitment
+			comm, err = kzg.CommitCoefficients(coeffs, c.monomial())
+			if err != nil {
+				return
+			}
+			serComm := comm.Bytes()
+			comms[j] = serComm[:]
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comms, nil
+}
+
+// Returns \sum r^i * C_i, where r is the challenge. This is the commitment to \sum r^i * p_i,
+// where C_i is the commitment to p_i, so it can be checked against CombinePolynomials.
+func (c *Context) CombineCommitments(serComms SerialisedCommitments, challenge [32]byte) (_ KZGCommitment, err error) {
+	defer c.recoverPanic(&err)
+
+	if len(serComms) == 0 {
+		return nil, ErrEmptyCombination
+	}
+
+	// 1. Deserialise the commitments and the challenge
+	comms, _, err := c.deserialiseCommitments(serComms)
+	if err != nil {
+		return nil, err
+	}
+	r, err := deserialiseScalar(challenge[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Combine the commitments using the powers of the challenge, which are computed once
+	powers := utils.ComputePowersParallel(r, uint(len(comms)))
+	combined, err := agg_kzg.FoldCommitments(comms, powers)
+	if err != nil {
+		return nil, err
+	}
+	serCombined := combined.Bytes()
+	return serCombined[:], nil
+}
+
+// Returns \sum r^i * p_i, where r is the challenge.
+// The polynomials must all be the same size.
+//
+// Note: the polynomials are modified in place during deserialisation.
+func (c *Context) CombinePolynomials(serPolys []SerialisedPoly, challenge [32]byte) (_ SerialisedPoly, err error) {
+	defer c.recoverPanic(&err)
+
+	if len(serPolys) == 0 {
+		return nil, ErrEmptyCombination
+	}
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return nil, err
+	}
+
+	// 1. Deserialise the polynomials and the challenge
+	polys, err := deserialisePolys(serPolys)
+	if err != nil {
+		return nil, err
+	}
+	for _, poly := range polys {
+		if len(poly) != len(polys[0]) {
+			return nil, ErrMatrixShapeMismatch
+		}
+	}
+	r, err := deserialiseScalar(challenge[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Combine the polynomials using the powers of the challenge
+	powers := utils.ComputePowersParallel(r, uint(len(polys)))
+	combined, err := agg_kzg.FoldPolynomials(polys, powers, c.protocol.FoldWorkers)
+	if err != nil {
+		return nil, err
+	}
+	return serialisePoly(combined), nil
+}
+
+// A cell of the extended matrix, which is in row `Row` and column `Column`.
+// The row is the index of the blob and the column is the index of the cell within the blob's extension
+type MatrixCell struct {
+	Row    uint64
+	Column uint64
+	Cell   SerialisedCell
+}
+
+// Returned by RecoverMatrix when a row cannot be recovered
+type RowRecoveryError struct {
+	Row uint64
+	Err error
+}
+
+func (e *RowRecoveryError) Error() string {
+	return fmt.Sprintf("could not recover row %d: %s", e.Row, e.Err)
+}
+
+func (e *RowRecoveryError) Unwrap() error {
+	return e.Err
+}
+
+// Recovers every cell and proof of an extended matrix with `numRows` blobs, from some of its cells.
+//
+// The cells may be given in any order. Each row is recovered with RecoverCellsAndKZGProofs, so at least
+// half of the cells of every row are needed, and the rows are recovered in parallel.
+// The result is indexed by row and then by column. If any row cannot be recovered, the error is a
+// *RowRecoveryError for the row with the lowest index.
+// The number of rows counts towards Limits.MaxPolysPerCall.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverMatrix(numRows uint64, matrixCells []MatrixCell) (_ [][]SerialisedCell, _ [][]KZGProof, err error) {
+	defer c.recoverPanic(&err)
+	cells, proofs, _, err := c.recoverMatrix(numRows, matrixCells, false)
+	return cells, proofs, err
+}
+
+// Same as RecoverMatrix, and also returns the report for the recovery of each row.
+// The reports are also returned when a row cannot be recovered, the report is nil for any row
+// whose cells fail their checks or which was not reached.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverMatrixWithReports(numRows uint64, matrixCells []MatrixCell) (_ [][]SerialisedCell, _ [][]KZGProof, _ []*RecoveryReport, err error) {
+	defer c.recoverPanic(&err)
+	return c.recoverMatrix(numRows, matrixCells, true)
+}
+
+// The reports are nil unless `withReports` is set
+func (c *Context) recoverMatrix(numRows uint64, matrixCells []MatrixCell, withReports bool) ([][]SerialisedCell, [][]KZGProof, []*RecoveryReport, error) {
+	if c.cellDomain == nil {
+		return nil, nil, nil, ErrCellsNotSupported
+	}
+
+	// Every row needs half of its cells, so this bounds the number of rows by the number of cells
+	// before anything is allocated. In particular, numRows then fits in an int
+	cellsPerRow := c.CellsPerExtBlob() / c.params.ExpansionFactor
+	if numRows > uint64(len(matrixCells))/cellsPerRow {
+		return nil, nil, nil, ErrNotEnoughCells
+	}
+	if err := c.checkPolysPerCall(int(numRows)); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 1. Group the cells by row
+	cellIndices := make([][]uint64, numRows)
+	rowCells := make([][]SerialisedCell, numRows)
+	for _, matrixCell := range matrixCells {
+		if matrixCell.Row >= numRows {
+			return nil, nil, nil, ErrInvalidRowIndex
+		}
+		cellIndices[matrixCell.Row] = append(cellIndices[matrixCell.Row], matrixCell.Column)
+		rowCells[matrixCell.Row] = append(rowCells[matrixCell.Row], matrixCell.Cell)
+	}
+
+	// 2. Recover the rows in parallel
+	cells := make([][]SerialisedCell, numRows)
+	proofs := make([][]KZGProof, numRows)
+	errs := make([]error, numRows)
+	var reports []*RecoveryReport
+	if withReports {
+		reports = make([]*RecoveryReport, numRows)
+	}
+	utils.ParallelFor(int(numRows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var report *RecoveryReport
+			if withReports {
+				report = newRecoveryReport()
+			}
+			cells[row], proofs[row], errs[row] = c.recoverCellsAndKZGProofs(cellIndices[row], rowCells[row], report)
+			if withReports {
+				reports[row] = report.ifDeserialised()
+			}
+			if errs[row] != nil {
+				// The error for the lowest row is returned, so the rest of the chunk is not needed
+				return
+			}
+		}
+	})
+
+	for row, err := range errs {
+		if err != nil {
+			return nil, nil, reports, &RowRecoveryError{Row: uint64(row), Err: err}
+		}
+	}
+	return cells, proofs, reports, nil
+}
@@ -0,0 +1,179 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+func TestColumnCommitments(t *testing.T) {
+	ctx := NewContextInsecure(16, 1234)
+
+	// When every row is the same, each column is constant
+	row := testSerialisedPoly(16, 3)
+	rows := []SerialisedPoly{copyPoly(row), copyPoly(row), copyPoly(row), copyPoly(row)}
+	comms, err := ctx.ColumnCommitments(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comms) != 16 {
+		t.Fatalf("expected 16 column commitments, got %d", len(comms))
+	}
+	_, _, genG1, _ := curve.Generators()
+	for j := 0; j < 16; j++ {
+		var expected curve.G1Affine
+		expected.ScalarMultiplication(&genG1, big.NewInt(int64(3+j)))
+		serExpected := expected.Bytes()
+		if !bytes.Equal(comms[j], serExpected[:]) {
+			t.Fatalf("commitment to column %d is incorrect", j)
+		}
+	}
+
+	if _, err := ctx.ColumnCommitments(copyPolys(rows[:3])); err != ErrInvalidNumRows {
+		t.Error("expected an error for a number of rows which is not a power of two")
+	}
+	uneven := []SerialisedPoly{copyPoly(row), copyPoly(row[:8])}
+	if _, err := ctx.ColumnCommitments(uneven); err != ErrMatrixShapeMismatch {
+		t.Error("expected an error for rows of different sizes")
+	}
+}
+
+func TestCombineRows(t *testing.T) {
+	ctx := NewContextInsecure(16, 1234)
+
+	rows := []SerialisedPoly{testSerialisedPoly(16, 3), testSerialisedPoly(16, 100), testSerialisedPoly(16, 7)}
+	rowComms, err := ctx.PolyToCommitments(copyPolys(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var challenge [32]byte
+	challenge[0] = 5
+
+	// The combination of the commitments is the commitment to the combination of the rows
+	combinedComm, err := ctx.CombineCommitments(rowComms, challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combinedRow, err := ctx.CombinePolynomials(copyPolys(rows), challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ctx.PolyToCommitments([]SerialisedPoly{combinedRow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(combinedComm, expected[0]) {
+		t.Error("combined commitment does not match the combined rows")
+	}
+
+	if _, err := ctx.CombineCommitments(nil, challenge); err != ErrEmptyCombination {
+		t.Error("expected an error for no commitments")
+	}
+	if _, err := ctx.CombinePolynomials(nil, challenge); err != ErrEmptyCombination {
+		t.Error("expected an error for no polynomials")
+	}
+}
+
+func TestRecoverMatrix(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	numRows := uint64(3)
+	expectedCells := make([][]SerialisedCell, numRows)
+	expectedProofs := make([][]KZGProof, numRows)
+	for row := uint64(0); row < numRows; row++ {
+		var err error
+		expectedCells[row], expectedProofs[row], err = ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 10*row+3))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each row is missing different columns
+	available := [][]uint64{{0, 1}, {3, 2, 1}, {2, 0}}
+	var matrixCells []MatrixCell
+	for row, columns := range available {
+		for _, column := range columns {
+			matrixCells = append(matrixCells, MatrixCell{Row: uint64(row), Column: column, Cell: copyPoly(expectedCells[row][column])})
+		}
+	}
+
+	cells, proofs, err := ctx.RecoverMatrix(numRows, matrixCells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for row := uint64(0); row < numRows; row++ {
+		for column := range expectedCells[row] {
+			if !bytes.Equal(cells[row][column][9], expectedCells[row][column][9]) {
+				t.Fatalf("cell (%d, %d) was not recovered", row, column)
+			}
+			if !bytes.Equal(proofs[row][column], expectedProofs[row][column]) {
+				t.Fatalf("proof for cell (%d, %d) was not recovered", row, column)
+			}
+		}
+	}
+
+	// Row 1 does not have enough cells
+	tooFew := []MatrixCell{
+		{Row: 0, Column: 0, Cell: copyPoly(expectedCells[0][0])},
+		{Row: 0, Column: 1, Cell: copyPoly(expectedCells[0][1])},
+		{Row: 0, Column: 2, Cell: copyPoly(expectedCells[0][2])},
+		{Row: 1, Column: 0, Cell: copyPoly(expectedCells[1][0])},
+	}
+	_, _, err = ctx.RecoverMatrix(2, tooFew)
+	var rowErr *RowRecoveryError
+	if !errors.As(err, &rowErr) || rowErr.Row != 1 || !errors.Is(err, ErrNotEnoughCells) {
+		t.Errorf("expected row 1 to fail recovery, got %v", err)
+	}
+
+	if _, _, err := ctx.RecoverMatrix(1, tooFew); err != ErrInvalidRowIndex {
+		t.Error("expected an error for an out of range row index")
+	}
+
+	// The number of rows is checked against the number of cells before anything is allocated
+	if _, _, err := ctx.RecoverMatrix(1<<40, tooFew); err != ErrNotEnoughCells {
+		t.Error("expected an error for too many rows")
+	}
+	limited := NewContextInsecure(128, 1234, WithLimits(Limits{MaxPolysPerCall: 1}))
+	if _, _, err := limited.RecoverMatrix(2, tooFew); !errors.Is(err, ErrLimitExceeded) {
+		t.Error("expected the number of rows to be limited")
+	}
+}
+
+func TestRecoverMatrixWithReports(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	numRows := uint64(2)
+	var matrixCells []MatrixCell
+	for row := uint64(0); row < numRows; row++ {
+		cells, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, row))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Row i is missing cell i
+		for column := uint64(0); column < 3; column++ {
+			if column != row {
+				matrixCells = append(matrixCells, MatrixCell{Row: row, Column: column, Cell: copyPoly(cells[column])})
+			}
+		}
+	}
+
+	_, _, reports, err := ctx.RecoverMatrixWithReports(numRows, matrixCells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != int(numRows) {
+		t.Fatalf("expected a report for each row, got %d", len(reports))
+	}
+	for row, report := range reports {
+		if report == nil {
+			t.Fatalf("expected a report for row %d", row)
+		}
+		if report.MissingCellIndices[0] != uint64(row) {
+			t.Errorf("report for row %d has missing cells %v", row, report.MissingCellIndices)
+		}
+	}
+}
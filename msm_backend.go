@@ -0,0 +1,48 @@
+package context
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// An implementation of multi-scalar multiplication over G1.
+//
+// This allows callers to offload the MSMs done when committing and creating proofs,
+// for example to a GPU, since for large workloads these dominate the proving time.
+//
+// The scalars are given in montgomery form, as stored by fr.Element.
+// Implementations are free to fall back to multiexp.MultiExp, for example
+// when the MSM is too small to be worth offloading.
+type MSMBackend interface {
+	MultiExpG1(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error)
+}
+
+// Sets the backend used for MSMs over the commit key.
+// By default, gnark-crypto is used
+func WithMSMBackend(backend MSMBackend) ContextOption {
+	return func(c *Context) {
+		if backend == nil {
+			c.commitKey.MultiExp = nil
+			return
+		}
+		c.commitKey.MultiExp = backend.MultiExpG1
+	}
+}
+
+// Sets the maximum number of goroutines that gnark-crypto uses for each MSM.
+// By default, this is the number of CPUs.
+//
+// This is ignored if an MSM backend is set with WithMSMBackend
+func WithMSMTasks(nbTasks int) ContextOption {
+	return func(c *Context) {
+		c.msmTasks = nbTasks
+	}
+}
+
+// Sets the number of goroutines used to fold polynomials in the aggregation protocols.
+// By default, this is the number of CPUs.
+func WithFoldWorkers(numWorkers int) ContextOption {
+	return func(c *Context) {
+		c.protocol.FoldWorkers = numWorkers
+	}
+}
@@ -0,0 +1,69 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/multiexp"
+)
+
+type countingMSMBackend struct {
+	calls int
+}
+
+func (b *countingMSMBackend) MultiExpG1(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error) {
+	b.calls++
+	return multiexp.MultiExp(scalars, points)
+}
+
+func TestWithMSMBackend(t *testing.T) {
+	backend := &countingMSMBackend{}
+	ctx := NewContextInsecure(4, 1234, WithMSMBackend(backend))
+	defaultCtx := NewContextInsecure(4, 1234)
+
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 7)}
+
+	proof, comms, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.calls == 0 {
+		t.Fatal("expected the MSM backend to be used")
+	}
+
+	expectedProof, expectedComms, err := defaultCtx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(proof, expectedProof) {
+		t.Error("proof does not match the default backend")
+	}
+	for i := range comms {
+		if !bytes.Equal(comms[i], expectedComms[i]) {
+			t.Error("commitment does not match the default backend")
+		}
+	}
+}
+
+func TestWithFoldWorkers(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	singleWorkerCtx := NewContextInsecure(8, 1234, WithFoldWorkers(1))
+	if singleWorkerCtx.protocol.FoldWorkers != 1 {
+		t.Fatal("number of fold workers was not set")
+	}
+
+	polys := []SerialisedPoly{testSerialisedPoly(8, 1), testSerialisedPoly(8, 9), testSerialisedPoly(8, 17)}
+	expectedProof, _, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, _, err := singleWorkerCtx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(proof, expectedProof) {
+		t.Error("number of fold workers changed the proof")
+	}
+}
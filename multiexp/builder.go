@@ -0,0 +1,170 @@
+package multiexp
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Largest window size that NewMSMBuilder will choose
+const maxWindowBits = 16
+
+// Builds an MSM from (scalar, point) pairs which are added one at a time, for protocols
+// which produce the inputs piecewise, such as aggregation and accumulators.
+//
+// The MSM is computed with the bucket method. Each scalar is split into signed windows of
+// c bits as soon as it is added, so this work overlaps with the arrival of the inputs, and
+// Finalize only needs to accumulate the buckets.
+// An MSMBuilder is not safe for concurrent use.
+type MSMBuilder struct {
+	// Window size in bits
+	c uint64
+	// Number of windows that each scalar is split into
+	numWindows uint64
+
+	points []curve.G1Affine
+	// digits[i*numWindows + w] is the w'th window of the i'th scalar.
+	// Every window except the last is in [-2^{c-1}, 2^{c-1}), the last window is non-negative
+	digits []int32
+}
+
+// Returns an empty builder, whose window size is chosen for MSMs of around `sizeHint` points.
+// The builder accepts any number of points, the hint only affects performance
+func NewMSMBuilder(sizeHint int) *MSMBuilder {
+	c := bestWindowBits(uint64(sizeHint))
+	return &MSMBuilder{
+		c:          c,
+		numWindows: (fr.Bits + c - 1) / c,
+		points:     make([]curve.G1Affine, 0, sizeHint),
+		digits:     make([]int32, 0, uint64(sizeHint)*((fr.Bits+c-1)/c)),
+	}
+}
+
+// Chooses the window size which minimises the number of group additions,
+// which is roughly (number of windows) * (n + number of buckets)
+func bestWindowBits(n uint64) uint64 {
+	best := uint64(1)
+	bestCost := ^uint64(0)
+	for c := uint64(1); c <= maxWindowBits; c++ {
+		numWindows := (fr.Bits + c - 1) / c
+		cost := numWindows * (n + (1 << (c - 1)))
+		if cost < bestCost {
+			best = c
+			bestCost = cost
+		}
+	}
+	return best
+}
+
+// Adds scalar * point to the MSM
+func (b *MSMBuilder) Add(scalar fr.Element, point curve.G1Affine) {
+	b.points = append(b.points, point)
+
+	// 1. Convert the scalar out of montgomery form
+	regular := scalar.ToRegular()
+
+	// 2. Split it into windows of c bits, and move each window into [-2^{c-1}, 2^{c-1})
+	// by carrying into the next one. The last window keeps the carry, since there is no window after it
+	mask := uint64(1)<<b.c - 1
+	half := int32(1) << (b.c - 1)
+	carry := int32(0)
+	for w := uint64(0); w < b.numWindows; w++ {
+		digit := int32(windowBits(&regular, w*b.c, b.c)&mask) + carry
+		carry = 0
+		if digit >= half && w != b.numWindows-1 {
+			digit -= int32(1) << b.c
+			carry = 1
+		}
+		b.digits = append(b.digits, digit)
+	}
+}
+
+// Returns the `c` bits of the scalar starting at bit `start`.
+// The scalar is four little-endian limbs, and the bits may span two of them
+func windowBits(scalar *fr.Element, start, c uint64) uint64 {
+	limb := start / 64
+	offset := start % 64
+	bits := scalar[limb] >> offset
+	if offset+c > 64 && limb+1 < fr.Limbs {
+		bits |= scalar[limb+1] << (64 - offset)
+	}
+	return bits
+}
+
+// Adds scalars[i] * points[i] to the MSM, for each i
+func (b *MSMBuilder) AddMany(scalars []fr.Element, points []curve.G1Affine) error {
+	if len(scalars) != len(points) {
+		return errors.New("number of scalars != number of points")
+	}
+	for i := range scalars {
+		b.Add(scalars[i], points[i])
+	}
+	return nil
+}
+
+// Returns the number of (scalar, point) pairs that have been added
+func (b *MSMBuilder) Len() int {
+	return len(b.points)
+}
+
+// Computes the MSM of every pair that has been added.
+// If no pairs have been added, this is the identity point. The builder is not reset
+func (b *MSMBuilder) Finalize() *curve.G1Affine {
+	// 1. Compute the sum for each window in parallel
+	windowSums := make([]curve.G1Jac, b.numWindows)
+	utils.ParallelForWithGoroutines(int(b.numWindows), int(b.numWindows), func(start, end int) {
+		for w := start; w < end; w++ {
+			b.windowSum(uint64(w), &windowSums[w])
+		}
+	})
+
+	// 2. Combine the windows, starting from the most significant
+	var result curve.G1Jac
+	result.Set(&windowSums[b.numWindows-1])
+	for w := int(b.numWindows) - 2; w >= 0; w-- {
+		for i := uint64(0); i < b.c; i++ {
+			result.DoubleAssign()
+		}
+		result.AddAssign(&windowSums[w])
+	}
+
+	var affineResult curve.G1Affine
+	affineResult.FromJacobian(&result)
+	return &affineResult
+}
+
+// Computes \sum_i digit_i * P_i for the w'th window, using the bucket method
+func (b *MSMBuilder) windowSum(w uint64, sum *curve.G1Jac) {
+	// 1. Add each point into the bucket for the absolute value of its digit, negating it if the digit is negative.
+	// The last window is non-negative and contains the remaining bits plus a carry, so it may need more buckets
+	numBuckets := uint64(1) << (b.c - 1)
+	if w == b.numWindows-1 {
+		numBuckets = uint64(1) << (fr.Bits - w*b.c)
+	}
+	buckets := make([]curve.G1Jac, numBuckets)
+	var neg curve.G1Affine
+	for i := range b.points {
+		digit := b.digits[uint64(i)*b.numWindows+w]
+		if digit > 0 {
+			buckets[digit-1].AddMixed(&b.points[i])
+		} else if digit < 0 {
+			neg.Neg(&b.points[i])
+			buckets[-digit-1].AddMixed(&neg)
+		}
+	}
+
+	// 2. \sum_k k * B_k is computed with a running sum from the largest bucket down
+	var runningSum curve.G1Jac
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.AddAssign(&buckets[k])
+		sum.AddAssign(&runningSum)
+	}
+}
+
+// Removes every pair from the builder, so that it can be reused
+func (b *MSMBuilder) Reset() {
+	b.points = b.points[:0]
+	b.digits = b.digits[:0]
+}
@@ -0,0 +1,115 @@
+package multiexp
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+func TestMSMBuilderMatchesMultiExp(t *testing.T) {
+	var base fr.Element
+	base.SetInt64(1234567)
+
+	// Include scalars near the modulus, which have the largest carries
+	var minusOne fr.Element
+	minusOne.SetInt64(-1)
+
+	for _, n := range []uint{1, 7, 64, 300} {
+		scalars := utils.ComputePowers(base, n)
+		scalars[0] = minusOne
+		points := genG1Points(n)
+
+		expected, err := MultiExp(scalars, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The hint only changes the window size, so any hint gives the same result
+		for _, sizeHint := range []int{0, int(n), 1 << 20} {
+			builder := NewMSMBuilder(sizeHint)
+			for i := range scalars {
+				builder.Add(scalars[i], points[i])
+			}
+			if builder.Len() != int(n) {
+				t.Fatalf("expected %d pairs, got %d", n, builder.Len())
+			}
+			got := builder.Finalize()
+			if !got.Equal(expected) {
+				t.Fatalf("MSM of size %d with size hint %d is incorrect", n, sizeHint)
+			}
+		}
+	}
+}
+
+func TestMSMBuilderEmpty(t *testing.T) {
+	got := NewMSMBuilder(16).Finalize()
+	var identity curve.G1Affine
+	if !got.Equal(&identity) {
+		t.Error("expected the identity point for an empty MSM")
+	}
+}
+
+func TestMSMBuilderAddManyAndReset(t *testing.T) {
+	var base fr.Element
+	base.SetInt64(42)
+	scalars := utils.ComputePowers(base, 32)
+	points := genG1Points(32)
+
+	expected, err := MultiExp(scalars, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := NewMSMBuilder(32)
+	if err := builder.AddMany(scalars[:1], points); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+
+	// Add the pairs in two pieces
+	if err := builder.AddMany(scalars[:10], points[:10]); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.AddMany(scalars[10:], points[10:]); err != nil {
+		t.Fatal(err)
+	}
+	if !builder.Finalize().Equal(expected) {
+		t.Error("MSM built in pieces is incorrect")
+	}
+
+	builder.Reset()
+	if builder.Len() != 0 {
+		t.Fatal("expected the builder to be empty after a reset")
+	}
+	if err := builder.AddMany(scalars[:5], points[:5]); err != nil {
+		t.Fatal(err)
+	}
+	expected, err = MultiExp(scalars[:5], points[:5])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !builder.Finalize().Equal(expected) {
+		t.Error("MSM after a reset is incorrect")
+	}
+}
+
+func BenchmarkMSMBuilder(b *testing.B) {
+	var base fr.Element
+	base.SetInt64(1234567)
+	scalars := utils.ComputePowers(base, 4096)
+	points := genG1Points(4096)
+
+	b.Run("builder", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			builder := NewMSMBuilder(len(points))
+			_ = builder.AddMany(scalars, points)
+			builder.Finalize()
+		}
+	})
+	b.Run("multiexp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = MultiExp(scalars, points)
+		}
+	})
+}
@@ -23,11 +23,55 @@ func MultiExp(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, e
 		return &result, nil
 	}
 
+	return multiExpG1(scalars, points)
+}
+
+// Same as MultiExp, except that gnark-crypto splits the work into at most `nbTasks` goroutines.
+// If nbTasks is zero, the number of CPUs is used.
+//
+// This always uses gnark-crypto, even if the library was built with another backend.
+func MultiExpWithTasks(scalars []fr.Element, points []curve.G1Affine, nbTasks int) (*curve.G1Affine, error) {
+	if len(scalars) != len(points) {
+		return nil, errors.New("number of scalars != number of points")
+	}
+
+	var result curve.G1Affine
+	if len(scalars) == 0 {
+		return &result, nil
+	}
+
+	config := ecc.MultiExpConfig{NbTasks: nbTasks, ScalarsMont: true}
+
+	return result.MultiExp(points, scalars, config)
+}
+
+// The implementation used for G1 MSMs.
+// This is gnark-crypto by default, builds with the `blst` tag replace it in an init function
+var multiExpG1 = gnarkMultiExpG1
+
+func gnarkMultiExpG1(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error) {
 	// We assume that all numbers are in montgomery form
 	// This does not hurt interoperability with field element implementations
 	// that use a different reduction strategy like Barret, because
 	// in the MultiExp function numbers are converted to their normal form
 	config := ecc.MultiExpConfig{ScalarsMont: true}
 
+	var result curve.G1Affine
+	return result.MultiExp(points, scalars, config)
+}
+
+// Same as MultiExp, but over G2 points
+func MultiExpG2(scalars []fr.Element, points []curve.G2Affine) (*curve.G2Affine, error) {
+	if len(scalars) != len(points) {
+		return nil, errors.New("number of scalars != number of points")
+	}
+
+	var result curve.G2Affine
+	if len(scalars) == 0 {
+		return &result, nil
+	}
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+
 	return result.MultiExp(points, scalars, config)
 }
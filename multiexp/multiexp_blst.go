@@ -0,0 +1,47 @@
+//go:build blst
+
+package multiexp
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Building with the `blst` tag routes G1 MSMs through supranational/blst.
+// This requires cgo.
+
+func init() {
+	multiExpG1 = blstMultiExpG1
+}
+
+func blstMultiExpG1(scalars []fr.Element, points []curve.G1Affine) (*curve.G1Affine, error) {
+	blstPoints := make(blst.P1Affines, len(points))
+	for i := 0; i < len(points); i++ {
+		serPoint := points[i].RawBytes()
+		if blstPoints[i].Deserialize(serPoint[:]) == nil {
+			return nil, errors.New("could not convert G1 point to blst")
+		}
+	}
+
+	// blst expects the scalars to be concatenated in little-endian
+	serScalars := make([]byte, 0, len(scalars)*fr.Bytes)
+	for i := 0; i < len(scalars); i++ {
+		serScalar := scalars[i].Bytes()
+		for j := len(serScalar) - 1; j >= 0; j-- {
+			serScalars = append(serScalars, serScalar[j])
+		}
+	}
+
+	blstResult := blstPoints.Mult(serScalars, fr.Bits)
+	serResult := blstResult.ToAffine().Serialize()
+
+	var result curve.G1Affine
+	_, err := result.SetBytes(serResult)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
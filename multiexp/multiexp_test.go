@@ -121,3 +121,56 @@ func genG1Points(n uint) []curve.G1Affine {
 	}
 	return points
 }
+
+func TestMultiExpG2Smoke(t *testing.T) {
+	var base fr.Element
+	base.SetInt64(7654321)
+
+	instance_size := uint(16)
+	powers := utils.ComputePowers(base, instance_size)
+
+	_, _, _, g2_gen := curve.Generators()
+	points := make([]curve.G2Affine, instance_size)
+	points[0] = g2_gen
+	for i := uint(1); i < instance_size; i++ {
+		points[i].Add(&points[i-1], &g2_gen)
+	}
+
+	got, err := MultiExpG2(powers, points)
+	if err != nil {
+		t.Fail()
+	}
+
+	var expected curve.G2Affine
+	for i := uint(0); i < instance_size; i++ {
+		var tmp curve.G2Affine
+		var bi big.Int
+		tmp.ScalarMultiplication(&points[i], powers[i].ToBigIntRegular(&bi))
+		expected.Add(&expected, &tmp)
+	}
+	if !got.Equal(&expected) {
+		t.Error("inconsistent G2 multi-exp result")
+	}
+}
+
+func TestMultiExpWithTasks(t *testing.T) {
+	var base fr.Element
+	base.SetInt64(1234567)
+
+	powers := utils.ComputePowers(base, 64)
+	points := genG1Points(64)
+
+	expected, err := MultiExp(powers, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, nbTasks := range []int{0, 1, 3} {
+		got, err := MultiExpWithTasks(powers, points, nbTasks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(expected) {
+			t.Errorf("incorrect result with %d tasks", nbTasks)
+		}
+	}
+}
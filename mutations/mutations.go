@@ -0,0 +1,226 @@
+// Package mutations produces systematically corrupted variants of valid proofs,
+// for use in negative test suites.
+//
+// Every variant returned must be rejected by the corresponding verification method.
+// The label of each variant describes the corruption, so that test failures
+// point to the check which is missing.
+package mutations
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+// Labels describing each corruption
+const (
+	LabelFlippedProofBit          = "flipped_proof_bit"
+	LabelFlippedCommitmentBit     = "flipped_commitment_bit"
+	LabelSwappedCommitmentProof   = "swapped_commitment_and_proof"
+	LabelProofAtInfinity          = "proof_at_infinity"
+	LabelProofWrongSubgroup       = "proof_not_in_subgroup"
+	LabelCommitmentWrongSubgroup  = "commitment_not_in_subgroup"
+	LabelModifiedBlobScalar       = "modified_blob_scalar"
+	LabelNonCanonicalBlobScalar   = "non_canonical_blob_scalar"
+	LabelClaimedValuePlusOne      = "claimed_value_plus_one"
+	LabelInputPointPlusOne        = "input_point_plus_one"
+	LabelNonCanonicalClaimedValue = "non_canonical_claimed_value"
+)
+
+var ErrEmptyBlob = errors.New("blob must contain at least one scalar")
+
+// A corrupted blob proof, to be checked with VerifyAggregateKzgProof
+type BlobProofCase struct {
+	Label      string
+	Blob       context.SerialisedPoly
+	Commitment context.KZGCommitment
+	Proof      context.KZGProof
+}
+
+// A corrupted proof that a polynomial evaluates to `ClaimedValue` at `InputPoint`,
+// to be checked with VerifyKZGProof
+type KZGProofCase struct {
+	Label        string
+	Commitment   context.KZGCommitment
+	Proof        context.KZGProof
+	InputPoint   [32]byte
+	ClaimedValue [32]byte
+}
+
+// Returns corrupted variants of a valid (blob, commitment, proof) triple.
+//
+// Every variant is a deep copy, so the inputs are not modified and the
+// variants can be passed to methods which deserialise in place
+func BlobProofVariants(blob context.SerialisedPoly, comm context.KZGCommitment, proof context.KZGProof) ([]BlobProofCase, error) {
+	if len(blob) == 0 {
+		return nil, ErrEmptyBlob
+	}
+
+	variant := func(label string) BlobProofCase {
+		return BlobProofCase{
+			Label:      label,
+			Blob:       copyPoly(blob),
+			Commitment: copyBytes(comm),
+			Proof:      copyBytes(proof),
+		}
+	}
+
+	var cases []BlobProofCase
+
+	c := variant(LabelFlippedProofBit)
+	flipLastBit(c.Proof)
+	cases = append(cases, c)
+
+	c = variant(LabelFlippedCommitmentBit)
+	flipLastBit(c.Commitment)
+	cases = append(cases, c)
+
+	c = variant(LabelSwappedCommitmentProof)
+	c.Commitment, c.Proof = c.Proof, c.Commitment
+	cases = append(cases, c)
+
+	c = variant(LabelProofAtInfinity)
+	c.Proof = pointAtInfinity()
+	cases = append(cases, c)
+
+	c = variant(LabelProofWrongSubgroup)
+	c.Proof = pointNotInSubgroup()
+	cases = append(cases, c)
+
+	c = variant(LabelCommitmentWrongSubgroup)
+	c.Commitment = pointNotInSubgroup()
+	cases = append(cases, c)
+
+	// Flipping the lowest bit keeps a canonical scalar canonical,
+	// unless it is the modulus minus one
+	c = variant(LabelModifiedBlobScalar)
+	c.Blob[0][0] ^= 1
+	cases = append(cases, c)
+
+	c = variant(LabelNonCanonicalBlobScalar)
+	c.Blob[0] = nonCanonicalScalar()
+	cases = append(cases, c)
+
+	return cases, nil
+}
+
+// Returns corrupted variants of a valid proof that the committed polynomial
+// evaluates to `claimedValue` at `inputPoint`. Scalars are in little-endian
+func KZGProofVariants(comm context.KZGCommitment, proof context.KZGProof, inputPoint, claimedValue [32]byte) ([]KZGProofCase, error) {
+	variant := func(label string) KZGProofCase {
+		return KZGProofCase{
+			Label:        label,
+			Commitment:   copyBytes(comm),
+			Proof:        copyBytes(proof),
+			InputPoint:   inputPoint,
+			ClaimedValue: claimedValue,
+		}
+	}
+
+	claimedValuePlusOne, err := plusOne(claimedValue)
+	if err != nil {
+		return nil, err
+	}
+	inputPointPlusOne, err := plusOne(inputPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []KZGProofCase
+
+	c := variant(LabelFlippedProofBit)
+	flipLastBit(c.Proof)
+	cases = append(cases, c)
+
+	c = variant(LabelSwappedCommitmentProof)
+	c.Commitment, c.Proof = c.Proof, c.Commitment
+	cases = append(cases, c)
+
+	c = variant(LabelProofWrongSubgroup)
+	c.Proof = pointNotInSubgroup()
+	cases = append(cases, c)
+
+	c = variant(LabelClaimedValuePlusOne)
+	c.ClaimedValue = claimedValuePlusOne
+	cases = append(cases, c)
+
+	c = variant(LabelInputPointPlusOne)
+	c.InputPoint = inputPointPlusOne
+	cases = append(cases, c)
+
+	c = variant(LabelNonCanonicalClaimedValue)
+	copy(c.ClaimedValue[:], nonCanonicalScalar())
+	cases = append(cases, c)
+
+	return cases, nil
+}
+
+// Adds one to a little-endian scalar, modulo the order of the scalar field
+func plusOne(serScalar [32]byte) ([32]byte, error) {
+	scalar, err := context.ScalarBytesLE(serScalar).Decode()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	one := fr.One()
+	scalar.Add(&scalar, &one)
+	return context.EncodeScalarLE(scalar), nil
+}
+
+// Returns the modulus of the scalar field in little-endian, which is
+// the smallest non-canonical scalar
+func nonCanonicalScalar() []byte {
+	modulus := fr.Modulus().Bytes()
+	serScalar := make([]byte, 32)
+	for i := 0; i < len(modulus); i++ {
+		serScalar[i] = modulus[len(modulus)-1-i]
+	}
+	return serScalar
+}
+
+func pointAtInfinity() []byte {
+	var infinity curve.G1Affine
+	serPoint := infinity.Bytes()
+	return serPoint[:]
+}
+
+// Returns a point which is on the curve, but not in the prime order subgroup
+func pointNotInSubgroup() []byte {
+	// y^2 = x^3 + 4
+	var x, rhs, four fp.Element
+	four.SetUint64(4)
+	for i := uint64(1); ; i++ {
+		x.SetUint64(i)
+		rhs.Square(&x).Mul(&rhs, &x).Add(&rhs, &four)
+
+		var y fp.Element
+		if y.Sqrt(&rhs) == nil {
+			continue
+		}
+		point := curve.G1Affine{X: x, Y: y}
+		if point.IsInSubGroup() {
+			continue
+		}
+		serPoint := point.Bytes()
+		return serPoint[:]
+	}
+}
+
+// Flips the lowest bit of the x coordinate of a compressed point
+func flipLastBit(serPoint []byte) {
+	serPoint[len(serPoint)-1] ^= 1
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
+
+func copyPoly(poly context.SerialisedPoly) context.SerialisedPoly {
+	polyCopy := make(context.SerialisedPoly, len(poly))
+	for i, serScalar := range poly {
+		polyCopy[i] = copyBytes(serScalar)
+	}
+	return polyCopy
+}
@@ -0,0 +1,64 @@
+package mutations
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+func testBlob(size int) context.SerialisedPoly {
+	blob := make(context.SerialisedPoly, size)
+	for i := range blob {
+		serScalar := context.EncodeScalarLE(fr.NewElement(uint64(i + 1)))
+		blob[i] = serScalar[:]
+	}
+	return blob
+}
+
+func TestBlobProofVariantsAreRejected(t *testing.T) {
+	ctx := context.NewContextInsecure(4, 1234)
+
+	blob := testBlob(4)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyAggregateKzgProof([]context.SerialisedPoly{copyPoly(blob)}, proof, context.SerialisedCommitments{comm}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := BlobProofVariants(blob, comm, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		err := ctx.VerifyAggregateKzgProof([]context.SerialisedPoly{c.Blob}, c.Proof, context.SerialisedCommitments{c.Commitment})
+		if err == nil {
+			t.Errorf("variant %s was accepted", c.Label)
+		}
+	}
+}
+
+func TestKZGProofVariantsAreRejected(t *testing.T) {
+	ctx := context.NewContextInsecure(4, 1234)
+
+	inputPoint := context.EncodeScalarLE(fr.NewElement(987))
+	proof, comm, claimedValue, err := ctx.ComputeKzgProof(testBlob(4), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyKZGProof(comm, proof, inputPoint, claimedValue); err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := KZGProofVariants(comm, proof, inputPoint, claimedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		if err := ctx.VerifyKZGProof(c.Commitment, c.Proof, c.InputPoint, c.ClaimedValue); err == nil {
+			t.Errorf("variant %s was accepted", c.Label)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package context
+
+import (
+	"bytes"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// The first stage at which an opening proof disagrees with the values recomputed from the blob
+type OpeningMismatch int
+
+const (
+	// Every value matches and the proof verifies
+	OpeningConsistent OpeningMismatch = iota
+	// The commitment is not the commitment to the blob
+	OpeningCommitmentMismatch
+	// The claimed value is not the evaluation of the blob at the input point
+	OpeningEvaluationMismatch
+	// The proof is not the commitment to the quotient
+	OpeningProofMismatch
+	// Every value matches, but the pairing check fails.
+	// This points to a difference in the setup
+	OpeningPairingFailure
+)
+
+func (m OpeningMismatch) String() string {
+	switch m {
+	case OpeningConsistent:
+		return "consistent"
+	case OpeningCommitmentMismatch:
+		return "commitment mismatch"
+	case OpeningEvaluationMismatch:
+		return "evaluation mismatch"
+	case OpeningProofMismatch:
+		return "proof mismatch"
+	case OpeningPairingFailure:
+		return "pairing failure"
+	default:
+		return "unknown"
+	}
+}
+
+// The result of recomputing an opening proof from its blob
+type OpeningDiagnosis struct {
+	// The first stage at which the inputs disagree with the recomputed values
+	Mismatch OpeningMismatch
+
+	// The values computed by this library, which can be compared against the inputs.
+	// Stages after the mismatch are still computed
+	ExpectedCommitment   KZGCommitment
+	ExpectedClaimedValue [32]byte
+	ExpectedProof        KZGProof
+}
+
+// Recomputes the commitment, evaluation and proof for a blob at an input point, and reports the
+// first stage at which they disagree with the given values. This is a diagnostic for finding
+// where another client's KZG proofs diverge; use VerifyKZGProof to verify proofs.
+//
+// An error is only returned if the blob or the input point can not be deserialised.
+// A malformed commitment or proof is reported as a mismatch.
+//
+// Note: the blob is modified in place during deserialisation.
+func (c *Context) DiagnoseKZGProof(serPoly SerialisedPoly, polynomialKZG KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes [32]byte) (_ OpeningDiagnosis, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the blob and the input point
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return OpeningDiagnosis{}, err
+	}
+	inputPoint, err := deserialiseScalar(append([]byte{}, inputPointBytes[:]...))
+	if err != nil {
+		return OpeningDiagnosis{}, err
+	}
+
+	// 2. Recompute every value independently of the inputs
+	comms, err := c.commitToPolynomials([]kzg.Polynomial{poly})
+	if err != nil {
+		return OpeningDiagnosis{}, err
+	}
+	var openingProof kzg.OpeningProof
+	c.withCPUBudget(func() {
+		openingProof, err = kzg.Open(c.domain, poly, inputPoint, c.commitKey)
+	})
+	if err != nil {
+		return OpeningDiagnosis{}, err
+	}
+
+	serComm := comms[0].Bytes()
+	serProof := openingProof.QuotientComm.Bytes()
+	diagnosis := OpeningDiagnosis{
+		ExpectedCommitment:   serComm[:],
+		ExpectedClaimedValue: openingProof.ClaimedValue.Bytes(),
+		ExpectedProof:        serProof[:],
+	}
+	utils.ReverseArray(&diagnosis.ExpectedClaimedValue)
+
+	// 3. Compare the inputs against the recomputed values, in the order that they are computed
+	switch {
+	case !bytes.Equal(polynomialKZG, diagnosis.ExpectedCommitment):
+		diagnosis.Mismatch = OpeningCommitmentMismatch
+	case claimedValueBytes != diagnosis.ExpectedClaimedValue:
+		diagnosis.Mismatch = OpeningEvaluationMismatch
+	case !bytes.Equal(kzgProof, diagnosis.ExpectedProof):
+		diagnosis.Mismatch = OpeningProofMismatch
+	default:
+		// 4. Every value matches, so the proof should verify
+		if c.verifyKZGProof(polynomialKZG, kzgProof, inputPointBytes, claimedValueBytes) != nil {
+			diagnosis.Mismatch = OpeningPairingFailure
+		}
+	}
+
+	if diagnosis.Mismatch != OpeningConsistent {
+		c.logger.Debug("opening proof is inconsistent", "mismatch", diagnosis.Mismatch.String())
+	}
+	return diagnosis, nil
+}
@@ -0,0 +1,72 @@
+package context
+
+import (
+	"testing"
+)
+
+func TestDiagnoseKZGProof(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	serPoly := testSerialisedPoly(8, 1)
+	var inputPoint [32]byte
+	inputPoint[0] = 123
+
+	proof, comm, claimedValue, err := ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only change one scalar, since adding a constant to every scalar does not change the proof
+	otherPoly := copyPoly(serPoly)
+	otherPoly[2] = otherPoly[5]
+	otherProof, otherComm, otherClaimedValue, err := ctx.ComputeKzgProof(otherPoly, inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		comm         KZGCommitment
+		proof        KZGProof
+		claimedValue [32]byte
+		expected     OpeningMismatch
+	}{
+		{comm, proof, claimedValue, OpeningConsistent},
+		{otherComm, proof, claimedValue, OpeningCommitmentMismatch},
+		{comm, proof, otherClaimedValue, OpeningEvaluationMismatch},
+		{comm, otherProof, claimedValue, OpeningProofMismatch},
+	}
+	for i, test := range tests {
+		diagnosis, err := ctx.DiagnoseKZGProof(copyPoly(serPoly), test.comm, test.proof, inputPoint, test.claimedValue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diagnosis.Mismatch != test.expected {
+			t.Errorf("test %d: expected %s, got %s", i, test.expected, diagnosis.Mismatch)
+		}
+		if diagnosis.ExpectedClaimedValue != claimedValue {
+			t.Errorf("test %d: expected claimed value was not recomputed", i)
+		}
+	}
+}
+
+func TestDiagnoseKZGProofPairingFailure(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	otherCtx := NewContextInsecure(8, 5678)
+	serPoly := testSerialisedPoly(8, 1)
+	var inputPoint [32]byte
+	inputPoint[0] = 123
+
+	proof, comm, claimedValue, err := ctx.ComputeKzgProof(copyPoly(serPoly), inputPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Swap the opening key, so that every recomputed value matches but the pairing does not
+	otherCtx.commitKey = ctx.commitKey
+	diagnosis, err := otherCtx.DiagnoseKZGProof(copyPoly(serPoly), comm, proof, inputPoint, claimedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diagnosis.Mismatch != OpeningPairingFailure {
+		t.Errorf("expected %s, got %s", OpeningPairingFailure, diagnosis.Mismatch)
+	}
+}
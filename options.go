@@ -0,0 +1,26 @@
+package context
+
+// Options which can be passed when creating a Context
+type ContextOption func(*Context)
+
+// Minimal logging interface, so that callers can plug in their logger of choice.
+//
+// keyvals are alternating keys and values, as in most structured loggers
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(msg string, keyvals ...interface{}) {}
+
+// Sets the logger that the Context will emit debug messages to.
+// By default, nothing is logged
+func WithLogger(logger Logger) ContextOption {
+	return func(c *Context) {
+		if logger == nil {
+			logger = noopLogger{}
+		}
+		c.logger = logger
+	}
+}
@@ -0,0 +1,29 @@
+package context
+
+import "testing"
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) {
+	l.messages = append(l.messages, msg)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	ctx := NewContextInsecure(4, 1234, WithLogger(logger))
+
+	if len(logger.messages) == 0 {
+		t.Fatal("expected context creation to be logged")
+	}
+
+	numMessages := len(logger.messages)
+	_, _, err := ctx.ComputeAggregateKzgProof([]SerialisedPoly{testSerialisedPoly(4, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.messages) == numMessages {
+		t.Error("expected aggregate proof computation to be logged")
+	}
+}
@@ -0,0 +1,51 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInternalPanic = errors.New("internal panic")
+
+// Returned by the exported methods on the Context when the library panics internally.
+//
+// A panic indicates a bug in the library, or an input which was not validated;
+// either way, it should not be possible for an adversarial input to crash the caller.
+type PanicError struct {
+	// The value that was passed to panic
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrInternalPanic, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrInternalPanic
+}
+
+// Makes the Context re-panic instead of returning a PanicError.
+// This is useful when debugging, since the stack trace is preserved
+func WithDebugPanics() ContextOption {
+	return func(c *Context) {
+		c.debugPanics = true
+	}
+}
+
+// Converts a panic into a PanicError.
+//
+// This must be deferred directly at the top of each exported method:
+//
+//	defer c.recoverPanic(&err)
+func (c *Context) recoverPanic(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if c.debugPanics {
+		panic(r)
+	}
+
+	c.logger.Debug("recovered from internal panic", "panic", r)
+	*err = &PanicError{Value: r}
+}
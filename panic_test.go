@@ -0,0 +1,38 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	err := func() (err error) {
+		defer ctx.recoverPanic(&err)
+		panic("index out of range")
+	}()
+
+	if !errors.Is(err, ErrInternalPanic) {
+		t.Fatalf("expected an internal panic error, got %v", err)
+	}
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "index out of range" {
+		t.Error("expected the panic value to be preserved")
+	}
+}
+
+func TestDebugPanics(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithDebugPanics())
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected the panic to be propagated in debug mode")
+		}
+	}()
+
+	_ = func() (err error) {
+		defer ctx.recoverPanic(&err)
+		panic("index out of range")
+	}()
+}
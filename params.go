@@ -0,0 +1,76 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var ErrInvalidParams = errors.New("invalid protocol parameters")
+
+// Protocol parameters which determine the size of blobs and cells.
+//
+// A Context is created with a single set of parameters, so that a change to the protocol
+// only requires a change here, rather than to constants spread throughout the package.
+type Params struct {
+	// Number of scalars in each blob
+	FieldElementsPerBlob uint64
+	// Number of bytes in each serialised scalar, this is fixed by the curve
+	BytesPerFieldElement uint64
+	// Ratio between the size of the extended domain and the size of the blob
+	ExpansionFactor uint64
+	// Number of evaluations in each cell
+	FieldElementsPerCell uint64
+}
+
+// Parameters for mainnet, as defined by EIP-4844 and EIP-7594
+var MainnetParams = DefaultParams(4096)
+
+// Returns the parameters with the mainnet cell layout, for blobs of the given size
+func DefaultParams(fieldElementsPerBlob uint64) Params {
+	return Params{
+		FieldElementsPerBlob: fieldElementsPerBlob,
+		BytesPerFieldElement: serialisedScalarSize,
+		ExpansionFactor:      ExpansionFactor,
+		FieldElementsPerCell: FieldElementsPerCell,
+	}
+}
+
+// Number of bytes in a serialised blob
+func (p Params) BytesPerBlob() uint64 {
+	return p.FieldElementsPerBlob * p.BytesPerFieldElement
+}
+
+// Number of evaluations in the extended domain
+func (p Params) FieldElementsPerExtBlob() uint64 {
+	return p.ExpansionFactor * p.FieldElementsPerBlob
+}
+
+// Number of cells that the extended evaluations of a blob are split into,
+// this is zero if cells are larger than the extended domain
+func (p Params) CellsPerExtBlob() uint64 {
+	return p.FieldElementsPerExtBlob() / p.FieldElementsPerCell
+}
+
+// Checks that the parameters can be used to create a Context
+func (p Params) Validate() error {
+	if p.FieldElementsPerBlob < 2 || !utils.IsPowerOfTwo(p.FieldElementsPerBlob) {
+		return fmt.Errorf("%w: FieldElementsPerBlob must be a power of two and at least 2", ErrInvalidParams)
+	}
+	if p.BytesPerFieldElement != serialisedScalarSize {
+		return fmt.Errorf("%w: BytesPerFieldElement must be %d", ErrInvalidParams, serialisedScalarSize)
+	}
+	if !utils.IsPowerOfTwo(p.ExpansionFactor) {
+		return fmt.Errorf("%w: ExpansionFactor must be a power of two", ErrInvalidParams)
+	}
+	if !utils.IsPowerOfTwo(p.FieldElementsPerCell) {
+		return fmt.Errorf("%w: FieldElementsPerCell must be a power of two", ErrInvalidParams)
+	}
+	return nil
+}
+
+// Returns the parameters that the Context was created with
+func (c *Context) Params() Params {
+	return c.params
+}
@@ -0,0 +1,57 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParamsValidate(t *testing.T) {
+	if err := MainnetParams.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if MainnetParams.CellsPerExtBlob() != 128 || MainnetParams.BytesPerBlob() != 131072 {
+		t.Error("mainnet parameters do not match EIP-4844 and EIP-7594")
+	}
+
+	invalid := []Params{
+		{FieldElementsPerBlob: 3, BytesPerFieldElement: 32, ExpansionFactor: 2, FieldElementsPerCell: 64},
+		{FieldElementsPerBlob: 4, BytesPerFieldElement: 48, ExpansionFactor: 2, FieldElementsPerCell: 64},
+		{FieldElementsPerBlob: 4, BytesPerFieldElement: 32, ExpansionFactor: 3, FieldElementsPerCell: 64},
+		{FieldElementsPerBlob: 4, BytesPerFieldElement: 32, ExpansionFactor: 2, FieldElementsPerCell: 0},
+	}
+	for i, params := range invalid {
+		if err := params.Validate(); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("parameters %d should be invalid", i)
+		}
+		if _, err := NewContextInsecureWithParams(params, 1234); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("context should not be created with parameters %d", i)
+		}
+	}
+}
+
+func TestContextWithCustomCellParams(t *testing.T) {
+	params := Params{
+		FieldElementsPerBlob: 16,
+		BytesPerFieldElement: 32,
+		ExpansionFactor:      4,
+		FieldElementsPerCell: 8,
+	}
+	ctx, err := NewContextInsecureWithParams(params, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Params() != params {
+		t.Error("context does not report the parameters it was created with")
+	}
+	if ctx.CellsPerExtBlob() != 8 {
+		t.Fatalf("expected 8 cells, got %d", ctx.CellsPerExtBlob())
+	}
+
+	serPoly := testSerialisedPoly(16, 7)
+	comm, cells, proofs := testCellsAndProofs(t, ctx, copyPoly(serPoly))
+	for i := range cells {
+		if err := ctx.VerifyCellKZGProof(comm, uint64(i), copyPoly(cells[i]), proofs[i]); err != nil {
+			t.Fatalf("cell %d: %v", i, err)
+		}
+	}
+}
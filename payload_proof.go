@@ -0,0 +1,98 @@
+package context
+
+import (
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// A single proof for a payload which spans multiple blobs.
+//
+// It attests that the prover knows the blobs behind all of the commitments,
+// and is verified using only the commitments.
+type PayloadProof struct {
+	Proof KZGProof
+	// Evaluation of the folded blobs at the challenge point, in little-endian
+	ClaimedValue [32]byte
+}
+
+// Computes a single proof for all of the blobs of a payload, such as those returned by ChunkAndCommit.
+// The commitments are not checked against the blobs, if they are incorrect then the proof will not verify.
+//
+// Note: the blobs are modified in place during deserialisation.
+func (c *Context) ComputePayloadProof(serPolys []SerialisedPoly, serComms SerialisedCommitments) (_ PayloadProof, err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkPolysPerCall(len(serPolys)); err != nil {
+		return PayloadProof{}, err
+	}
+
+	// 1. Deserialise the blobs and their commitments
+	polys, err := deserialisePolys(serPolys)
+	if err != nil {
+		return PayloadProof{}, err
+	}
+	comms, err := deserialiseComms(serComms)
+	if err != nil {
+		return PayloadProof{}, err
+	}
+
+	c.logger.Debug("computing payload proof", "numPolys", len(polys))
+
+	// 2. Open the folded blobs
+	var proof *agg_kzg.PayloadOpeningProof
+	c.withCPUBudget(func() {
+		proof, err = c.protocol.OpenPayload(c.domain, polys, comms, c.commitKey)
+	})
+	if err != nil {
+		return PayloadProof{}, err
+	}
+
+	// 3. Serialise the proof
+	serProof := proof.QuotientComm.Bytes()
+	claimedValue := proof.ClaimedValue.Bytes()
+	utils.ReverseArray(&claimedValue)
+
+	return PayloadProof{
+		Proof:        serProof[:],
+		ClaimedValue: claimedValue,
+	}, nil
+}
+
+// Verifies a proof computed by ComputePayloadProof, using only the commitments to the blobs
+func (c *Context) VerifyPayloadProof(serComms SerialisedCommitments, proof PayloadProof) (err error) {
+	defer c.recoverPanic(&err)
+
+	if err := c.checkBatchSize(len(serComms)); err != nil {
+		return err
+	}
+
+	// 1. Deserialise the commitments
+	comms, _, err := c.deserialiseCommitments(serComms)
+	if err != nil {
+		return err
+	}
+
+	// 2. Deserialise the proof
+	quotientComm, err := deserialisePoint(proof.Proof)
+	if err != nil {
+		return err
+	}
+	claimedValue, err := deserialiseScalar(append([]byte{}, proof.ClaimedValue[:]...))
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug("verifying payload proof", "numComms", len(comms))
+
+	payloadProof := &agg_kzg.PayloadOpeningProof{
+		QuotientComm: quotientComm,
+		ClaimedValue: claimedValue,
+	}
+	c.withCPUBudget(func() {
+		err = c.protocol.VerifyPayloadOpening(comms, payloadProof, c.openKey)
+	})
+	if err != nil {
+		c.logger.Debug("payload proof verification failed", "err", err)
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package context
+
+import "testing"
+
+func TestPayloadProof(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	data := make([]byte, 2*ctx.DataBytesPerBlob())
+	for i := range data {
+		data[i] = byte(i)
+	}
+	payload, err := ctx.ChunkAndCommit(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof, err := ctx.ComputePayloadProof(copyPolys(payload.Blobs), payload.Commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyPayloadProof(payload.Commitments, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// A proof for different blobs should not verify against the commitments
+	otherBlobs := copyPolys(payload.Blobs)
+	otherBlobs[0][0][0] ^= 1
+	otherProof, err := ctx.ComputePayloadProof(otherBlobs, payload.Commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyPayloadProof(payload.Commitments, otherProof); err == nil {
+		t.Error("proof for different blobs should not verify")
+	}
+}
@@ -0,0 +1,66 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Consensus spec presets, which fix the number of field elements in each blob
+type Preset int
+
+const (
+	PresetMainnet Preset = iota
+	// The minimal preset is used by the consensus spec tests, and uses tiny blobs
+	PresetMinimal
+)
+
+var ErrUnknownPreset = errors.New("unknown preset")
+
+func (p Preset) String() string {
+	switch p {
+	case PresetMainnet:
+		return "mainnet"
+	case PresetMinimal:
+		return "minimal"
+	default:
+		return "unknown"
+	}
+}
+
+// Returns FIELD_ELEMENTS_PER_BLOB for the preset, or zero if the preset is unknown
+func (p Preset) FieldElementsPerBlob() int {
+	switch p {
+	case PresetMainnet:
+		return 4096
+	case PresetMinimal:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Returns the protocol parameters for the preset, with the mainnet cell layout
+func (p Preset) Params() Params {
+	return DefaultParams(uint64(p.FieldElementsPerBlob()))
+}
+
+// Same as NewContextInsecure, except that the polynomial size comes from the preset.
+// DO NOT USE THIS METHOD IN PRODUCTION
+func NewContextInsecureForPreset(preset Preset, trustedSetupSecret int, opts ...ContextOption) (*Context, error) {
+	polyDegree := preset.FieldElementsPerBlob()
+	if polyDegree == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPreset, preset)
+	}
+	return NewContextInsecureWithParams(preset.Params(), trustedSetupSecret, opts...)
+}
+
+// Returns the preset whose blob size matches the Context.
+// ok is false if the Context does not match any preset
+func (c *Context) Preset() (preset Preset, ok bool) {
+	for _, preset := range []Preset{PresetMainnet, PresetMinimal} {
+		if uint64(preset.FieldElementsPerBlob()) == c.domain.Cardinality {
+			return preset, true
+		}
+	}
+	return 0, false
+}
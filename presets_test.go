@@ -0,0 +1,41 @@
+package context
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMinimalPreset(t *testing.T) {
+	ctx, err := NewContextInsecureForPreset(PresetMinimal, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Features().PolyDegree != 4 {
+		t.Errorf("unexpected polynomial size %d", ctx.Features().PolyDegree)
+	}
+	preset, ok := ctx.Preset()
+	if !ok || preset != PresetMinimal {
+		t.Error("context should match the minimal preset")
+	}
+
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1)}
+	proof, comms, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyAggregateKzgProof(copyPolys(polys), proof, comms); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnknownPreset(t *testing.T) {
+	_, err := NewContextInsecureForPreset(Preset(99), 1234)
+	if !errors.Is(err, ErrUnknownPreset) {
+		t.Error("expected an error for an unknown preset")
+	}
+
+	ctx := NewContextInsecure(8, 1234)
+	if _, ok := ctx.Preset(); ok {
+		t.Error("context should not match any preset")
+	}
+}
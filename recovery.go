@@ -0,0 +1,198 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+var (
+	ErrRecoveryLengthMismatch = errors.New("number of cell indices and cells must be the same")
+	ErrDuplicateCellIndex     = errors.New("cell indices must be unique")
+	ErrNotEnoughCells         = errors.New("at least half of the cells are needed for recovery")
+)
+
+// Spec: recover_cells_and_kzg_proofs
+//
+// Reconstructs every cell of a blob's extension from at least half of them, and recomputes the proofs.
+// The i'th cell is at index cellIndices[i], the indices do not need to be sorted but must be unique.
+// The cells are erasure decoded into the polynomial, from which all of the cells and proofs are then computed,
+// so the cells which were passed in are also returned.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverCellsAndKZGProofs(cellIndices []uint64, cells []SerialisedCell) (_ []SerialisedCell, _ []KZGProof, err error) {
+	defer c.recoverPanic(&err)
+	return c.recoverCellsAndKZGProofs(cellIndices, cells, nil)
+}
+
+// Same as RecoverCellsAndKZGProofs, and also returns a report of the work done.
+// The report is nil if the cells fail their checks, it is returned whether or not the recovery succeeds.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverCellsAndKZGProofsWithReport(cellIndices []uint64, cells []SerialisedCell) (_ []SerialisedCell, _ []KZGProof, _ *RecoveryReport, err error) {
+	defer c.recoverPanic(&err)
+
+	report := newRecoveryReport()
+	recoveredCells, proofs, err := c.recoverCellsAndKZGProofs(cellIndices, cells, report)
+	return recoveredCells, proofs, report.ifDeserialised(), err
+}
+
+// The report is filled in if it is not nil
+func (c *Context) recoverCellsAndKZGProofs(cellIndices []uint64, cells []SerialisedCell, report *RecoveryReport) ([]SerialisedCell, []KZGProof, error) {
+	if c.cellDomain == nil {
+		return nil, nil, ErrCellsNotSupported
+	}
+	if len(cellIndices) != len(cells) {
+		return nil, nil, ErrRecoveryLengthMismatch
+	}
+	if err := c.checkCellsPerReconstruction(len(cells)); err != nil {
+		return nil, nil, err
+	}
+
+	// 1. Check the cells, and find the cells which are present
+	numCells := c.CellsPerExtBlob()
+	present := make([]bool, numCells)
+	for i, cellIndex := range cellIndices {
+		if err := c.checkCell(cellIndex, cells[i]); err != nil {
+			return nil, nil, err
+		}
+		if present[cellIndex] {
+			return nil, nil, ErrDuplicateCellIndex
+		}
+		present[cellIndex] = true
+	}
+	if uint64(len(cells))*c.params.ExpansionFactor < numCells {
+		return nil, nil, ErrNotEnoughCells
+	}
+
+	// 2. Place the cells into the extended evaluations, which are in bit-reversed order.
+	// The missing evaluations are left as zero
+	extendedEvals := make([]fr.Element, c.extendedDomain.Cardinality)
+	for i, cellIndex := range cellIndices {
+		if err := c.placeCell(extendedEvals, cellIndex, cells[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return c.recoverFromExtendedEvals(present, extendedEvals, report)
+}
+
+// A cell together with its index in the extension of the blob
+type IndexedCell struct {
+	Index uint64
+	Cell  SerialisedCell
+}
+
+// Same as RecoverCellsAndKZGProofs, except that the cells are pulled from `next` as they arrive,
+// for example from peers. `next` may block until a cell is available, and returns false once there
+// are no more cells.
+//
+// Each cell is deserialised as soon as it is received, and `next` is no longer called once
+// enough cells have been received. Cells whose index has already been received are skipped,
+// since the same cell may arrive from more than one peer. They still count towards
+// MaxCellsPerReconstruction, which limits the number of times that `next` is called.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverCellsAndKZGProofsFromStream(next func() (IndexedCell, bool)) (_ []SerialisedCell, _ []KZGProof, err error) {
+	defer c.recoverPanic(&err)
+	return c.recoverCellsAndKZGProofsFromStream(next, nil)
+}
+
+// Same as RecoverCellsAndKZGProofsFromStream, and also returns a report of the work done.
+// The deserialisation time includes the time spent waiting for cells to arrive.
+//
+// Note: the cells are modified in place during deserialisation.
+func (c *Context) RecoverCellsAndKZGProofsFromStreamWithReport(next func() (IndexedCell, bool)) (_ []SerialisedCell, _ []KZGProof, _ *RecoveryReport, err error) {
+	defer c.recoverPanic(&err)
+
+	report := newRecoveryReport()
+	recoveredCells, proofs, err := c.recoverCellsAndKZGProofsFromStream(next, report)
+	return recoveredCells, proofs, report.ifDeserialised(), err
+}
+
+// The report is filled in if it is not nil
+func (c *Context) recoverCellsAndKZGProofsFromStream(next func() (IndexedCell, bool), report *RecoveryReport) ([]SerialisedCell, []KZGProof, error) {
+	if c.cellDomain == nil {
+		return nil, nil, ErrCellsNotSupported
+	}
+
+	// 1. Receive cells until half of them are present
+	numCells := c.CellsPerExtBlob()
+	numNeeded := numCells / c.params.ExpansionFactor
+	present := make([]bool, numCells)
+	extendedEvals := make([]fr.Element, c.extendedDomain.Cardinality)
+	numStreamed := 0
+	for numReceived := uint64(0); numReceived < numNeeded; {
+		indexedCell, ok := next()
+		if !ok {
+			return nil, nil, ErrNotEnoughCells
+		}
+		// Duplicates count towards the limit, as they do when the cells are given as a slice
+		numStreamed++
+		if err := c.checkCellsPerReconstruction(numStreamed); err != nil {
+			return nil, nil, err
+		}
+		if err := c.checkCell(indexedCell.Index, indexedCell.Cell); err != nil {
+			return nil, nil, err
+		}
+		if present[indexedCell.Index] {
+			continue
+		}
+
+		// 2. Place the cell into the extended evaluations
+		if err := c.placeCell(extendedEvals, indexedCell.Index, indexedCell.Cell); err != nil {
+			return nil, nil, err
+		}
+		present[indexedCell.Index] = true
+		numReceived++
+	}
+
+	return c.recoverFromExtendedEvals(present, extendedEvals, report)
+}
+
+// Deserialises the cell into its position in the bit-reversed extended evaluations
+func (c *Context) placeCell(extendedEvals []fr.Element, cellIndex uint64, cell SerialisedCell) error {
+	evaluations, err := deserialisePoly(cell)
+	if err != nil {
+		return err
+	}
+	copy(extendedEvals[cellIndex*c.params.FieldElementsPerCell:], evaluations)
+	return nil
+}
+
+// Erasure decodes the polynomial from the extended evaluations of the cells which are present,
+// then recomputes every cell and proof. The extended evaluations are in bit-reversed order,
+// with zeros for the cells which are missing
+func (c *Context) recoverFromExtendedEvals(present []bool, extendedEvals []fr.Element, report *RecoveryReport) ([]SerialisedCell, []KZGProof, error) {
+	var missingIndices []uint64
+	var missingShifts []fr.Element
+	for cellIndex := range present {
+		if !present[cellIndex] {
+			missingIndices = append(missingIndices, uint64(cellIndex))
+			missingShifts = append(missingShifts, c.cosetShiftForCell(uint64(cellIndex)))
+		}
+	}
+	numProvided := len(present) - len(missingIndices)
+
+	c.extendedDomain.BitReverse(extendedEvals)
+	report.endDeserialisation(numProvided, missingIndices, c.extendedDomain.Cardinality)
+
+	c.logger.Debug("recovering cells", "numCells", numProvided, "numMissing", len(missingShifts))
+
+	// 3. Erasure decode the polynomial
+	var coeffs []fr.Element
+	var err error
+	c.withCPUBudget(func() {
+		coeffs, err = kzg.RecoverPolynomialCoeffs(c.extendedDomain, extendedEvals, c.params.FieldElementsPerCell, missingShifts)
+	})
+	report.endDecoding()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Recompute every cell and proof
+	recoveredCells, proofs, err := c.computeCellsAndProofsFromCoeffs(coeffs[:c.domain.Cardinality])
+	report.endProofs()
+	return recoveredCells, proofs, err
+}
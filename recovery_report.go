@@ -0,0 +1,74 @@
+package context
+
+import "time"
+
+// Describes the work done by a single recovery, it is returned by the WithReport variants of
+// the recovery methods. The methods without a report do not allocate one or read the clock for it.
+//
+// This is intended for operators monitoring reconstruction, for example to see how many
+// cells are usually missing or whether time is spent decoding or recomputing proofs.
+type RecoveryReport struct {
+	// Number of cells that were passed in
+	CellsProvided int
+	// Indices of the cells which were missing, in ascending order
+	MissingCellIndices []uint64
+	// Size of the FFTs used to erasure decode the polynomial, this is the number of extended evaluations
+	DecodingFFTSize uint64
+	// Time spent checking and deserialising the cells
+	DeserialisationTime time.Duration
+	// Time spent erasure decoding the polynomial
+	DecodingTime time.Duration
+	// Time spent recomputing the cells and their proofs
+	ProofTime time.Duration
+
+	// Start of the phase that is currently being timed
+	phaseStart time.Time
+	// Whether the cells were deserialised, rather than failing their checks
+	deserialised bool
+}
+
+// Starts timing the deserialisation of the cells
+func newRecoveryReport() *RecoveryReport {
+	return &RecoveryReport{phaseStart: time.Now()}
+}
+
+// Ends the current phase, adding its duration to `phase`
+func (r *RecoveryReport) endPhase(phase *time.Duration) {
+	now := time.Now()
+	*phase += now.Sub(r.phaseStart)
+	r.phaseStart = now
+}
+
+func (r *RecoveryReport) endDeserialisation(cellsProvided int, missingCellIndices []uint64, decodingFFTSize uint64) {
+	if r == nil {
+		return
+	}
+	r.endPhase(&r.DeserialisationTime)
+	r.deserialised = true
+	r.CellsProvided = cellsProvided
+	r.MissingCellIndices = missingCellIndices
+	r.DecodingFFTSize = decodingFFTSize
+}
+
+func (r *RecoveryReport) endDecoding() {
+	if r == nil {
+		return
+	}
+	r.endPhase(&r.DecodingTime)
+}
+
+func (r *RecoveryReport) endProofs() {
+	if r == nil {
+		return
+	}
+	r.endPhase(&r.ProofTime)
+}
+
+// Returns the report if the cells were deserialised, so that reports are not returned for cells
+// which fail their checks
+func (r *RecoveryReport) ifDeserialised() *RecoveryReport {
+	if !r.deserialised {
+		return nil
+	}
+	return r
+}
@@ -0,0 +1,67 @@
+package context
+
+import "testing"
+
+func TestRecoveryReport(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	cells, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, report, err := ctx.RecoverCellsAndKZGProofsWithReport([]uint64{3, 0}, []SerialisedCell{copyPoly(cells[3]), copyPoly(cells[0])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	if report.CellsProvided != 2 {
+		t.Errorf("expected 2 cells to be provided, got %d", report.CellsProvided)
+	}
+	if len(report.MissingCellIndices) != 2 || report.MissingCellIndices[0] != 1 || report.MissingCellIndices[1] != 2 {
+		t.Errorf("unexpected missing cells %v", report.MissingCellIndices)
+	}
+	if report.DecodingFFTSize != 256 {
+		t.Errorf("expected an FFT size of 256, got %d", report.DecodingFFTSize)
+	}
+	if report.DecodingTime <= 0 || report.ProofTime <= 0 {
+		t.Error("expected the phases to be timed")
+	}
+
+	// No report is returned for cells which fail their checks
+	_, _, report, err = ctx.RecoverCellsAndKZGProofsWithReport([]uint64{0}, []SerialisedCell{copyPoly(cells[0])})
+	if err != ErrNotEnoughCells {
+		t.Fatal("expected too few cells")
+	}
+	if report != nil {
+		t.Error("expected no report for a recovery which fails its checks")
+	}
+}
+
+func TestRecoveryReportFromStream(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+
+	cells, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := []IndexedCell{{Index: 2, Cell: copyPoly(cells[2])}, {Index: 1, Cell: copyPoly(cells[1])}}
+	next := func() (IndexedCell, bool) {
+		if len(stream) == 0 {
+			return IndexedCell{}, false
+		}
+		indexedCell := stream[0]
+		stream = stream[1:]
+		return indexedCell, true
+	}
+	_, _, report, err := ctx.RecoverCellsAndKZGProofsFromStreamWithReport(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report == nil || report.CellsProvided != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
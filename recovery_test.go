@@ -0,0 +1,144 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRecoverCellsAndKZGProofs(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+	if !ctx.SupportsFork(ForkPeerDAS) {
+		t.Fatal("expected peerdas to be supported")
+	}
+
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Recover from exactly half of the cells, in an unsorted order
+	cellIndices := []uint64{3, 1}
+	recovered, recoveredProofs, err := ctx.RecoverCellsAndKZGProofs(cellIndices, []SerialisedCell{copyPoly(cells[3]), copyPoly(cells[1])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != len(cells) || len(recoveredProofs) != len(proofs) {
+		t.Fatalf("expected %d cells and proofs", len(cells))
+	}
+	for i := range cells {
+		for j := range cells[i] {
+			if !bytes.Equal(recovered[i][j], cells[i][j]) {
+				t.Fatalf("cell %d was not recovered", i)
+			}
+		}
+		if !bytes.Equal(recoveredProofs[i], proofs[i]) {
+			t.Fatalf("proof for cell %d was not recovered", i)
+		}
+	}
+
+	// Recover from all of the cells
+	allIndices := []uint64{0, 1, 2, 3}
+	recovered, _, err = ctx.RecoverCellsAndKZGProofs(allIndices, copyPolys(cells))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(recovered[2][5], cells[2][5]) {
+		t.Error("cells were not recovered")
+	}
+}
+
+func TestRecoverCellsAndKZGProofsInvalid(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+	cells, _, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ctx.RecoverCellsAndKZGProofs([]uint64{0}, []SerialisedCell{copyPoly(cells[0])}); err != ErrNotEnoughCells {
+		t.Error("expected an error for too few cells")
+	}
+	if _, _, err := ctx.RecoverCellsAndKZGProofs([]uint64{0, 0}, copyPolys(cells[:2])); err != ErrDuplicateCellIndex {
+		t.Error("expected an error for duplicate cell indices")
+	}
+	if _, _, err := ctx.RecoverCellsAndKZGProofs([]uint64{0}, copyPolys(cells[:2])); err != ErrRecoveryLengthMismatch {
+		t.Error("expected a length mismatch")
+	}
+	if _, _, err := ctx.RecoverCellsAndKZGProofs([]uint64{0, 4}, copyPolys(cells[:2])); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+
+	limited := NewContextInsecure(128, 1234, WithLimits(Limits{MaxCellsPerReconstruction: 2}))
+	if _, _, err := limited.RecoverCellsAndKZGProofs([]uint64{0, 1, 2}, copyPolys(cells[:3])); !errors.Is(err, ErrLimitExceeded) {
+		t.Error("expected the reconstruction limit to be enforced")
+	}
+
+	small := NewContextInsecure(4, 1234)
+	if _, _, err := small.RecoverCellsAndKZGProofs(nil, nil); err != ErrCellsNotSupported {
+		t.Error("expected cells to not be supported")
+	}
+	if small.SupportsFork(ForkPeerDAS) {
+		t.Error("peerdas should not be supported when cells are not")
+	}
+}
+
+func TestRecoverCellsAndKZGProofsFromStream(t *testing.T) {
+	ctx := NewContextInsecure(128, 1234)
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofs(testSerialisedPoly(128, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cell 2 arrives twice, and the stream stops being read once two distinct cells have arrived
+	stream := []uint64{2, 2, 0, 1, 3}
+	numCalls := 0
+	next := func() (IndexedCell, bool) {
+		if numCalls == len(stream) {
+			return IndexedCell{}, false
+		}
+		cellIndex := stream[numCalls]
+		numCalls++
+		return IndexedCell{Index: cellIndex, Cell: copyPoly(cells[cellIndex])}, true
+	}
+
+	recovered, recoveredProofs, err := ctx.RecoverCellsAndKZGProofsFromStream(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numCalls != 3 {
+		t.Errorf("expected the stream to stop after 3 cells, it was read %d times", numCalls)
+	}
+	for i := range cells {
+		if !bytes.Equal(recovered[i][7], cells[i][7]) || !bytes.Equal(recoveredProofs[i], proofs[i]) {
+			t.Fatalf("cell %d was not recovered", i)
+		}
+	}
+
+	// The stream ends before enough cells have arrived
+	numCalls = 0
+	stream = []uint64{1, 1}
+	if _, _, err := ctx.RecoverCellsAndKZGProofsFromStream(next); err != ErrNotEnoughCells {
+		t.Error("expected an error when the stream ends early")
+	}
+
+	next = func() (IndexedCell, bool) {
+		return IndexedCell{Index: ctx.CellsPerExtBlob(), Cell: copyPoly(cells[0])}, true
+	}
+	if _, _, err := ctx.RecoverCellsAndKZGProofsFromStream(next); err != ErrInvalidCellIndex {
+		t.Error("expected an error for an out of range cell index")
+	}
+
+	// Duplicates count towards the reconstruction limit, so a stream of them cannot run forever
+	limited := NewContextInsecure(128, 1234, WithLimits(Limits{MaxCellsPerReconstruction: 2}))
+	numCalls = 0
+	next = func() (IndexedCell, bool) {
+		numCalls++
+		return IndexedCell{Index: 0, Cell: copyPoly(cells[0])}, true
+	}
+	if _, _, err := limited.RecoverCellsAndKZGProofsFromStream(next); !errors.Is(err, ErrLimitExceeded) {
+		t.Error("expected the reconstruction limit to be enforced")
+	}
+	if numCalls != 3 {
+		t.Errorf("expected the stream to stop once the limit was exceeded, it was read %d times", numCalls)
+	}
+}
@@ -0,0 +1,85 @@
+package context
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+// A field element which was decoded from a canonical encoding.
+//
+// The only ways to create a Scalar are from a canonical encoding or from values which are
+// already reduced, so methods taking a Scalar do not need to check their inputs again.
+type Scalar struct {
+	inner fr.Element
+}
+
+// Decodes a scalar from its SerialisedScalar encoding, which is little-endian.
+// An error is returned if the encoding is not canonical
+func ScalarFromBytes(serScalar [32]byte) (Scalar, error) {
+	return ScalarFromLE(ScalarBytesLE(serScalar))
+}
+
+// Decodes a scalar from a little-endian encoding
+func ScalarFromLE(b ScalarBytesLE) (Scalar, error) {
+	element, err := b.Decode()
+	if err != nil {
+		return Scalar{}, err
+	}
+	return Scalar{inner: element}, nil
+}
+
+// Decodes a scalar from a big-endian encoding
+func ScalarFromBE(b ScalarBytesBE) (Scalar, error) {
+	element, err := b.Decode()
+	if err != nil {
+		return Scalar{}, err
+	}
+	return Scalar{inner: element}, nil
+}
+
+// Since fr.Element is always reduced, this cannot fail
+func ScalarFromElement(element fr.Element) Scalar {
+	return Scalar{inner: element}
+}
+
+func ScalarFromUint64(v uint64) Scalar {
+	return Scalar{inner: fr.NewElement(v)}
+}
+
+// Returns the SerialisedScalar encoding of the scalar, which is little-endian
+func (s Scalar) Bytes() [32]byte {
+	return [32]byte(EncodeScalarLE(s.inner))
+}
+
+func (s Scalar) Element() fr.Element {
+	return s.inner
+}
+
+func (s Scalar) Equal(other Scalar) bool {
+	return s.inner.Equal(&other.inner)
+}
+
+// Same as VerifyKZGProof, except that the input point and the claimed value
+// have already been decoded
+func (c *Context) VerifyKZGProofScalars(polynomialKZG KZGCommitment, kzgProof KZGProof, inputPoint, claimedValue Scalar) (err error) {
+	defer c.recoverPanic(&err)
+
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return err
+	}
+	quotientComm, err := deserialisePoint(kzgProof)
+	if err != nil {
+		return err
+	}
+
+	proof := kzg.OpeningProof{
+		QuotientComm: quotientComm,
+		InputPoint:   inputPoint.inner,
+		ClaimedValue: claimedValue.inner,
+	}
+	c.withCPUBudget(func() {
+		err = kzg.Verify(&polyComm, &proof, c.openKey)
+	})
+	return err
+}
@@ -0,0 +1,57 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var ErrNonCanonicalScalar = errors.New("scalar is not in canonical format")
+
+// Scalars encoded as 32 bytes, with the byte order as part of the type.
+//
+// The consensus specs encode scalars in big-endian, whereas SerialisedScalar
+// in this library is little-endian. Some external stacks emit little-endian
+// encodings too. Using distinct types means that the two cannot be mixed
+// without an explicit conversion.
+type ScalarBytesBE [32]byte
+type ScalarBytesLE [32]byte
+
+// Encodes a scalar in big-endian
+func EncodeScalarBE(scalar fr.Element) ScalarBytesBE {
+	return ScalarBytesBE(scalar.Bytes())
+}
+
+// Encodes a scalar in little-endian
+func EncodeScalarLE(scalar fr.Element) ScalarBytesLE {
+	return EncodeScalarBE(scalar).ToLE()
+}
+
+// Decodes the big-endian bytes into a scalar.
+// An error is returned if the bytes are not the canonical encoding of a scalar
+func (b ScalarBytesBE) Decode() (fr.Element, error) {
+	scalar, isCanon := utils.ReduceCanonical(b[:])
+	if !isCanon {
+		return fr.Element{}, ErrNonCanonicalScalar
+	}
+	return scalar, nil
+}
+
+// Decodes the little-endian bytes into a scalar.
+// An error is returned if the bytes are not the canonical encoding of a scalar
+func (b ScalarBytesLE) Decode() (fr.Element, error) {
+	return b.ToBE().Decode()
+}
+
+// Reverses the bytes, to give the little-endian encoding
+func (b ScalarBytesBE) ToLE() ScalarBytesLE {
+	utils.ReverseArray((*[32]byte)(&b))
+	return ScalarBytesLE(b)
+}
+
+// Reverses the bytes, to give the big-endian encoding
+func (b ScalarBytesLE) ToBE() ScalarBytesBE {
+	utils.ReverseArray((*[32]byte)(&b))
+	return ScalarBytesBE(b)
+}
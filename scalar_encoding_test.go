@@ -0,0 +1,44 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestScalarEncodingRoundTrip(t *testing.T) {
+	scalar := fr.NewElement(0x0102030405060708)
+
+	be := EncodeScalarBE(scalar)
+	le := EncodeScalarLE(scalar)
+
+	if be[31] != 0x08 || le[0] != 0x08 {
+		t.Fatal("unexpected byte order")
+	}
+	if be.ToLE() != le || le.ToBE() != be {
+		t.Error("conversion between byte orders is incorrect")
+	}
+
+	for _, decode := range []func() (fr.Element, error){be.Decode, le.Decode} {
+		got, err := decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&scalar) {
+			t.Error("scalar did not round trip")
+		}
+	}
+}
+
+func TestScalarDecodeNonCanonical(t *testing.T) {
+	var be ScalarBytesBE
+	for i := range be {
+		be[i] = 0xff
+	}
+	if _, err := be.Decode(); err != ErrNonCanonicalScalar {
+		t.Error("expected a non canonical error")
+	}
+	if _, err := be.ToLE().Decode(); err != ErrNonCanonicalScalar {
+		t.Error("expected a non canonical error")
+	}
+}
@@ -0,0 +1,68 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestScalarRoundTrip(t *testing.T) {
+	scalar := ScalarFromUint64(1234)
+
+	got, err := ScalarFromBytes(scalar.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(scalar) {
+		t.Error("scalar did not round trip")
+	}
+
+	// The encoding should match the one used for SerialisedScalar
+	serScalar := serialiseScalar(fr.NewElement(1234))
+	encoded := scalar.Bytes()
+	if !bytes.Equal(encoded[:], serScalar) {
+		t.Error("scalar encoding differs from SerialisedScalar")
+	}
+
+	fromBE, err := ScalarFromBE(EncodeScalarBE(fr.NewElement(1234)))
+	if err != nil || !fromBE.Equal(scalar) {
+		t.Error("big-endian decoding is incorrect")
+	}
+}
+
+func TestScalarNonCanonical(t *testing.T) {
+	var serScalar [32]byte
+	for i := range serScalar {
+		serScalar[i] = 0xff
+	}
+	if _, err := ScalarFromBytes(serScalar); err != ErrNonCanonicalScalar {
+		t.Error("expected an error for a non canonical scalar")
+	}
+}
+
+func TestVerifyKZGProofScalars(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	poly := testSerialisedPoly(4, 2)
+	comms, err := ctx.PolyToCommitments([]SerialisedPoly{copyPoly(poly)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputPoint := ScalarFromUint64(100)
+	proof, _, claimedValueBytes, err := ctx.ComputeKzgProof(copyPoly(poly), inputPoint.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimedValue, err := ScalarFromBytes(claimedValueBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ctx.VerifyKZGProofScalars(comms[0], proof, inputPoint, claimedValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyKZGProofScalars(comms[0], proof, inputPoint, ScalarFromUint64(1)); err == nil {
+		t.Error("expected the proof to fail with an incorrect claimed value")
+	}
+}
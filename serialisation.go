@@ -0,0 +1,175 @@
+package context
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// G2 points are only used in the opening key, however callers exchanging
+// opening keys or ceremony contributions will need to (de)serialise them.
+type SerialisedG2Point = []byte
+
+var ErrG2PointTrailingBytes = errors.New("serialised G2 point has trailing bytes")
+
+// Serialises a G2 point into its 96 byte compressed form
+func SerialiseG2Point(point curve.G2Affine) SerialisedG2Point {
+	serPoint := point.Bytes()
+	return serPoint[:]
+}
+
+// Serialises a G2 point into its 192 byte uncompressed form
+func SerialiseG2PointUncompressed(point curve.G2Affine) SerialisedG2Point {
+	serPoint := point.RawBytes()
+	return serPoint[:]
+}
+
+// Deserialises a G2 point from either its compressed or uncompressed form.
+//
+// The encoding is determined by the most significant bits of the first byte,
+// following the zcash serialisation format.
+// This will do subgroup checks and is relatively expensive
+func DeserialiseG2Point(serPoint SerialisedG2Point) (curve.G2Affine, error) {
+	var point curve.G2Affine
+
+	bytesRead, err := point.SetBytes(serPoint)
+	if err != nil {
+		return curve.G2Affine{}, err
+	}
+	if bytesRead != len(serPoint) {
+		return curve.G2Affine{}, ErrG2PointTrailingBytes
+	}
+	return point, nil
+}
+
+// Serialises G1 points into their 48 byte compressed form
+func SerialiseG1Points(points []curve.G1Affine) []SerialisedG1Point {
+	serPoints := make([]SerialisedG1Point, len(points))
+	for i := 0; i < len(points); i++ {
+		serPoint := points[i].Bytes()
+		serPoints[i] = serPoint[:]
+	}
+	return serPoints
+}
+
+// Deserialises compressed G1 points, such as commitments or proofs, checking that each
+// point is in the correct subgroup.
+//
+// Decompression and the subgroup checks are the expensive part, so the points are split
+// between one goroutine per CPU. The subgroup checks are not combined into a single
+// random linear combination, since the cofactor of G1 has small factors and an
+// invalid point would be missed with noticeable probability.
+// If more than one point is invalid, the error is for the point with the lowest index.
+func DeserialiseG1Points(serPoints []SerialisedG1Point) ([]curve.G1Affine, error) {
+	points := make([]curve.G1Affine, len(serPoints))
+	errs := make([]error, len(serPoints))
+
+	utils.ParallelFor(len(serPoints), func(start, end int) {
+		for i := start; i < end; i++ {
+			points[i], errs[i] = deserialisePoint(serPoints[i])
+		}
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("G1 point %d: %w", i, err)
+		}
+	}
+	return points, nil
+}
+
+// Size of the length prefix of a serialised polynomial
+const polyLengthPrefixSize = 4
+
+var ErrInvalidSerialisedPolyLength = errors.New("serialised polynomial length does not match its length prefix")
+
+// Serialises a polynomial, so that intermediate state such as recovered or folded
+// polynomials can be persisted or sent between processes.
+//
+// The encoding is the number of scalars as a little-endian uint32,
+// followed by each scalar in the same encoding as a SerialisedScalar.
+// The polynomial can be in either lagrange or monomial form, this is not recorded.
+func SerialisePolynomial(poly kzg.Polynomial) []byte {
+	serPoly := make([]byte, polyLengthPrefixSize, polyLengthPrefixSize+len(poly)*serialisedScalarSize)
+	binary.LittleEndian.PutUint32(serPoly, uint32(len(poly)))
+	for i := 0; i < len(poly); i++ {
+		serPoly = append(serPoly, serialiseScalar(poly[i])...)
+	}
+	return serPoly
+}
+
+// Deserialises a polynomial which was serialised with SerialisePolynomial.
+// An error is returned if any of the scalars are not canonical
+func DeserialisePolynomial(serPoly []byte) (kzg.Polynomial, error) {
+	if len(serPoly) < polyLengthPrefixSize {
+		return nil, ErrInvalidSerialisedPolyLength
+	}
+	numScalars := uint64(binary.LittleEndian.Uint32(serPoly))
+	// Check the length before allocating, so that a malicious prefix cannot cause a large allocation
+	if uint64(len(serPoly)-polyLengthPrefixSize) != numScalars*serialisedScalarSize {
+		return nil, ErrInvalidSerialisedPolyLength
+	}
+
+	poly := make(kzg.Polynomial, numScalars)
+	for i := uint64(0); i < numScalars; i++ {
+		start := polyLengthPrefixSize + i*serialisedScalarSize
+		// deserialiseScalar modifies its input, so the caller's bytes are copied first
+		serScalar := append([]byte{}, serPoly[start:start+serialisedScalarSize]...)
+		scalar, err := deserialiseScalar(serScalar)
+		if err != nil {
+			return nil, err
+		}
+		poly[i] = scalar
+	}
+	return poly, nil
+}
+
+var ErrInvalidSerialisedPointsLength = errors.New("serialised G1 points are not a multiple of 48 bytes")
+
+// Concatenates blob or KZG proofs into a single value, for example to store the proofs of a
+// sidecar in a database. Every proof must be 48 bytes, they are not checked to be valid points
+func SerialiseProofs(proofs []KZGProof) ([]byte, error) {
+	return concatG1Points(proofs)
+}
+
+// Splits a value created by SerialiseProofs back into proofs.
+// The proofs are copied, and are not checked to be valid points
+func DeserialiseProofs(serProofs []byte) ([]KZGProof, error) {
+	return splitG1Points(serProofs)
+}
+
+// Same as SerialiseProofs, except for commitments
+func SerialiseCommitments(comms SerialisedCommitments) ([]byte, error) {
+	return concatG1Points(comms)
+}
+
+// Same as DeserialiseProofs, except for commitments
+func DeserialiseCommitments(serComms []byte) (SerialisedCommitments, error) {
+	return splitG1Points(serComms)
+}
+
+func concatG1Points(serPoints []SerialisedG1Point) ([]byte, error) {
+	concatenated := make([]byte, 0, len(serPoints)*serialisedG1Size)
+	for i, serPoint := range serPoints {
+		if len(serPoint) != serialisedG1Size {
+			return nil, fmt.Errorf("G1 point %d: %w", i, ErrInvalidPointBytesSize)
+		}
+		concatenated = append(concatenated, serPoint...)
+	}
+	return concatenated, nil
+}
+
+func splitG1Points(concatenated []byte) ([]SerialisedG1Point, error) {
+	if len(concatenated)%serialisedG1Size != 0 {
+		return nil, ErrInvalidSerialisedPointsLength
+	}
+	serPoints := make([]SerialisedG1Point, len(concatenated)/serialisedG1Size)
+	for i := range serPoints {
+		serPoints[i] = append([]byte{}, concatenated[i*serialisedG1Size:(i+1)*serialisedG1Size]...)
+	}
+	return serPoints, nil
+}
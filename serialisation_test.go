@@ -0,0 +1,198 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func TestG2SerialisationRoundTrip(t *testing.T) {
+	_, _, _, gen2Aff := curve.Generators()
+
+	var point curve.G2Affine
+	point.ScalarMultiplication(&gen2Aff, big.NewInt(1234))
+
+	compressed := SerialiseG2Point(point)
+	if len(compressed) != curve.SizeOfG2AffineCompressed {
+		t.Fatalf("unexpected compressed length %d", len(compressed))
+	}
+	got, err := DeserialiseG2Point(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&point) {
+		t.Error("compressed G2 point did not round trip")
+	}
+
+	uncompressed := SerialiseG2PointUncompressed(point)
+	if len(uncompressed) != curve.SizeOfG2AffineUncompressed {
+		t.Fatalf("unexpected uncompressed length %d", len(uncompressed))
+	}
+	got, err = DeserialiseG2Point(uncompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&point) {
+		t.Error("uncompressed G2 point did not round trip")
+	}
+}
+
+func TestG2DeserialiseTrailingBytes(t *testing.T) {
+	_, _, _, gen2Aff := curve.Generators()
+
+	serPoint := append(SerialiseG2Point(gen2Aff), 0)
+	_, err := DeserialiseG2Point(serPoint)
+	if err != ErrG2PointTrailingBytes {
+		t.Error("expected an error for trailing bytes")
+	}
+}
+
+func TestPolynomialSerialisationRoundTrip(t *testing.T) {
+	poly := kzg.Polynomial{fr.NewElement(1), fr.NewElement(2), fr.NewElement(1 << 40)}
+
+	serPoly := SerialisePolynomial(poly)
+	if len(serPoly) != polyLengthPrefixSize+len(poly)*serialisedScalarSize {
+		t.Fatalf("unexpected serialised length %d", len(serPoly))
+	}
+	serCopy := append([]byte{}, serPoly...)
+
+	got, err := DeserialisePolynomial(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(serPoly, serCopy) {
+		t.Error("deserialisation should not modify its input")
+	}
+	if len(got) != len(poly) {
+		t.Fatal("polynomial did not round trip")
+	}
+	for i := range poly {
+		if !got[i].Equal(&poly[i]) {
+			t.Fatal("polynomial did not round trip")
+		}
+	}
+
+	empty, err := DeserialisePolynomial(SerialisePolynomial(nil))
+	if err != nil || len(empty) != 0 {
+		t.Error("empty polynomial did not round trip")
+	}
+}
+
+func TestDeserialisePolynomialInvalid(t *testing.T) {
+	serPoly := SerialisePolynomial(kzg.Polynomial{fr.NewElement(1), fr.NewElement(2)})
+
+	if _, err := DeserialisePolynomial(serPoly[:len(serPoly)-1]); err != ErrInvalidSerialisedPolyLength {
+		t.Error("expected an error for a truncated polynomial")
+	}
+	if _, err := DeserialisePolynomial(serPoly[:2]); err != ErrInvalidSerialisedPolyLength {
+		t.Error("expected an error for a truncated length prefix")
+	}
+
+	nonCanonical := append([]byte{}, serPoly...)
+	for i := polyLengthPrefixSize; i < polyLengthPrefixSize+serialisedScalarSize; i++ {
+		nonCanonical[i] = 0xff
+	}
+	if _, err := DeserialisePolynomial(nonCanonical); err != ErrNonCanonicalScalar {
+		t.Error("expected an error for a non canonical scalar")
+	}
+}
+
+func TestG1PointsSerialisationRoundTrip(t *testing.T) {
+	_, _, g1Gen, _ := curve.Generators()
+
+	points := make([]curve.G1Affine, 17)
+	for i := 1; i < len(points); i++ {
+		points[i].Add(&points[i-1], &g1Gen)
+	}
+
+	got, err := DeserialiseG1Points(SerialiseG1Points(points))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(points) {
+		t.Fatalf("expected %d points, got %d", len(points), len(got))
+	}
+	for i := range points {
+		if !got[i].Equal(&points[i]) {
+			t.Errorf("point %d does not round trip", i)
+		}
+	}
+
+	empty, err := DeserialiseG1Points(nil)
+	if err != nil || len(empty) != 0 {
+		t.Error("no points should deserialise to no points")
+	}
+
+	serPoints := SerialiseG1Points(points)
+	serPoints[5] = make([]byte, 48)
+	serPoints[5][0] = 0x80 | 0x04
+	if _, err := DeserialiseG1Points(serPoints); err == nil {
+		t.Error("invalid point should not deserialise")
+	}
+}
+
+func TestProofsSerialisationRoundTrip(t *testing.T) {
+	_, _, g1Gen, _ := curve.Generators()
+
+	proofs := make([]KZGProof, 5)
+	var point curve.G1Affine
+	for i := range proofs {
+		point.Add(&point, &g1Gen)
+		serPoint := point.Bytes()
+		proofs[i] = serPoint[:]
+	}
+
+	serProofs, err := SerialiseProofs(proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(serProofs) != len(proofs)*48 {
+		t.Fatalf("expected %d bytes, got %d", len(proofs)*48, len(serProofs))
+	}
+	got, err := DeserialiseProofs(serProofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(proofs) {
+		t.Fatalf("expected %d proofs, got %d", len(proofs), len(got))
+	}
+	for i := range proofs {
+		if !bytes.Equal(got[i], proofs[i]) {
+			t.Errorf("proof %d does not round trip", i)
+		}
+	}
+
+	// The deserialised proofs should not alias the serialised value
+	serProofs[0] ^= 1
+	if !bytes.Equal(got[0], proofs[0]) {
+		t.Error("deserialised proofs should be copied")
+	}
+
+	comms, err := DeserialiseCommitments(nil)
+	if err != nil || len(comms) != 0 {
+		t.Error("no bytes should deserialise to no commitments")
+	}
+	serComms, err := SerialiseCommitments(proofs)
+	if err != nil || len(serComms) != len(proofs)*48 {
+		t.Error("commitments should serialise the same way as proofs")
+	}
+}
+
+func TestProofsSerialisationInvalidLengths(t *testing.T) {
+	if _, err := DeserialiseProofs(make([]byte, 97)); err != ErrInvalidSerialisedPointsLength {
+		t.Error("expected an error for a length that is not a multiple of 48")
+	}
+	if _, err := DeserialiseCommitments(make([]byte, 47)); err != ErrInvalidSerialisedPointsLength {
+		t.Error("expected an error for a length that is not a multiple of 48")
+	}
+
+	proofs := []KZGProof{make([]byte, 48), make([]byte, 47)}
+	if _, err := SerialiseProofs(proofs); !errors.Is(err, ErrInvalidPointBytesSize) {
+		t.Error("expected an error for a proof that is not 48 bytes")
+	}
+}
@@ -0,0 +1,204 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/agg_kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var (
+	ErrSetupSizeNotPow2  = errors.New("number of G1 points in the setup must be a power of two and at least 2")
+	ErrSetupSizeMismatch = errors.New("number of lagrange and monomial G1 points in the setup differ")
+	ErrSetupNotEnoughG2  = errors.New("setup must contain at least two G2 points")
+	ErrNilChallengeHash  = errors.New("challenge hash function must not be nil")
+)
+
+// Creates a Context from a setup which has already been parsed.
+//
+// - g1Lagrange are the points [L_i(α)]G₁ where the lagrange polynomials are over the roots of unity in natural order
+// - g1Monomial are the points [α^i]G₁
+// - g2 are the points [α^i]G₂, only the first two are needed for KZG proofs. Zero suffix and cell proofs need more.
+//
+// The points are copied, and are assumed to be in the correct subgroup.
+// The bit-reversal permutation is applied to the lagrange points, as is done when the
+// setup is generated in NewContextInsecure.
+func NewContextFromPoints(g1Lagrange []curve.G1Affine, g1Monomial []curve.G1Affine, g2 []curve.G2Affine, opts ...ContextOption) (*Context, error) {
+	return newContextFromPoints(g1Lagrange, g1Monomial, g2, agg_kzg.Protocol{}, opts)
+}
+
+// A Context whose Fiat-Shamir transform uses a hash other than SHA-256, for deployments outside of Ethereum.
+//
+// This is a separate type, so that a *Context always uses SHA-256. Every other constructor, including
+// the presets, returns a *Context, and there is no way to take the *Context out of this type.
+// The methods of Context are available on it, except that WriteTo returns ErrContextCacheCustomHash.
+type ContextWithChallengeHash struct {
+	*customHashContext
+}
+
+// The embedded field has the name of this alias, so that it is unexported
+type customHashContext = Context
+
+// Same as NewContextFromPoints, except that `newHash` replaces SHA-256 in the Fiat-Shamir
+// transform of the aggregate, blob and payload proofs. For example, a deployment outside of
+// Ethereum may use Keccak or BLAKE3.
+//
+// Proofs created by this Context do not verify with a SHA-256 Context, and vice versa.
+// Ethereum clients must not use this method.
+func NewContextFromPointsWithChallengeHash(newHash fiatshamir.HashFunc, g1Lagrange []curve.G1Affine, g1Monomial []curve.G1Affine, g2 []curve.G2Affine, opts ...ContextOption) (*ContextWithChallengeHash, error) {
+	if newHash == nil {
+		return nil, ErrNilChallengeHash
+	}
+	ctx, err := newContextFromPoints(g1Lagrange, g1Monomial, g2, agg_kzg.Protocol{NewHash: newHash}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ContextWithChallengeHash{ctx}, nil
+}
+
+// Same as Context.DownsampleSetup, the smaller setup keeps the hash
+func (c *ContextWithChallengeHash) DownsampleSetup(size uint64, opts ...ContextOption) (*ContextWithChallengeHash, error) {
+	ctx, err := c.customHashContext.DownsampleSetup(size, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ContextWithChallengeHash{ctx}, nil
+}
+
+// Same as Context.WithPriority
+func (c *ContextWithChallengeHash) WithPriority(priority Priority) *ContextWithChallengeHash {
+	return &ContextWithChallengeHash{c.customHashContext.WithPriority(priority)}
+}
+
+// Same as NewContextFromPoints, except that the setup only contains the monomial points [α^i]G₁,
+// as is the case for several published ceremony artifacts.
+//
+// The lagrange points are derived with an inverse FFT over G1, which is split between one
+// goroutine per CPU. This is slower than loading the lagrange points, so the Context can be
+// cached with WriteTo.
+func NewContextFromMonomialPoints(g1Monomial []curve.G1Affine, g2 []curve.G2Affine, opts ...ContextOption) (*Context, error) {
+	size := uint64(len(g1Monomial))
+	if err := checkSetupSizes(size, g1Monomial, g2); err != nil {
+		return nil, err
+	}
+	domains, err := kzg.NewDomainExtended(size, ExpansionFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	// The lagrange points are returned in the bit-reversed order of the roots, as the commit key expects
+	g1Lagrange, err := domains.Original.MonomialToLagrangeG1(g1Monomial)
+	if err != nil {
+		return nil, err
+	}
+	return newContextFromReversedPoints(domains, kzg.CommitKey{G1: g1Lagrange}, g1Monomial, g2, agg_kzg.Protocol{}, opts)
+}
+
+// Same as NewContextFromPoints, except that the setup only contains the lagrange points [L_i(α)]G₁.
+//
+// The monomial points are only needed for cell proofs, zero suffix proofs, commitment cross checks
+// and exporting the setup. They are derived with an FFT over G1 the first time they are needed,
+// so a Context which only verifies blob proofs never computes them.
+func NewContextFromLagrangePoints(g1Lagrange []curve.G1Affine, g2 []curve.G2Affine, opts ...ContextOption) (*Context, error) {
+	return newContextFromPoints(g1Lagrange, nil, g2, agg_kzg.Protocol{}, opts)
+}
+
+// Returns the monomial SRS, deriving its G1 points from the lagrange points if needed
+func (c *Context) monomial() *kzg.MonomialSRS {
+	if c.deriveMonomialG1 != nil {
+		c.deriveMonomialG1.Do(func() {
+			// The commit key is in the same order as the roots of the domain
+			points, err := c.domain.LagrangeToMonomialG1(c.commitKey.G1)
+			if err != nil {
+				panic(fmt.Sprintf("could not derive monomial points %s", err))
+			}
+			c.monomialSRS.G1 = points
+		})
+	}
+	return c.monomialSRS
+}
+
+func newContextFromPoints(g1Lagrange []curve.G1Affine, g1Monomial []curve.G1Affine, g2 []curve.G2Affine, protocol agg_kzg.Protocol, opts []ContextOption) (*Context, error) {
+	size := uint64(len(g1Lagrange))
+	if err := checkSetupSizes(size, g1Monomial, g2); err != nil {
+		return nil, err
+	}
+	domains, err := kzg.NewDomainExtended(size, ExpansionFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the lagrange points, then reverse them to match the roots of the domain
+	commitKey := kzg.CommitKey{G1: make([]curve.G1Affine, size)}
+	copy(commitKey.G1, g1Lagrange)
+	commitKey.ReversePoints()
+
+	return newContextFromReversedPoints(domains, commitKey, g1Monomial, g2, protocol, opts)
+}
+
+// Checks the number of points in a setup with `size` lagrange points
+func checkSetupSizes(size uint64, g1Monomial []curve.G1Affine, g2 []curve.G2Affine) error {
+	if size < 2 || !utils.IsPowerOfTwo(size) {
+		return ErrSetupSizeNotPow2
+	}
+	// The monomial points are derived later if the setup does not contain them
+	if g1Monomial != nil && uint64(len(g1Monomial)) != size {
+		return ErrSetupSizeMismatch
+	}
+	if len(g2) < 2 {
+		return ErrSetupNotEnoughG2
+	}
+	return nil
+}
+
+// Same as newContextFromPoints, except that the lagrange points in the commit key are already in the
+// bit-reversed order of the roots of domains.Original, and are not copied.
+// The sizes of the points are assumed to have been checked with checkSetupSizes
+func newContextFromReversedPoints(domains *kzg.ExtendedDomain, commitKey kzg.CommitKey, g1Monomial []curve.G1Affine, g2 []curve.G2Affine, protocol agg_kzg.Protocol, opts []ContextOption) (*Context, error) {
+	size := domains.Original.Cardinality
+	deriveMonomial := g1Monomial == nil
+
+	monomialSRS := &kzg.MonomialSRS{G2: make([]curve.G2Affine, len(g2))}
+	copy(monomialSRS.G2, g2)
+
+	// G₁ = [α^0]G₁, which is also \sum [L_i(α)]G₁ since the lagrange polynomials sum to one
+	var genG1 curve.G1Affine
+	if deriveMonomial {
+		var sum curve.G1Jac
+		for i := 0; i < len(commitKey.G1); i++ {
+			sum.AddMixed(&commitKey.G1[i])
+		}
+		genG1.FromJacobian(&sum)
+	} else {
+		monomialSRS.G1 = make([]curve.G1Affine, size)
+		copy(monomialSRS.G1, g1Monomial)
+		genG1 = g1Monomial[0]
+	}
+
+	srs := &kzg.SRS{
+		CommitKey: commitKey,
+		OpeningKey: kzg.OpeningKey{
+			GenG1:   genG1,
+			GenG2:   g2[0],
+			AlphaG2: g2[1],
+		},
+	}
+
+	ctx, err := newContext(domains, srs, monomialSRS, DefaultParams(size), opts)
+	if err != nil {
+		return nil, err
+	}
+	// Only the hash is taken from `protocol`, the rest is configured by the options
+	ctx.protocol.NewHash = protocol.NewHash
+	if deriveMonomial {
+		ctx.deriveMonomialG1 = &sync.Once{}
+	}
+	ctx.logger.Debug("created context from points", "polyDegree", size, "numG2", len(g2))
+
+	return ctx, nil
+}
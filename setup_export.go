@@ -0,0 +1,107 @@
+package context
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var ErrInvalidDownsampleSize = errors.New("downsampled setup size must be a power of two, at least 2 and at most the size of the setup")
+
+// The points of a setup, in the form accepted by NewContextFromPoints
+type SetupPoints struct {
+	// [L_i(α)]G₁, over the roots of unity in natural order
+	G1Lagrange []curve.G1Affine
+	// [α^i]G₁
+	G1Monomial []curve.G1Affine
+	// [α^i]G₂
+	G2Monomial []curve.G2Affine
+}
+
+// The points of a setup, each serialised in compressed form with the same encoding
+type SerialisedSetup struct {
+	G1Lagrange []SerialisedG1Point
+	G1Monomial []SerialisedG1Point
+	G2Monomial []SerialisedG2Point
+}
+
+// Returns a Context for a smaller setup, derived from the setup of this Context.
+//
+// The smaller setup keeps the first `size` powers of α in G₁, and the lagrange points are
+// recomputed for the roots of unity of size `size`. Since α is the same, this is as secure as
+// the original setup, so devnets with small blobs can reuse the output of the ceremony.
+// Every G₂ point is kept. The options are not inherited, they are passed again.
+//
+// The new setup can be exported with SetupPoints, SerialiseSetup or WriteTo.
+func (c *Context) DownsampleSetup(size uint64, opts ...ContextOption) (*Context, error) {
+	if size < 2 || !utils.IsPowerOfTwo(size) || size > uint64(len(c.monomial().G1)) {
+		return nil, ErrInvalidDownsampleSize
+	}
+
+	// 1. Take the first `size` powers of α
+	g1Monomial := c.monomial().G1[:size]
+
+	// 2. Recompute the lagrange points over the smaller domain, in the bit-reversed order of its roots
+	domains, err := kzg.NewDomainExtended(size, ExpansionFactor)
+	if err != nil {
+		return nil, err
+	}
+	g1Lagrange, err := domains.Original.MonomialToLagrangeG1(g1Monomial)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Create the Context, this copies the monomial points.
+	// The Fiat-Shamir configuration is kept, so that both setups produce compatible proofs
+	return newContextFromReversedPoints(domains, kzg.CommitKey{G1: g1Lagrange}, g1Monomial, c.monomial().G2, c.protocol, opts)
+}
+
+// Returns a copy of the points in the setup, which can be passed to NewContextFromPoints
+func (c *Context) SetupPoints() SetupPoints {
+	points := SetupPoints{
+		G1Lagrange: make([]curve.G1Affine, len(c.commitKey.G1)),
+		G1Monomial: make([]curve.G1Affine, len(c.monomial().G1)),
+		G2Monomial: make([]curve.G2Affine, len(c.monomial().G2)),
+	}
+	copy(points.G1Lagrange, c.commitKey.G1)
+	copy(points.G1Monomial, c.monomial().G1)
+	copy(points.G2Monomial, c.monomial().G2)
+
+	// The commit key is stored in bit-reversed order
+	utils.BitReversePoints(points.G1Lagrange)
+
+	return points
+}
+
+// Returns the points in the setup in compressed form, using `encoding`
+func (c *Context) SerialiseSetup(encoding PointEncoding) (SerialisedSetup, error) {
+	points := c.SetupPoints()
+	serSetup := SerialisedSetup{
+		G1Lagrange: make([]SerialisedG1Point, len(points.G1Lagrange)),
+		G1Monomial: make([]SerialisedG1Point, len(points.G1Monomial)),
+		G2Monomial: make([]SerialisedG2Point, len(points.G2Monomial)),
+	}
+
+	var err error
+	for i := 0; i < len(points.G1Lagrange); i++ {
+		serSetup.G1Lagrange[i], err = SerialiseG1PointWithEncoding(points.G1Lagrange[i], encoding)
+		if err != nil {
+			return SerialisedSetup{}, err
+		}
+	}
+	for i := 0; i < len(points.G1Monomial); i++ {
+		serSetup.G1Monomial[i], err = SerialiseG1PointWithEncoding(points.G1Monomial[i], encoding)
+		if err != nil {
+			return SerialisedSetup{}, err
+		}
+	}
+	for i := 0; i < len(points.G2Monomial); i++ {
+		serSetup.G2Monomial[i], err = SerialiseG2PointWithEncoding(points.G2Monomial[i], encoding)
+		if err != nil {
+			return SerialisedSetup{}, err
+		}
+	}
+	return serSetup, nil
+}
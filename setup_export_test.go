@@ -0,0 +1,91 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDownsampleSetup(t *testing.T) {
+	ctx := NewContextInsecure(16, 1234)
+	smallCtx, err := ctx.DownsampleSetup(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The downsampled setup should be the setup generated for the smaller size with the same secret
+	expected := NewContextInsecure(4, 1234).SetupPoints()
+	got := smallCtx.SetupPoints()
+	for i := 0; i < 4; i++ {
+		if !got.G1Lagrange[i].Equal(&expected.G1Lagrange[i]) {
+			t.Errorf("lagrange point %d does not match", i)
+		}
+		if !got.G1Monomial[i].Equal(&expected.G1Monomial[i]) {
+			t.Errorf("monomial point %d does not match", i)
+		}
+	}
+	if len(got.G2Monomial) != 16 {
+		t.Errorf("expected every G2 point to be kept, got %d", len(got.G2Monomial))
+	}
+
+	// Proofs from the downsampled setup should verify
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 5)}
+	proof, comms, err := smallCtx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := smallCtx.VerifyAggregateKzgProof(copyPolys(polys), proof, comms); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDownsampleSetupInvalidSize(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	for _, size := range []uint64{0, 1, 3, 16} {
+		if _, err := ctx.DownsampleSetup(size); err != ErrInvalidDownsampleSize {
+			t.Errorf("expected an error for size %d", size)
+		}
+	}
+}
+
+func TestSerialiseSetupRoundTrip(t *testing.T) {
+	ctx := NewContextInsecure(8, 1234)
+	points := ctx.SetupPoints()
+
+	for _, encoding := range []PointEncoding{EncodingZcash, EncodingArkworks} {
+		serSetup, err := ctx.SerialiseSetup(encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, serPoint := range serSetup.G1Lagrange {
+			point, err := DeserialiseG1PointWithEncoding(serPoint, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !point.Equal(&points.G1Lagrange[i]) {
+				t.Errorf("lagrange point %d did not round trip", i)
+			}
+		}
+		for i, serPoint := range serSetup.G2Monomial {
+			point, err := DeserialiseG2PointWithEncoding(serPoint, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !point.Equal(&points.G2Monomial[i]) {
+				t.Errorf("G2 point %d did not round trip", i)
+			}
+		}
+	}
+
+	// The exported points should create the same Context
+	got, err := NewContextFromPoints(points.G1Lagrange, points.G1Monomial, points.G2Monomial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	polys := []SerialisedPoly{testSerialisedPoly(8, 1)}
+	_, expectedComms, _ := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	_, comms, _ := got.ComputeAggregateKzgProof(copyPolys(polys))
+	if !bytes.Equal(comms[0], expectedComms[0]) {
+		t.Error("exported setup created a different Context")
+	}
+}
@@ -0,0 +1,162 @@
+package context
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+func TestNewContextFromPoints(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, secret)
+	monomialSRS, _ := kzg.NewMonomialSRSInsecure(4, secret)
+
+	ctx, err := NewContextFromPoints(srs.CommitKey.G1, monomialSRS.G1, monomialSRS.G2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCtx := NewContextInsecure(4, 1234)
+
+	poly := testSerialisedPoly(4, 3)
+	got, err := ctx.PolyToCommitments([]SerialisedPoly{copyPoly(poly)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := expectedCtx.PolyToCommitments([]SerialisedPoly{copyPoly(poly)})
+	if !bytes.Equal(got[0], expected[0]) {
+		t.Error("commitment does not match the insecure context")
+	}
+
+	if err := ctx.Warmup(); err != nil {
+		t.Error(err)
+	}
+
+	_, err = NewContextFromPoints(srs.CommitKey.G1[:3], monomialSRS.G1[:3], monomialSRS.G2)
+	if err != ErrSetupSizeNotPow2 {
+		t.Error("expected an error for a non power of two setup")
+	}
+}
+
+func TestNewContextFromPointsWithChallengeHash(t *testing.T) {
+	secret := big.NewInt(1234)
+	domain := kzg.NewDomain(4)
+	srs, _ := kzg.NewSRSInsecure(*domain, secret)
+	monomialSRS, _ := kzg.NewMonomialSRSInsecure(4, secret)
+
+	ctx, err := NewContextFromPointsWithChallengeHash(sha512.New, srs.CommitKey.G1, monomialSRS.G1, monomialSRS.G2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha256Ctx := NewContextInsecure(4, 1234)
+
+	serPoly := testSerialisedPoly(4, 3)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// The challenges differ, so the proof does not verify with SHA-256
+	if err := sha256Ctx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof); err == nil {
+		t.Error("proof made with a different hash should not verify with SHA-256")
+	}
+
+	vector, err := ctx.ComputeChallengeVector(copyPoly(serPoly), comm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vector.CompressedState) != sha512.Size {
+		t.Error("challenges should be computed with the configured hash")
+	}
+
+	// The hash is not part of the cache, so the Context must not be written
+	var buf bytes.Buffer
+	if _, err := ctx.WriteTo(&buf); err != ErrContextCacheCustomHash {
+		t.Error("expected a Context with a custom hash to not be cached")
+	}
+
+	// A downsampled setup keeps the hash
+	small, err := ctx.DownsampleSetup(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vector, err = small.ComputeChallengeVector(testSerialisedPoly(2, 3), comm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vector.CompressedState) != sha512.Size {
+		t.Error("downsampled setup should keep the configured hash")
+	}
+
+	_, err = NewContextFromPointsWithChallengeHash(nil, srs.CommitKey.G1, monomialSRS.G1, monomialSRS.G2)
+	if err != ErrNilChallengeHash {
+		t.Error("expected an error for a nil hash")
+	}
+}
+
+func TestNewContextFromMonomialPoints(t *testing.T) {
+	monomialSRS, _ := kzg.NewMonomialSRSInsecure(16, big.NewInt(1234))
+
+	ctx, err := NewContextFromMonomialPoints(monomialSRS.G1, monomialSRS.G2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The derived lagrange points should be those of the insecure context
+	expected := NewContextInsecure(16, 1234).SetupPoints()
+	got := ctx.SetupPoints()
+	for i := range expected.G1Lagrange {
+		if !got.G1Lagrange[i].Equal(&expected.G1Lagrange[i]) {
+			t.Errorf("lagrange point %d does not match", i)
+		}
+	}
+
+	_, err = NewContextFromMonomialPoints(monomialSRS.G1[:3], monomialSRS.G2)
+	if err != ErrSetupSizeNotPow2 {
+		t.Error("expected an error for a non power of two setup")
+	}
+}
+
+func TestNewContextFromLagrangePoints(t *testing.T) {
+	expectedCtx := NewContextInsecure(16, 1234)
+	expected := expectedCtx.SetupPoints()
+
+	ctx, err := NewContextFromLagrangePoints(expected.G1Lagrange, expected.G2Monomial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Features().SetupHash != expectedCtx.Features().SetupHash {
+		t.Error("setup does not match the insecure context")
+	}
+
+	// The monomial points are only derived when they are needed
+	if ctx.monomialSRS.G1 != nil {
+		t.Error("monomial points should not be derived up front")
+	}
+	got := ctx.MonomialG1Points()
+	for i := range expected.G1Monomial {
+		if !got[i].Equal(&expected.G1Monomial[i]) {
+			t.Errorf("monomial point %d does not match", i)
+		}
+	}
+
+	// Proofs which need the monomial points should work
+	serPoly := testSerialisedPoly(16, 1)
+	for i := 8; i < 16; i++ {
+		serPoly[i] = make([]byte, 32)
+	}
+	proof, err := ctx.ComputeZeroSuffixProof(copyPoly(serPoly), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comms, _ := ctx.PolyToCommitments([]SerialisedPoly{copyPoly(serPoly)})
+	if err := ctx.VerifyZeroSuffixProof(comms[0], proof, 8); err != nil {
+		t.Error(err)
+	}
+}
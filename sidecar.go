@@ -0,0 +1,89 @@
+package context
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The SSZ encoding of a BlobsSidecar is:
+//
+//	beacon_block_root:    Root (32 bytes)
+//	beacon_block_slot:    Slot (uint64, little-endian)
+//	blobs:                offset (uint32, little-endian) to List[Blob, MAX_BLOBS_PER_BLOCK]
+//	kzg_aggregated_proof: KZGProof (48 bytes)
+//
+// followed by the blobs, which are concatenated since each blob has a fixed size.
+const (
+	sidecarRootSize   = 32
+	sidecarSlotSize   = 8
+	sidecarOffsetSize = 4
+	sidecarProofSize  = 48
+	sidecarFixedSize  = sidecarRootSize + sidecarSlotSize + sidecarOffsetSize + sidecarProofSize
+)
+
+var (
+	ErrSidecarTooShort           = errors.New("ssz encoded sidecar is too short")
+	ErrSidecarInvalidBlobsOffset = errors.New("ssz encoded sidecar has an invalid offset for the blobs")
+	ErrSidecarInvalidBlobsLength = errors.New("ssz encoded sidecar has a blobs list which is not a multiple of the blob size")
+)
+
+// Verifies the aggregated proof in an SSZ encoded BlobsSidecar, as received over gossip.
+//
+// The blobs and the proof are read directly from the SSZ bytes, so consensus clients do not
+// need to decode the sidecar into intermediate structs first. The commitments are not part of
+// the sidecar, they come from the corresponding beacon block.
+//
+// Note: the bytes of the sidecar are modified in place during deserialisation.
+func (c *Context) VerifyBlobsSidecarSSZ(sidecarSSZ []byte, serComms SerialisedCommitments) (err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Split the sidecar into the blobs and the aggregated proof
+	serPolys, serProof, err := c.decodeBlobsSidecarSSZ(sidecarSSZ)
+	if err != nil {
+		return err
+	}
+
+	return c.VerifyAggregateKzgProof(serPolys, serProof, serComms)
+}
+
+// Returns the blobs and the aggregated proof in an SSZ encoded BlobsSidecar.
+// The returned slices alias the input
+func (c *Context) decodeBlobsSidecarSSZ(sidecarSSZ []byte) ([]SerialisedPoly, KZGProof, error) {
+	if len(sidecarSSZ) < sidecarFixedSize {
+		return nil, nil, ErrSidecarTooShort
+	}
+
+	offsetStart := sidecarRootSize + sidecarSlotSize
+	blobsOffset := binary.LittleEndian.Uint32(sidecarSSZ[offsetStart : offsetStart+sidecarOffsetSize])
+	// The blobs are the only variable sized field, so they must start immediately
+	// after the fixed sized part
+	if blobsOffset != sidecarFixedSize {
+		return nil, nil, ErrSidecarInvalidBlobsOffset
+	}
+
+	proofStart := offsetStart + sidecarOffsetSize
+	serProof := sidecarSSZ[proofStart : proofStart+sidecarProofSize]
+
+	blobsBytes := sidecarSSZ[sidecarFixedSize:]
+	blobSize := int(c.params.BytesPerBlob())
+	if len(blobsBytes)%blobSize != 0 {
+		return nil, nil, ErrSidecarInvalidBlobsLength
+	}
+
+	numBlobs := len(blobsBytes) / blobSize
+	if err := c.checkBatchSize(numBlobs); err != nil {
+		return nil, nil, err
+	}
+
+	serPolys := make([]SerialisedPoly, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blob := blobsBytes[i*blobSize : (i+1)*blobSize]
+		serPoly := make(SerialisedPoly, c.domain.Cardinality)
+		for j := 0; j < len(serPoly); j++ {
+			serPoly[j] = blob[j*serialisedScalarSize : (j+1)*serialisedScalarSize]
+		}
+		serPolys[i] = serPoly
+	}
+
+	return serPolys, serProof, nil
+}
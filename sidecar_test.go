@@ -0,0 +1,68 @@
+package context
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestVerifyBlobsSidecarSSZ(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 10)}
+	proof, comms, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ctx.VerifyBlobsSidecarSSZ(encodeTestSidecar(polys, proof), comms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Swapping the blobs should make the proof fail
+	swapped := []SerialisedPoly{polys[1], polys[0]}
+	err = ctx.VerifyBlobsSidecarSSZ(encodeTestSidecar(swapped, proof), comms)
+	if err == nil {
+		t.Error("expected the proof to fail with the blobs swapped")
+	}
+}
+
+func TestDecodeBlobsSidecarSSZInvalid(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	polys := []SerialisedPoly{testSerialisedPoly(4, 1)}
+	proof, comms, err := ctx.ComputeAggregateKzgProof(copyPolys(polys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sidecar := encodeTestSidecar(polys, proof)
+
+	err = ctx.VerifyBlobsSidecarSSZ(sidecar[:sidecarFixedSize-1], comms)
+	if err != ErrSidecarTooShort {
+		t.Errorf("expected %v, got %v", ErrSidecarTooShort, err)
+	}
+
+	err = ctx.VerifyBlobsSidecarSSZ(sidecar[:len(sidecar)-1], comms)
+	if err != ErrSidecarInvalidBlobsLength {
+		t.Errorf("expected %v, got %v", ErrSidecarInvalidBlobsLength, err)
+	}
+
+	badOffset := append([]byte{}, sidecar...)
+	binary.LittleEndian.PutUint32(badOffset[sidecarRootSize+sidecarSlotSize:], sidecarFixedSize+1)
+	err = ctx.VerifyBlobsSidecarSSZ(badOffset, comms)
+	if err != ErrSidecarInvalidBlobsOffset {
+		t.Errorf("expected %v, got %v", ErrSidecarInvalidBlobsOffset, err)
+	}
+}
+
+func encodeTestSidecar(polys []SerialisedPoly, proof KZGProof) []byte {
+	sidecar := make([]byte, sidecarRootSize+sidecarSlotSize+sidecarOffsetSize)
+	binary.LittleEndian.PutUint32(sidecar[sidecarRootSize+sidecarSlotSize:], sidecarFixedSize)
+	sidecar = append(sidecar, proof...)
+	for _, poly := range polys {
+		for _, scalar := range poly {
+			sidecar = append(sidecar, scalar...)
+		}
+	}
+	return sidecar
+}
@@ -0,0 +1,162 @@
+// Package spec is a thin layer over the Context whose names and signatures follow
+// the consensus spec's polynomial-commitments.md, so that the two can be compared
+// line by line.
+//
+// All inputs and outputs are bytes. Scalars are little-endian, as in the rest of this library.
+// Methods which return a bool in the spec return an error instead, which is nil if the
+// spec would return true.
+package spec
+
+import (
+	"errors"
+
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+const (
+	BytesPerFieldElement = 32
+	BytesPerCommitment   = 48
+	BytesPerProof        = 48
+)
+
+type Bytes32 = [BytesPerFieldElement]byte
+type Bytes48 = [BytesPerCommitment]byte
+type KZGCommitment = Bytes48
+type KZGProof = Bytes48
+
+// A blob is FIELD_ELEMENTS_PER_BLOB serialised scalars concatenated together
+type Blob = []byte
+
+var ErrInvalidBlobSize = errors.New("blob is not BYTES_PER_BLOB bytes")
+
+// Holds the trusted setup, which the spec keeps as global state
+type Spec struct {
+	ctx *context.Context
+}
+
+func New(ctx *context.Context) *Spec {
+	return &Spec{ctx: ctx}
+}
+
+// Returns BYTES_PER_BLOB
+func (s *Spec) BytesPerBlob() int {
+	return int(s.ctx.Params().BytesPerBlob())
+}
+
+// Spec: blob_to_kzg_commitment
+func (s *Spec) BlobToKZGCommitment(blob Blob) (KZGCommitment, error) {
+	polynomial, err := s.blobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	comms, err := s.ctx.PolyToCommitments([]context.SerialisedPoly{polynomial})
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return toBytes48(comms[0])
+}
+
+// Spec: compute_kzg_proof
+func (s *Spec) ComputeKZGProof(blob Blob, zBytes Bytes32) (KZGProof, Bytes32, error) {
+	polynomial, err := s.blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	proof, _, y, err := s.ctx.ComputeKzgProof(polynomial, zBytes)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	proofBytes, err := toBytes48(proof)
+	return proofBytes, y, err
+}
+
+// Spec: verify_kzg_proof
+func (s *Spec) VerifyKZGProof(commitmentBytes Bytes48, zBytes Bytes32, yBytes Bytes32, proofBytes Bytes48) error {
+	return s.ctx.VerifyKZGProof(commitmentBytes[:], proofBytes[:], zBytes, yBytes)
+}
+
+// Spec: compute_blob_kzg_proof
+func (s *Spec) ComputeBlobKZGProof(blob Blob, commitmentBytes Bytes48) (KZGProof, error) {
+	polynomial, err := s.blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	proof, err := s.ctx.ComputeBlobKZGProof(polynomial, commitmentBytes[:])
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return toBytes48(proof)
+}
+
+// Spec: verify_blob_kzg_proof
+func (s *Spec) VerifyBlobKZGProof(blob Blob, commitmentBytes Bytes48, proofBytes Bytes48) error {
+	polynomial, err := s.blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	return s.ctx.VerifyBlobKZGProof(polynomial, commitmentBytes[:], proofBytes[:])
+}
+
+// Spec: compute_aggregate_kzg_proof
+func (s *Spec) ComputeAggregateKZGProof(blobs []Blob) (KZGProof, error) {
+	polynomials, err := s.blobsToPolynomials(blobs)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	proof, _, err := s.ctx.ComputeAggregateKzgProof(polynomials)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return toBytes48(proof)
+}
+
+// Spec: verify_aggregate_kzg_proof
+func (s *Spec) VerifyAggregateKZGProof(blobs []Blob, commitmentsBytes []Bytes48, aggregatedProofBytes Bytes48) error {
+	polynomials, err := s.blobsToPolynomials(blobs)
+	if err != nil {
+		return err
+	}
+	comms := make(context.SerialisedCommitments, len(commitmentsBytes))
+	for i := range commitmentsBytes {
+		comms[i] = commitmentsBytes[i][:]
+	}
+	return s.ctx.VerifyAggregateKzgProof(polynomials, aggregatedProofBytes[:], comms)
+}
+
+// Spec: blob_to_polynomial
+//
+// The scalars are copied, since the Context deserialises them in place.
+// Their canonicity is checked by the Context
+func (s *Spec) blobToPolynomial(blob Blob) (context.SerialisedPoly, error) {
+	if len(blob) != s.BytesPerBlob() {
+		return nil, ErrInvalidBlobSize
+	}
+	blobCopy := append([]byte{}, blob...)
+
+	polynomial := make(context.SerialisedPoly, len(blob)/BytesPerFieldElement)
+	for i := range polynomial {
+		polynomial[i] = blobCopy[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement]
+	}
+	return polynomial, nil
+}
+
+func (s *Spec) blobsToPolynomials(blobs []Blob) ([]context.SerialisedPoly, error) {
+	polynomials := make([]context.SerialisedPoly, len(blobs))
+	for i, blob := range blobs {
+		polynomial, err := s.blobToPolynomial(blob)
+		if err != nil {
+			return nil, err
+		}
+		polynomials[i] = polynomial
+	}
+	return polynomials, nil
+}
+
+func toBytes48(serPoint []byte) (Bytes48, error) {
+	var res Bytes48
+	if len(serPoint) != len(res) {
+		return res, errors.New("serialised point is not 48 bytes")
+	}
+	copy(res[:], serPoint)
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	context "github.com/crate-crypto/go-proto-danksharding-crypto"
+)
+
+func testBlob(s *Spec, offset uint64) Blob {
+	blob := make(Blob, 0, s.BytesPerBlob())
+	for i := 0; i < s.BytesPerBlob()/BytesPerFieldElement; i++ {
+		serScalar := context.EncodeScalarLE(fr.NewElement(offset + uint64(i)))
+		blob = append(blob, serScalar[:]...)
+	}
+	return blob
+}
+
+func TestSpecRoundTrip(t *testing.T) {
+	s := New(context.NewContextInsecure(4, 1234))
+
+	blob := testBlob(s, 1)
+	blobCopy := append(Blob{}, blob...)
+
+	commitment, err := s.BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob) != string(blobCopy) {
+		t.Fatal("blob was modified")
+	}
+
+	z := Bytes32(context.EncodeScalarLE(fr.NewElement(987)))
+	proof, y, err := s.ComputeKZGProof(blob, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifyKZGProof(commitment, z, y, proof); err != nil {
+		t.Error(err)
+	}
+
+	blobProof, err := s.ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifyBlobKZGProof(blob, commitment, blobProof); err != nil {
+		t.Error(err)
+	}
+
+	otherBlob := testBlob(s, 10)
+	otherCommitment, err := s.BlobToKZGCommitment(otherBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggProof, err := s.ComputeAggregateKZGProof([]Blob{blob, otherBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifyAggregateKZGProof([]Blob{blob, otherBlob}, []Bytes48{commitment, otherCommitment}, aggProof); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := s.BlobToKZGCommitment(blob[1:]); err != ErrInvalidBlobSize {
+		t.Error("expected an error for a blob of the wrong size")
+	}
+}
@@ -0,0 +1,125 @@
+package context
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// Hash tree roots, as defined in the SSZ spec, for the types which are
+// validated by this package:
+//
+//	Blob:          ByteVector[BYTES_PER_FIELD_ELEMENT * FIELD_ELEMENTS_PER_BLOB]
+//	KZGCommitment: Bytes48
+//	List[KZGCommitment, MAX_BLOBS_PER_BLOCK]
+//
+// The blob is hashed exactly as it is serialised, so each scalar is one chunk.
+
+const (
+	sszChunkSize      = 32
+	serialisedG1Size  = 48
+	sszMaxMerkleDepth = 64
+)
+
+var (
+	ErrSSZInvalidScalarSize     = errors.New("ssz blob contains a scalar which is not 32 bytes")
+	ErrSSZInvalidCommitmentSize = errors.New("ssz commitment is not 48 bytes")
+	ErrSSZTooManyCommitments    = errors.New("ssz commitment list is longer than its limit")
+)
+
+// zeroHashes[i] is the root of a tree of depth i whose leaves are all zero chunks
+var zeroHashes = computeZeroHashes()
+
+// Returns the hash tree root of a blob
+func HashTreeRootBlob(blob SerialisedPoly) ([32]byte, error) {
+	chunks := make([][32]byte, len(blob))
+	for i, serScalar := range blob {
+		if len(serScalar) != sszChunkSize {
+			return [32]byte{}, ErrSSZInvalidScalarSize
+		}
+		copy(chunks[i][:], serScalar)
+	}
+	return merkleize(chunks, uint64(len(chunks))), nil
+}
+
+// Returns the hash tree root of a commitment or a proof
+func HashTreeRootCommitment(comm KZGCommitment) ([32]byte, error) {
+	if len(comm) != serialisedG1Size {
+		return [32]byte{}, ErrSSZInvalidCommitmentSize
+	}
+	// 48 bytes are packed into two chunks, with the second one padded with zeroes
+	var chunks [2][32]byte
+	copy(chunks[0][:], comm[:sszChunkSize])
+	copy(chunks[1][:], comm[sszChunkSize:])
+	return hashPair(&chunks[0], &chunks[1]), nil
+}
+
+// Returns the hash tree root of a list of commitments, whose maximum length is `limit`.
+// This is the blob_kzg_commitments field of the beacon block body
+func HashTreeRootCommitments(comms SerialisedCommitments, limit uint64) ([32]byte, error) {
+	if uint64(len(comms)) > limit {
+		return [32]byte{}, ErrSSZTooManyCommitments
+	}
+
+	// 1. Compute the root of each commitment
+	roots := make([][32]byte, len(comms))
+	for i, comm := range comms {
+		root, err := HashTreeRootCommitment(comm)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		roots[i] = root
+	}
+
+	// 2. Merkleize the roots, padding up to the limit
+	root := merkleize(roots, limit)
+
+	// 3. Mix in the length of the list
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:8], uint64(len(comms)))
+	return hashPair(&root, &length), nil
+}
+
+// Computes the root of a binary merkle tree, whose leaves are the chunks
+// padded with zero chunks up to the next power of two of `limit`.
+//
+// The input slice is used as scratch space
+func merkleize(chunks [][32]byte, limit uint64) [32]byte {
+	depth := 0
+	for (uint64(1) << depth) < limit {
+		depth++
+	}
+
+	layer := chunks
+	for d := 0; d < depth; d++ {
+		// Pad odd layers with the root of an empty subtree of the same depth
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHashes[d])
+		}
+		next := layer[:len(layer)/2]
+		for i := 0; i < len(next); i++ {
+			next[i] = hashPair(&layer[2*i], &layer[2*i+1])
+		}
+		layer = next
+	}
+
+	if len(layer) == 0 {
+		return zeroHashes[depth]
+	}
+	return layer[0]
+}
+
+func hashPair(left, right *[32]byte) [32]byte {
+	var buf [64]byte
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+	return sha256.Sum256(buf[:])
+}
+
+func computeZeroHashes() [sszMaxMerkleDepth + 1][32]byte {
+	var hashes [sszMaxMerkleDepth + 1][32]byte
+	for i := 1; i < len(hashes); i++ {
+		hashes[i] = hashPair(&hashes[i-1], &hashes[i-1])
+	}
+	return hashes
+}
@@ -0,0 +1,70 @@
+package context
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func sha256Concat(left, right []byte) [32]byte {
+	return sha256.Sum256(append(append([]byte{}, left...), right...))
+}
+
+func TestHashTreeRootBlob(t *testing.T) {
+	blob := testSerialisedPoly(4, 3)
+
+	h01 := sha256Concat(blob[0], blob[1])
+	h23 := sha256Concat(blob[2], blob[3])
+	expected := sha256Concat(h01[:], h23[:])
+
+	got, err := HashTreeRootBlob(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Error("blob hash tree root is incorrect")
+	}
+
+	blob[1] = blob[1][:31]
+	if _, err := HashTreeRootBlob(blob); err != ErrSSZInvalidScalarSize {
+		t.Error("expected an error for a short scalar")
+	}
+}
+
+func TestHashTreeRootCommitments(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	comms, err := ctx.PolyToCommitments([]SerialisedPoly{testSerialisedPoly(4, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commRoot, err := HashTreeRootCommitment(comms[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCommRoot := sha256Concat(comms[0], make([]byte, 16))
+	if commRoot != expectedCommRoot {
+		t.Error("commitment hash tree root is incorrect")
+	}
+
+	// A list with a limit of 4 is a tree of depth 2, with the length mixed in
+	zero := make([]byte, 32)
+	zeroPair := sha256Concat(zero, zero)
+	left := sha256Concat(commRoot[:], zero)
+	body := sha256Concat(left[:], zeroPair[:])
+	length := make([]byte, 32)
+	binary.LittleEndian.PutUint64(length, 1)
+	expected := sha256Concat(body[:], length)
+
+	got, err := HashTreeRootCommitments(comms, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Error("commitment list hash tree root is incorrect")
+	}
+
+	if _, err := HashTreeRootCommitments(comms, 0); err != ErrSSZTooManyCommitments {
+		t.Error("expected an error when the list exceeds its limit")
+	}
+}
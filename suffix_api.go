@@ -0,0 +1,54 @@
+package context
+
+import (
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+// Creates a proof that every evaluation of the polynomial at an index >= suffixStart is zero.
+//
+// This allows a verifier to check the length of the payload inside of a padded blob, given
+// only its commitment.
+func (c *Context) ComputeZeroSuffixProof(serPoly SerialisedPoly, suffixStart uint64) (_ KZGProof, err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the polynomial
+	poly, err := deserialisePoly(serPoly)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Create the zero suffix proof
+	proof, err := kzg.ProveZeroSuffix(c.domain, poly, suffixStart, c.monomial())
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Serialise the quotient commitment
+	serProof := proof.QuotientComm.Bytes()
+	return serProof[:], nil
+}
+
+// Verifies a proof that every evaluation of the committed polynomial at an index >= suffixStart is zero.
+func (c *Context) VerifyZeroSuffixProof(polynomialKZG KZGCommitment, serProof KZGProof, suffixStart uint64) (err error) {
+	defer c.recoverPanic(&err)
+
+	// 1. Deserialise the commitment
+	polyComm, _, err := c.deserialiseCommitment(polynomialKZG)
+	if err != nil {
+		return err
+	}
+
+	// 2. Deserialise the quotient commitment
+	quotientComm, err := deserialisePoint(serProof)
+	if err != nil {
+		return err
+	}
+
+	proof := kzg.ZeroSuffixProof{
+		QuotientComm: quotientComm,
+	}
+	c.withCPUBudget(func() {
+		err = kzg.VerifyZeroSuffix(c.domain, &polyComm, &proof, suffixStart, c.monomial())
+	})
+	return err
+}
@@ -0,0 +1,48 @@
+package context
+
+import (
+	"sync"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/fiatshamir"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+)
+
+// Holds the trace of the most recent Fiat-Shamir transcript
+type transcriptTracer struct {
+	mu        sync.Mutex
+	lastTrace *fiatshamir.Trace
+}
+
+// Makes the Context record the Fiat-Shamir transcript of every aggregate proof
+// that it computes or verifies. The most recent one can be retrieved with LastTranscriptTrace.
+//
+// The transcript is hashed a second time to record it, so this is intended for
+// debugging challenges which differ between clients, and not for production.
+func WithTranscriptTrace() ContextOption {
+	return func(c *Context) {
+		c.transcriptTracer = &transcriptTracer{}
+	}
+}
+
+// Returns the trace of the most recent transcript, or nil if tracing is not
+// enabled or no aggregate proof has been computed or verified yet
+func (c *Context) LastTranscriptTrace() *fiatshamir.Trace {
+	if c.transcriptTracer == nil {
+		return nil
+	}
+	c.transcriptTracer.mu.Lock()
+	defer c.transcriptTracer.mu.Unlock()
+	return c.transcriptTracer.lastTrace
+}
+
+// Records the transcript for an aggregate proof over the polynomials, if tracing is enabled
+func (c *Context) traceTranscript(comms []kzg.Commitment, polys []kzg.Polynomial) {
+	if c.transcriptTracer == nil {
+		return
+	}
+	trace := c.protocol.TraceChallenges(comms, polys)
+
+	c.transcriptTracer.mu.Lock()
+	defer c.transcriptTracer.mu.Unlock()
+	c.transcriptTracer.lastTrace = trace
+}
@@ -0,0 +1,28 @@
+package context
+
+import "testing"
+
+func TestTranscriptTrace(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithTranscriptTrace())
+	if ctx.LastTranscriptTrace() != nil {
+		t.Error("no trace should be recorded before any proofs")
+	}
+
+	poly := testSerialisedPoly(4, 1)
+	proof, comms, err := ctx.ComputeAggregateKzgProof([]SerialisedPoly{copyPoly(poly)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proverTrace := ctx.LastTranscriptTrace().String()
+
+	if err := ctx.VerifyAggregateKzgProof([]SerialisedPoly{copyPoly(poly)}, proof, comms); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.LastTranscriptTrace().String() != proverTrace {
+		t.Error("prover and verifier traces should be identical")
+	}
+
+	if NewContextInsecure(4, 1234).LastTranscriptTrace() != nil {
+		t.Error("no trace should be recorded unless enabled")
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Splits [0, n) into a chunk for each CPU, and calls `work` on each chunk in its own goroutine.
+//
+// A panic in any of the goroutines is re-raised on the calling goroutine once every chunk has
+// finished, so that the caller can recover from it.
+func ParallelFor(n int, work func(start, end int)) {
+	ParallelForWithGoroutines(n, runtime.NumCPU(), work)
+}
+
+// Same as ParallelFor, except that [0, n) is split into at most `numGoroutines` chunks.
+// If numGoroutines is zero or negative, the number of CPUs is used
+func ParallelForWithGoroutines(n int, numGoroutines int, work func(start, end int)) {
+	if numGoroutines <= 0 {
+		numGoroutines = runtime.NumCPU()
+	}
+	if numGoroutines > n {
+		numGoroutines = n
+	}
+	if numGoroutines <= 1 {
+		work(0, n)
+		return
+	}
+	chunkSize := (n + numGoroutines - 1) / numGoroutines
+
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var panicValue interface{}
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicValue = r })
+				}
+			}()
+			work(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if panicValue != nil {
+		panic(panicValue)
+	}
+}
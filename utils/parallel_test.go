@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelForCoversRange(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 1000} {
+		for _, numGoroutines := range []int{0, 1, 3, 2000} {
+			visits := make([]int32, n)
+			ParallelForWithGoroutines(n, numGoroutines, func(start, end int) {
+				for i := start; i < end; i++ {
+					atomic.AddInt32(&visits[i], 1)
+				}
+			})
+			for i, v := range visits {
+				if v != 1 {
+					t.Fatalf("index %d of %d was visited %d times with %d goroutines", i, n, v, numGoroutines)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelForForwardsPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected the panic to reach the caller, got %v", r)
+		}
+	}()
+	ParallelFor(1000, func(start, end int) {
+		if start == 0 {
+			panic("boom")
+		}
+	})
+}
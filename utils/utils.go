@@ -2,9 +2,9 @@ package utils
 
 import (
 	"bytes"
-	"math"
 	"math/big"
 	"math/bits"
+	"runtime"
 
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 
@@ -37,6 +37,32 @@ func computePowers(x fr.Element, n uint) []fr.Element {
 	return powers
 }
 
+// Below this size, the overhead of spawning goroutines outweighs
+// the gain from computing the powers in parallel
+const parallelPowersThreshold = 1 << 12
+
+// Same as ComputePowers, except that for large `n` the powers are computed in parallel.
+//
+// The table is split into one chunk per CPU. The first power in each chunk is x^start,
+// which is computed with an exponentiation, then the rest of the chunk is filled in by multiplying by x.
+// Callers needing many powers of the same challenge should compute the table once
+// and reuse it, rather than calling this repeatedly.
+func ComputePowersParallel(x fr.Element, n uint) []fr.Element {
+	if n < parallelPowersThreshold || runtime.NumCPU() == 1 {
+		return ComputePowers(x, n)
+	}
+
+	powers := make([]fr.Element, n)
+	ParallelFor(int(n), func(start, end int) {
+		powers[start].Exp(x, new(big.Int).SetUint64(uint64(start)))
+		for i := start + 1; i < end; i++ {
+			powers[i].Mul(&powers[i-1], &x)
+		}
+	})
+
+	return powers
+}
+
 func IsPowerOfTwo(value uint64) bool {
 	return value > 0 && (value&(value-1) == 0)
 }
@@ -47,30 +73,6 @@ func ReverseArray(s *[32]uint8) {
 	}
 }
 
-// Raise an element to the power of two
-// exp is of the form 2^y
-// TODO: benchmark this versus using a .Exp which does not make the
-// TODO assumption of power of 2. I expect this to be about 30% faster
-// TODO: though since we are talking about nanoseconds, we can probably
-// TODO use the non optimised version, since its only a small percentage
-func Pow2(x fr.Element, exp uint64) *fr.Element {
-	if !IsPowerOfTwo(exp) {
-		// This can only happen if we stop using roots of unity whose domain is a power of 2
-		panic("The domain should always be a power of two, for our use-case")
-	}
-	pow := int(math.Log2(float64(exp)))
-	if pow == 0 {
-		one := fr.One()
-		return &one
-	}
-
-	var result = x
-	for i := 0; i < pow; i++ {
-		result.Square(&result)
-	}
-	return &result
-}
-
 func ReverseSlice(b []byte) {
 	last := len(b) - 1
 	for i := 0; i < len(b)/2; i++ {
@@ -91,6 +93,33 @@ func ReduceCanonical(serScalar []byte) (fr.Element, bool) {
 	return scalar, isCanon
 }
 
+// Same as ReduceCanonical, but over many encodings.
+// The flag at index i indicates whether serScalars[i] was canonical
+func ReduceCanonicalBatch(serScalars [][]byte) ([]fr.Element, []bool) {
+	scalars := make([]fr.Element, len(serScalars))
+	isCanon := make([]bool, len(serScalars))
+	reduceCanonicalInto(serScalars, scalars, isCanon)
+	return scalars, isCanon
+}
+
+// Same as ReduceCanonicalBatch, except that the encodings are split between
+// `numGoroutines` goroutines. If numGoroutines is zero, the number of CPUs is used
+func ReduceCanonicalBatchParallel(serScalars [][]byte, numGoroutines int) ([]fr.Element, []bool) {
+	scalars := make([]fr.Element, len(serScalars))
+	isCanon := make([]bool, len(serScalars))
+	ParallelForWithGoroutines(len(serScalars), numGoroutines, func(start, end int) {
+		reduceCanonicalInto(serScalars[start:end], scalars[start:end], isCanon[start:end])
+	})
+
+	return scalars, isCanon
+}
+
+func reduceCanonicalInto(serScalars [][]byte, scalars []fr.Element, isCanon []bool) {
+	for i := 0; i < len(serScalars); i++ {
+		scalars[i], isCanon[i] = ReduceCanonical(serScalars[i])
+	}
+}
+
 func BytesToBigIntCanonical(b *big.Int) bool {
 
 	// fast path
@@ -105,6 +134,33 @@ func BytesToBigIntCanonical(b *big.Int) bool {
 	return false
 }
 
+// Returns the position of the i'th element after applying the bit-reversal permutation
+// to a slice of length `size`. The permutation is its own inverse, so this also maps a
+// position back to the original index.
+// size must be a power of 2 and i must be less than size
+func BitReverseIndex(i, size uint64) uint64 {
+	if !IsPowerOfTwo(size) {
+		panic("size must be a power of two")
+	}
+	if i >= size {
+		panic("index must be less than the size")
+	}
+	return bits.Reverse64(i) >> (64 - bits.TrailingZeros64(size))
+}
+
+// Returns BitReverseIndex(i, size) for every i < size.
+// size must be a power of 2
+func BitReversedIndices(size uint64) []uint64 {
+	if !IsPowerOfTwo(size) {
+		panic("size must be a power of two")
+	}
+	indices := make([]uint64, size)
+	for i := uint64(0); i < size; i++ {
+		indices[i] = BitReverseIndex(i, size)
+	}
+	return indices
+}
+
 // BitReverse applies the bit-reversal permutation to a.
 // len(a) must be a power of 2
 // Taken and modified from gnark-crypto
@@ -124,6 +180,10 @@ func BitReverseRoots(a []fr.Element) {
 	}
 }
 
+// Same as BitReverseRoots, except over G1 points.
+// This converts an SRS in lagrange form between natural and bit-reversed order,
+// since the permutation is its own inverse.
+// len(a) must be a power of 2
 func BitReversePoints(a []curve.G1Affine) {
 	n := uint64(len(a))
 	if !IsPowerOfTwo(n) {
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"fmt"
 	"math"
 	"math/big"
 	"testing"
@@ -102,6 +103,25 @@ func TestComputePowersSmoke(t *testing.T) {
 	}
 }
 
+func TestComputePowersParallel(t *testing.T) {
+	var base fr.Element
+	base.SetInt64(123)
+
+	// Sizes below the threshold, and above it with a partial final chunk
+	for _, n := range []uint{0, 16, parallelPowersThreshold + 7} {
+		got := ComputePowersParallel(base, n)
+		expected := ComputePowers(base, n)
+		if len(got) != len(expected) {
+			t.Fatal("incorrect number of powers")
+		}
+		for i := range expected {
+			if !expected[i].Equal(&got[i]) {
+				t.Fatalf("incorrect power at index %d", i)
+			}
+		}
+	}
+}
+
 func TestReversal(t *testing.T) {
 	powInt := func(x, y int) int {
 		return int(math.Pow(float64(x), float64(y)))
@@ -132,11 +152,55 @@ func TestExponentiate(t *testing.T) {
 	var result fr.Element
 
 	result.Exp(base, big.NewInt(16))
-	res2 := Pow2(base, 16)
+	res2 := ExpPow2(base, 16)
 
 	if !res2.Equal(&result) {
 		t.Fail()
 	}
+
+	// x^1 is x
+	res3 := ExpPow2(base, 1)
+	if !res3.Equal(&base) {
+		t.Error("x^1 should be x")
+	}
+
+	exponents := []uint64{0, 1, 2, 3, 15, 16, 17, 4095, 4096, 0xdeadbeef, 1<<64 - 1}
+	for _, exp := range exponents {
+		expected := expBig(base, exp)
+		got := ExpUint64(base, exp)
+		if !got.Equal(&expected) {
+			t.Errorf("ExpUint64 is incorrect for exponent %d", exp)
+		}
+	}
+}
+
+func BenchmarkExpPow2(b *testing.B) {
+	base := fr.NewElement(123)
+	for i := 0; i < b.N; i++ {
+		ExpPow2(base, 4096)
+	}
+}
+
+func BenchmarkExpUint64(b *testing.B) {
+	base := fr.NewElement(123)
+	for _, exp := range []uint64{4095, 0xdeadbeef} {
+		b.Run(fmt.Sprintf("%d", exp), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ExpUint64(base, exp)
+			}
+		})
+	}
+}
+
+func BenchmarkExpBig(b *testing.B) {
+	base := fr.NewElement(123)
+	for _, exp := range []uint64{4095, 4096, 0xdeadbeef} {
+		b.Run(fmt.Sprintf("%d", exp), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				expBig(base, exp)
+			}
+		})
+	}
 }
 
 func TestBatchNormalisation(t *testing.T) {
@@ -213,6 +277,42 @@ func TestCanonicalEncoding(t *testing.T) {
 	}
 }
 
+func TestReduceCanonicalBatch(t *testing.T) {
+	numScalars := 10
+	serScalars := make([][]byte, numScalars)
+	for i := 0; i < numScalars; i++ {
+		x := randReducedBigInt()
+		if i%3 == 0 {
+			x = addModP(x)
+		}
+		var serScalar [32]byte
+		x.FillBytes(serScalar[:])
+		serScalars[i] = serScalar[:]
+	}
+
+	// Zero checks the sequential version
+	for _, numGoroutines := range []int{0, 1, 3, 20} {
+		scalars, isCanon := ReduceCanonicalBatch(serScalars)
+		if numGoroutines > 0 {
+			scalars, isCanon = ReduceCanonicalBatchParallel(serScalars, numGoroutines)
+		}
+		for i := 0; i < numScalars; i++ {
+			expected, expectedCanon := ReduceCanonical(serScalars[i])
+			if isCanon[i] != expectedCanon || isCanon[i] != (i%3 != 0) {
+				t.Fatalf("incorrect canonicality flag at index %d", i)
+			}
+			if !scalars[i].Equal(&expected) {
+				t.Fatalf("incorrect scalar at index %d", i)
+			}
+		}
+	}
+
+	scalars, isCanon := ReduceCanonicalBatchParallel(nil, 0)
+	if len(scalars) != 0 || len(isCanon) != 0 {
+		t.Error("expected an empty result for an empty batch")
+	}
+}
+
 func addModP(x big.Int) big.Int {
 	modulus := fr.Modulus()
 
@@ -243,3 +343,62 @@ func randomScalars(size int) []fr.Element {
 	}
 	return res
 }
+
+func TestBitReverseIndex(t *testing.T) {
+	expected := []uint64{0, 4, 2, 6, 1, 5, 3, 7}
+	indices := BitReversedIndices(8)
+	for i := uint64(0); i < 8; i++ {
+		if BitReverseIndex(i, 8) != expected[i] || indices[i] != expected[i] {
+			t.Errorf("bit-reversed index of %d should be %d", i, expected[i])
+		}
+	}
+
+	// The permutation should agree with BitReverseRoots
+	scalars := make([]fr.Element, 16)
+	for i := range scalars {
+		scalars[i].SetUint64(uint64(i))
+	}
+	BitReverseRoots(scalars)
+	for i := uint64(0); i < 16; i++ {
+		var expected fr.Element
+		expected.SetUint64(BitReverseIndex(i, 16))
+		if !scalars[i].Equal(&expected) {
+			t.Errorf("BitReverseIndex disagrees with BitReverseRoots at %d", i)
+		}
+	}
+
+	if BitReverseIndex(0, 1) != 0 {
+		t.Error("a slice of size one is unchanged by bit-reversal")
+	}
+}
+
+func TestBitReversePoints(t *testing.T) {
+	_, _, gen, _ := curve.Generators()
+	points := make([]curve.G1Affine, 8)
+	for i := range points {
+		points[i].ScalarMultiplication(&gen, big.NewInt(int64(i+1)))
+	}
+	original := append([]curve.G1Affine{}, points...)
+
+	BitReversePoints(points)
+	for i := uint64(0); i < 8; i++ {
+		if !points[i].Equal(&original[BitReverseIndex(i, 8)]) {
+			t.Errorf("point %d was not permuted correctly", i)
+		}
+	}
+
+	// The permutation is its own inverse
+	BitReversePoints(points)
+	for i := range points {
+		if !points[i].Equal(&original[i]) {
+			t.Errorf("point %d did not return to its original position", i)
+		}
+	}
+}
+
+// Returns x^exp, using fr.Element.Exp. This is what the helpers in exp.go are benchmarked against
+func expBig(x fr.Element, exp uint64) fr.Element {
+	var result fr.Element
+	result.Exp(x, new(big.Int).SetUint64(exp))
+	return result
+}
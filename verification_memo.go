@@ -0,0 +1,77 @@
+package context
+
+import "crypto/sha256"
+
+// Bounded LRU set of blob proofs which have already been verified successfully.
+//
+// A blob sidecar is usually verified when it arrives over gossip and again when the
+// block is imported, so remembering the result lets the second verification be skipped.
+// Failed verifications are never remembered.
+type verifiedProofCache struct {
+	lru *lruCache
+}
+
+func newVerifiedProofCache(capacity int) *verifiedProofCache {
+	return &verifiedProofCache{lru: newLRUCache(capacity)}
+}
+
+func (vc *verifiedProofCache) contains(key [32]byte) bool {
+	_, ok := vc.lru.get(key[:])
+	return ok
+}
+
+func (vc *verifiedProofCache) add(key [32]byte) {
+	vc.lru.add(key[:], nil)
+}
+
+func (vc *verifiedProofCache) len() int {
+	return vc.lru.len()
+}
+
+// Makes VerifyBlobKZGProof and VerifyBlobKZGProofPoly remember up to `capacity` successful verifications,
+// keyed by the hash of the blob, the commitment and the proof. Verifying the same blob, commitment and
+// proof again, with either method, returns immediately.
+//
+// A capacity of zero or less disables memoization.
+func WithVerificationMemo(capacity int) ContextOption {
+	return func(c *Context) {
+		if capacity <= 0 {
+			c.verifiedProofs = nil
+			return
+		}
+		c.verifiedProofs = newVerifiedProofCache(capacity)
+	}
+}
+
+// Returns the key for a blob proof, which is sha256(sha256(blob) || commitment || proof).
+//
+// Returns false if the inputs do not have the sizes of a valid blob, commitment and proof,
+// since the key would not uniquely identify them, for example a blob containing a 31 and a 33
+// byte scalar would have the same key as the blob with those bytes split evenly.
+func (c *Context) blobProofMemoKey(serPoly SerialisedPoly, polynomialKZG KZGCommitment, kzgProof KZGProof) ([32]byte, bool) {
+	if uint64(len(serPoly)) != c.domain.Cardinality {
+		return [32]byte{}, false
+	}
+	for _, serScalar := range serPoly {
+		if len(serScalar) != serialisedScalarSize {
+			return [32]byte{}, false
+		}
+	}
+	return blobProofMemoKeyFromHash(BlobHash(serPoly), polynomialKZG, kzgProof)
+}
+
+// Same as blobProofMemoKey, except that the blob has already been hashed with BlobHash
+func blobProofMemoKeyFromHash(blobHash [32]byte, polynomialKZG KZGCommitment, kzgProof KZGProof) ([32]byte, bool) {
+	if len(polynomialKZG) != serialisedG1Size || len(kzgProof) != serialisedG1Size {
+		return [32]byte{}, false
+	}
+
+	hasher := sha256.New()
+	hasher.Write(blobHash[:])
+	hasher.Write(polynomialKZG)
+	hasher.Write(kzgProof)
+
+	var key [32]byte
+	copy(key[:], hasher.Sum(nil))
+	return key, true
+}
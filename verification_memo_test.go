@@ -0,0 +1,93 @@
+package context
+
+import "testing"
+
+func TestVerificationMemo(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithVerificationMemo(4))
+
+	serPoly := testSerialisedPoly(4, 3)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ctx.VerifyBlobKZGProof(copyPoly(serPoly), comm, proof); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.verifiedProofs.len() != 1 {
+		t.Fatalf("expected 1 memoized proof, got %d", ctx.verifiedProofs.len())
+	}
+
+	// The blob is not deserialised when the proof is memoized, so it is left untouched
+	memoPoly := copyPoly(serPoly)
+	if err := ctx.VerifyBlobKZGProof(memoPoly, comm, proof); err != nil {
+		t.Fatal(err)
+	}
+	for i := range memoPoly {
+		if string(memoPoly[i]) != string(serPoly[i]) {
+			t.Fatal("memoized verification should not deserialise the blob")
+		}
+	}
+
+	// A different blob with the same commitment and proof is still verified, and fails
+	otherPoly := testSerialisedPoly(4, 4)
+	if err := ctx.VerifyBlobKZGProof(otherPoly, comm, proof); err == nil {
+		t.Error("proof should not verify for a different blob")
+	}
+	if ctx.verifiedProofs.len() != 1 {
+		t.Error("failed verifications should not be memoized")
+	}
+}
+
+func TestVerificationMemoPoly(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234, WithVerificationMemo(4))
+
+	serPoly := testSerialisedPoly(4, 3)
+	comm, proof, err := ctx.ComputeCommitmentAndBlobProof(copyPoly(serPoly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	poly, err := DeserialiseBlob(serPoly)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The polynomial and the serialised blob share the same memo key
+	if err := ctx.VerifyBlobKZGProofPoly(poly, BlobHash(serPoly), comm, proof); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.verifiedProofs.len() != 1 {
+		t.Fatalf("expected 1 memoized proof, got %d", ctx.verifiedProofs.len())
+	}
+	memoPoly := copyPoly(serPoly)
+	if err := ctx.VerifyBlobKZGProof(memoPoly, comm, proof); err != nil {
+		t.Fatal(err)
+	}
+	for i := range memoPoly {
+		if string(memoPoly[i]) != string(serPoly[i]) {
+			t.Fatal("the blob should have been found in the memo, instead of being deserialised")
+		}
+	}
+
+	// Failed verifications are not memoized
+	otherPoly, _ := DeserialiseBlob(testSerialisedPoly(4, 4))
+	if err := ctx.VerifyBlobKZGProofPoly(otherPoly, BlobHash(testSerialisedPoly(4, 4)), comm, proof); err == nil {
+		t.Error("proof should not verify for a different polynomial")
+	}
+	if ctx.verifiedProofs.len() != 1 {
+		t.Error("failed verifications should not be memoized")
+	}
+}
+
+func TestVerifiedProofCacheEviction(t *testing.T) {
+	cache := newVerifiedProofCache(1)
+	cache.add([32]byte{1})
+	cache.add([32]byte{2})
+
+	if cache.contains([32]byte{1}) {
+		t.Error("oldest key should have been evicted")
+	}
+	if !cache.contains([32]byte{2}) {
+		t.Error("newest key should be cached")
+	}
+}
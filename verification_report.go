@@ -0,0 +1,80 @@
+package context
+
+import "time"
+
+// Describes the work done by a single call to a batch verification method, it is returned
+// by the WithReport variants of those methods.
+//
+// This is intended for operators tuning the performance of a node, for example
+// to see whether time is spent deserialising points or computing pairings.
+// The methods without a report do not allocate one or read the clock for it.
+type VerificationReport struct {
+	// Number of blobs, or polynomials, that were verified
+	ItemsVerified int
+	// Number of items in each batch of pairings. Items that are verified
+	// together share a single pairing check
+	SubBatchSizes []int
+	// Time spent deserialising the blobs, commitments and proofs, including subgroup checks
+	DeserialisationTime time.Duration
+	// Time spent computing challenges, evaluations and pairings
+	VerificationTime time.Duration
+	// Number of commitments which did not need to be deserialised because they were cached
+	CacheHits int
+
+	// Start of the phase that is currently being timed
+	phaseStart time.Time
+	// Whether verification was reached, rather than the inputs failing to deserialise
+	finished bool
+}
+
+// Starts timing the deserialisation of a batch
+func newVerificationReport() *VerificationReport {
+	return &VerificationReport{phaseStart: time.Now()}
+}
+
+// Ends the deserialisation phase and starts timing the verification phase
+func (r *VerificationReport) endDeserialisation() {
+	if r == nil {
+		return
+	}
+	now := time.Now()
+	r.DeserialisationTime += now.Sub(r.phaseStart)
+	r.phaseStart = now
+}
+
+// Ends a verification phase, so that another batch can be deserialised
+func (r *VerificationReport) endVerification() {
+	if r == nil {
+		return
+	}
+	now := time.Now()
+	r.VerificationTime += now.Sub(r.phaseStart)
+	r.phaseStart = now
+}
+
+func (r *VerificationReport) addCacheHits(cacheHits int) {
+	if r == nil {
+		return
+	}
+	r.CacheHits += cacheHits
+}
+
+// Ends the verification phase and records the shape of the batch
+func (r *VerificationReport) finish(itemsVerified int, subBatchSizes ...int) {
+	if r == nil {
+		return
+	}
+	r.endVerification()
+	r.ItemsVerified = itemsVerified
+	r.SubBatchSizes = subBatchSizes
+	r.finished = true
+}
+
+// Returns the report if verification was reached, so that reports are not returned for inputs
+// which fail to deserialise
+func (r *VerificationReport) ifFinished() *VerificationReport {
+	if !r.finished {
+		return nil
+	}
+	return r
+}
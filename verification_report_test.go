@@ -0,0 +1,114 @@
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerificationReport(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+
+	numBlobs := 2
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numBlobs)
+
+	report, err := ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys), serComms, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report == nil {
+		t.Fatal("expected a report after batch verification")
+	}
+	if report.ItemsVerified != numBlobs {
+		t.Errorf("expected %d items verified, got %d", numBlobs, report.ItemsVerified)
+	}
+	if len(report.SubBatchSizes) != 1 || report.SubBatchSizes[0] != numBlobs {
+		t.Errorf("unexpected sub-batch sizes %v", report.SubBatchSizes)
+	}
+	if report.VerificationTime <= 0 {
+		t.Error("verification time should be recorded")
+	}
+
+	// A report is returned for invalid proofs, but not for inputs which fail to deserialise
+	report, err = ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys), serComms, []KZGProof{proofs[1], proofs[0]})
+	if err == nil {
+		t.Fatal("swapped proofs should not verify")
+	}
+	if report == nil {
+		t.Error("expected a report when the proofs are invalid")
+	}
+	report, err = ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys), serComms, []KZGProof{proofs[0], {1}})
+	if err == nil {
+		t.Fatal("malformed proof should not verify")
+	}
+	if report != nil {
+		t.Error("no report should be returned when deserialisation fails")
+	}
+}
+
+func TestVerificationReportsAreSeparate(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, 3)
+
+	// Concurrent calls each get the report for their own batch
+	sizes := []int{1, 2, 3}
+	reports := make([]*VerificationReport, len(sizes))
+	errs := make([]error, len(sizes))
+	done := make(chan struct{})
+	for i, size := range sizes {
+		go func(i, size int) {
+			reports[i], errs[i] = ctx.VerifyBlobKZGProofBatchWithReport(copyPolys(serPolys[:size]), serComms[:size], proofs[:size])
+			done <- struct{}{}
+		}(i, size)
+	}
+	for range sizes {
+		<-done
+	}
+	for i, size := range sizes {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if reports[i].ItemsVerified != size {
+			t.Errorf("expected the report for %d items, got %d", size, reports[i].ItemsVerified)
+		}
+	}
+}
+
+func TestAggregateVerificationReport(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	serPolys := []SerialisedPoly{testSerialisedPoly(4, 1), testSerialisedPoly(4, 5)}
+	proof, serComms, err := ctx.ComputeAggregateKzgProof(copyPolys(serPolys))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ctx.VerifyAggregateKzgProofWithReport(copyPolys(serPolys), proof, serComms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report == nil || report.ItemsVerified != len(serPolys) {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestDeadlineVerificationReport(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	numBlobs := 2*deadlineSubBatchSize + 1
+	serPolys, serComms, proofs := testBlobProofBatch(t, ctx, numBlobs)
+
+	numVerified, report, err := ctx.VerifyBlobKZGProofBatchWithDeadlineAndReport(time.Now().Add(time.Minute), copyPolys(serPolys), serComms, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numVerified != numBlobs || report.ItemsVerified != numBlobs {
+		t.Errorf("expected %d blobs to be verified, got %d", numBlobs, numVerified)
+	}
+	if len(report.SubBatchSizes) != 3 {
+		t.Errorf("expected 3 sub-batches, got %v", report.SubBatchSizes)
+	}
+
+	// Nothing is verified once the deadline has passed
+	_, report, err = ctx.VerifyBlobKZGProofBatchWithDeadlineAndReport(time.Now().Add(-time.Second), copyPolys(serPolys), serComms, proofs)
+	if err == nil || report != nil {
+		t.Error("expected no report when the deadline has already passed")
+	}
+}
@@ -0,0 +1,34 @@
+package context
+
+import (
+	"crypto/sha256"
+
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+// Version byte for versioned hashes of KZG commitments
+const VersionedHashVersionKZG = 0x01
+
+// Computes the versioned hash of a commitment, as referenced by blob transactions.
+//
+// The commitment is not deserialised, so this does not check that it is a valid point
+func KZGToVersionedHash(comm KZGCommitment) [32]byte {
+	versionedHash := sha256.Sum256(comm)
+	versionedHash[0] = VersionedHashVersionKZG
+	return versionedHash
+}
+
+// Computes the versioned hash of each commitment, in the same order as the commitments.
+// This is used when validating a block which references many blobs.
+//
+// The commitments are split between one goroutine per CPU. As with KZGToVersionedHash,
+// the commitments are not deserialised.
+func KZGToVersionedHashes(comms SerialisedCommitments) [][32]byte {
+	versionedHashes := make([][32]byte, len(comms))
+	utils.ParallelFor(len(comms), func(start, end int) {
+		for i := start; i < end; i++ {
+			versionedHashes[i] = KZGToVersionedHash(comms[i])
+		}
+	})
+	return versionedHashes
+}
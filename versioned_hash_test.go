@@ -0,0 +1,30 @@
+package context
+
+import (
+	"testing"
+)
+
+func TestKZGToVersionedHashes(t *testing.T) {
+	comms := make(SerialisedCommitments, 37)
+	for i := range comms {
+		comms[i] = make([]byte, serialisedG1Size)
+		comms[i][0] = byte(i)
+	}
+
+	versionedHashes := KZGToVersionedHashes(comms)
+	if len(versionedHashes) != len(comms) {
+		t.Fatalf("expected %d hashes, got %d", len(comms), len(versionedHashes))
+	}
+	for i, comm := range comms {
+		if versionedHashes[i] != KZGToVersionedHash(comm) {
+			t.Errorf("versioned hash %d is incorrect", i)
+		}
+		if versionedHashes[i][0] != VersionedHashVersionKZG {
+			t.Errorf("versioned hash %d has the wrong version", i)
+		}
+	}
+
+	if len(KZGToVersionedHashes(nil)) != 0 {
+		t.Error("expected no hashes for no commitments")
+	}
+}
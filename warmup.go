@@ -0,0 +1,70 @@
+package context
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/kzg"
+	"github.com/crate-crypto/go-proto-danksharding-crypto/utils"
+)
+
+var ErrWarmupKnownAnswer = errors.New("warmup known-answer check failed")
+
+// Runs the prover and verifier code paths once over a polynomial whose
+// commitment is known in advance.
+//
+// Latency sensitive callers can call this at startup, so that one-time costs
+// (lazily allocated tables in gnark, page faults on the SRS) are not
+// paid on the first real request. It also acts as a sanity check on the
+// loaded setup.
+func (c *Context) Warmup() (err error) {
+	defer c.recoverPanic(&err)
+
+	// The constant polynomial f(x) = 1 has evaluations 1 over the whole domain.
+	// Since the lagrange polynomials sum to one, its commitment is the G1 generator.
+	// The order of the roots does not matter for this polynomial.
+	poly := make(kzg.Polynomial, c.domain.Cardinality)
+	for i := 0; i < len(poly); i++ {
+		poly[i].SetOne()
+	}
+
+	// 1. Commit and check against the known answer
+	comm, err := kzg.Commit(poly, c.commitKey)
+	if err != nil {
+		return err
+	}
+	if !comm.Equal(&c.openKey.GenG1) {
+		c.logger.Debug("warmup commitment does not match the known answer")
+		return ErrWarmupKnownAnswer
+	}
+
+	// 2. Open at a point outside of the domain, f(a) should be one
+	//
+	// A point is in the domain if and only if a^n = 1
+	one := fr.One()
+	point := fr.NewElement(c.domain.Cardinality + 1)
+	for {
+		pointPowN := utils.ExpPow2(point, c.domain.Cardinality)
+		if !pointPowN.IsOne() {
+			break
+		}
+		point.Add(&point, &one)
+	}
+	proof, err := kzg.Open(c.domain, poly, point, c.commitKey)
+	if err != nil {
+		return err
+	}
+	if !proof.ClaimedValue.IsOne() {
+		c.logger.Debug("warmup evaluation does not match the known answer")
+		return ErrWarmupKnownAnswer
+	}
+
+	// 3. Verify the proof, this exercises the pairing code
+	err = kzg.Verify(comm, &proof, c.openKey)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug("warmup complete")
+	return nil
+}
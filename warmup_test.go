@@ -0,0 +1,10 @@
+package context
+
+import "testing"
+
+func TestWarmup(t *testing.T) {
+	ctx := NewContextInsecure(4, 1234)
+	if err := ctx.Warmup(); err != nil {
+		t.Error(err)
+	}
+}